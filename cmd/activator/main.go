@@ -222,6 +222,8 @@ func main() {
 	ah := activatorhandler.New(ctx, throttler, transport, networkConfig.EnableMeshPodAddressability, logger, tlsEnabled)
 	ah = concurrencyReporter.Handler(ah)
 	ah = activatorhandler.NewTracingHandler(ah)
+	mirrorHandler := activatorhandler.NewMirrorHandler(ctx, ah)
+	ah = pkghttp.BodyBufferHandler(mirrorHandler, activatorhandler.BodyBufferConfigFor)
 	reqLogHandler, err := pkghttp.NewRequestLogHandler(ah, logging.NewSyncFileWriter(os.Stdout), "",
 		requestLogTemplateInputGetter, false /*enableProbeRequestLog*/)
 	if err != nil {
@@ -233,6 +235,11 @@ func main() {
 	// the healthchecks or probes.
 	ah = activatorhandler.NewMetricHandler(env.PodName, ah)
 	ah = activatorhandler.NewContextHandler(ctx, ah, configStore)
+	// The copies of the mirrored requests are handled as new requests.
+	mirrorHandler.MirrorHandler = ah
+	// The requests split finer than the Ingress supports pick their revision
+	// before anything else.
+	ah = activatorhandler.NewSplitHandler(ctx, ah)
 
 	// Network probe handlers.
 	ah = &activatorhandler.ProbeHandler{NextHandler: ah}
@@ -38,6 +38,17 @@ func updateRequestLogFromConfigMap(logger *zap.SugaredLogger, h *pkghttp.Request
 		var newTemplate string
 		if obsconfig.EnableRequestLog {
 			newTemplate = obsconfig.RequestLogTemplate
+			// An invalid request log configuration keeps the current one,
+			// but doesn't hold back the template.
+			if rlCfg, err := pkghttp.NewRequestLogConfigFromConfigMap(configMap); err != nil {
+				logger.Errorw("Failed to get the request log configuration.", zap.Error(err), "configmap", configMap)
+			} else {
+				h.SetConfig(rlCfg)
+			}
+		} else {
+			// Structured request logs don't need a template, so they
+			// have to be turned off explicitly.
+			h.SetConfig(nil)
 		}
 		if err := h.SetTemplate(newTemplate); err != nil {
 			logger.Errorw("Failed to update the request log template.", zap.Error(err), "template", newTemplate)
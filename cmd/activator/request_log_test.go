@@ -88,6 +88,16 @@ func TestUpdateRequestLogFromConfigMap(t *testing.T) {
 			metrics.EnableReqLogKey:   "true",
 		},
 		want: "testRevision, testNs, testSvc, testConfig, , \n",
+	}, {
+		name: "invalid request log configuration",
+		url:  "http://example.com/testpage",
+		data: map[string]string{
+			metrics.ReqLogTemplateKey:       "{{.Request.Method}} {{.Request.URL}}\n",
+			metrics.EnableReqLogKey:         "true",
+			pkghttp.RequestLogFormatKey:     "xml",
+			pkghttp.RequestLogSampleRateKey: "2",
+		},
+		want: "POST http://example.com/testpage\n",
 	}, {
 		name: "empty template 2",
 		url:  "http://example.com/testpage",
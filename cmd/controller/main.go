@@ -40,6 +40,7 @@ var ctors = []injection.ControllerConstructor{
 	serverlessservice.NewController,
 	service.NewController,
 	gc.NewController,
+	gc.NewSweeperController,
 	nscert.NewController,
 }
 
@@ -36,8 +36,9 @@ import (
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
-	servingv1alpha1.SchemeGroupVersion.WithKind("DomainMapping"): &servingv1alpha1.DomainMapping{},
-	servingv1beta1.SchemeGroupVersion.WithKind("DomainMapping"):  &servingv1beta1.DomainMapping{},
+	servingv1alpha1.SchemeGroupVersion.WithKind("DomainMapping"):      &servingv1alpha1.DomainMapping{},
+	servingv1alpha1.SchemeGroupVersion.WithKind("DomainMappingGrant"): &servingv1alpha1.DomainMappingGrant{},
+	servingv1beta1.SchemeGroupVersion.WithKind("DomainMapping"):       &servingv1beta1.DomainMapping{},
 }
 
 func newDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -88,6 +88,12 @@ type config struct {
 	ServingEnableRequestLog      bool   `split_words:"true"` // optional
 	ServingEnableProbeRequestLog bool   `split_words:"true"` // optional
 
+	// Structured request log configuration
+	ServingRequestLogFormat          string `split_words:"true"` // optional
+	ServingRequestLogFields          string `split_words:"true"` // optional
+	ServingRequestLogSampleRate      string `split_words:"true"` // optional
+	ServingRequestLogErrorSampleRate string `split_words:"true"` // optional
+
 	// Metrics configuration
 	ServingNamespace             string `split_words:"true" required:"true"`
 	ServingRevision              string `split_words:"true" required:"true"`
@@ -173,7 +179,7 @@ func main() {
 	// Enable TLS when certificate is mounted.
 	tlsEnabled := exists(logger, certPath) && exists(logger, keyPath)
 
-	mainServer, drain := buildServer(ctx, env, probe, stats, logger, concurrencyendpoint, false)
+	mainServer, drain := buildServer(ctx, env, probe, stats, promStatReporter, logger, concurrencyendpoint, false)
 	httpServers := map[string]*http.Server{
 		"main":    mainServer,
 		"metrics": buildMetricsServer(promStatReporter, protoStatReporter),
@@ -188,7 +194,7 @@ func main() {
 	// See also https://github.com/knative/serving/issues/12808.
 	var tlsServers map[string]*http.Server
 	if tlsEnabled {
-		mainTLSServer, drain := buildServer(ctx, env, probe, stats, logger, concurrencyendpoint, true /* enable TLS */)
+		mainTLSServer, drain := buildServer(ctx, env, probe, stats, promStatReporter, logger, concurrencyendpoint, true /* enable TLS */)
 		tlsServers = map[string]*http.Server{
 			"tlsMain":  mainTLSServer,
 			"tlsAdmin": buildAdminServer(logger, drain),
@@ -265,7 +271,8 @@ func buildProbe(logger *zap.SugaredLogger, encodedProbe string, autodetectHTTP2
 	return readiness.NewProbe(coreProbe)
 }
 
-func buildServer(ctx context.Context, env config, probeContainer func() bool, stats *network.RequestStats, logger *zap.SugaredLogger,
+func buildServer(ctx context.Context, env config, probeContainer func() bool, stats *network.RequestStats,
+	promStatReporter *queue.PrometheusStatsReporter, logger *zap.SugaredLogger,
 	ce *queue.ConcurrencyEndpoint, enableTLS bool) (server *http.Server, drain func()) {
 	// TODO: If TLS is enabled, execute probes twice and tracking two different sets of container health.
 
@@ -273,13 +280,17 @@ func buildServer(ctx context.Context, env config, probeContainer func() bool, st
 
 	httpProxy := pkghttp.NewHeaderPruningReverseProxy(target, pkghttp.NoHostOverride, activator.RevisionHeaders, false /* use HTTP */)
 	httpProxy.Transport = buildTransport(env, logger)
-	httpProxy.ErrorHandler = pkghandler.Error(logger)
+	httpProxy.ErrorHandler = queue.GRPCErrorHandler(logger, pkghandler.Error(logger))
 	httpProxy.BufferPool = network.NewBufferPool()
 	httpProxy.FlushInterval = network.FlushInterval
 
-	// TODO: During HTTP and HTTPS transition, counting concurrency could not be accurate. Count accurately.
-	breaker := buildBreaker(logger, env)
 	metricsSupported := supportsMetrics(ctx, logger, env, enableTLS)
+	breakerReporters := []queue.BreakerReporter{promStatReporter}
+	if metricsSupported {
+		breakerReporters = append(breakerReporters, breakerMetricsReporter(logger, env)...)
+	}
+	// TODO: During HTTP and HTTPS transition, counting concurrency could not be accurate. Count accurately.
+	breaker := buildBreaker(logger, env, breakerReporters)
 	tracingEnabled := env.TracingConfigBackend != tracingconfig.None
 	concurrencyStateEnabled := env.ConcurrencyStateEndpoint != ""
 	firstByteTimeout := time.Duration(env.RevisionTimeoutSeconds) * time.Second
@@ -306,6 +317,10 @@ func buildServer(ctx context.Context, env config, probeContainer func() bool, st
 		composedHandler = requestAppMetricsHandler(logger, composedHandler, breaker, env)
 	}
 	composedHandler = queue.ProxyHandler(breaker, stats, tracingEnabled, composedHandler)
+	composedHandler = queue.GRPCTimeoutHandler(composedHandler)
+	composedHandler = queue.HeaderMutationsHandler(logger, composedHandler)
+	composedHandler = queue.PathRewriteHandler(logger, composedHandler)
+	composedHandler = queue.FaultHandler(logger, composedHandler)
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
 	composedHandler = handler.NewTimeoutHandler(composedHandler, "request timeout", firstByteTimeout, idleTimeout, maxDurationTimeout)
 
@@ -364,7 +379,7 @@ func buildTransport(env config, logger *zap.SugaredLogger) http.RoundTripper {
 	}
 }
 
-func buildBreaker(logger *zap.SugaredLogger, env config) *queue.Breaker {
+func buildBreaker(logger *zap.SugaredLogger, env config, reporters []queue.BreakerReporter) *queue.Breaker {
 	if env.ContainerConcurrency < 1 {
 		return nil
 	}
@@ -376,6 +391,7 @@ func buildBreaker(logger *zap.SugaredLogger, env config) *queue.Breaker {
 		QueueDepth:      queueDepth,
 		MaxConcurrency:  env.ContainerConcurrency,
 		InitialCapacity: env.ContainerConcurrency,
+		Reporters:       reporters,
 	}
 	logger.Infof("Queue container is starting with BreakerParams = %#v", params)
 	return queue.NewBreaker(params)
@@ -435,9 +451,32 @@ func requestLogHandler(logger *zap.SugaredLogger, currentHandler http.Handler, e
 		logger.Errorw("Error setting up request logger. Request logs will be unavailable.", zap.Error(err))
 		return currentHandler
 	}
+
+	rlCfg, err := pkghttp.NewRequestLogConfigFromMap(requestLogConfigMap(env))
+	if err != nil {
+		logger.Errorw("Error parsing request log configuration. Using the defaults.", zap.Error(err))
+	}
+	handler.SetConfig(rlCfg)
 	return handler
 }
 
+// requestLogConfigMap maps the request log environment back onto the
+// config-observability keys, skipping unset values so they get defaulted.
+func requestLogConfigMap(env config) map[string]string {
+	data := make(map[string]string, 4)
+	for key, value := range map[string]string{
+		pkghttp.RequestLogFormatKey:          env.ServingRequestLogFormat,
+		pkghttp.RequestLogFieldsKey:          env.ServingRequestLogFields,
+		pkghttp.RequestLogSampleRateKey:      env.ServingRequestLogSampleRate,
+		pkghttp.RequestLogErrorSampleRateKey: env.ServingRequestLogErrorSampleRate,
+	} {
+		if value != "" {
+			data[key] = value
+		}
+	}
+	return data
+}
+
 func requestMetricsHandler(logger *zap.SugaredLogger, currentHandler http.Handler, env config) http.Handler {
 	h, err := queue.NewRequestMetricsHandler(currentHandler, env.ServingNamespace,
 		env.ServingService, env.ServingConfiguration, env.ServingRevision, env.ServingPod)
@@ -458,6 +497,16 @@ func requestAppMetricsHandler(logger *zap.SugaredLogger, currentHandler http.Han
 	return h
 }
 
+func breakerMetricsReporter(logger *zap.SugaredLogger, env config) []queue.BreakerReporter {
+	r, err := queue.NewBreakerMetricsReporter(env.ServingNamespace,
+		env.ServingService, env.ServingConfiguration, env.ServingRevision, env.ServingPod)
+	if err != nil {
+		logger.Errorw("Error setting up queue metrics reporter. Queue metrics will be unavailable.", zap.Error(err))
+		return nil
+	}
+	return []queue.BreakerReporter{r}
+}
+
 func setupMetricsExporter(ctx context.Context, logger *zap.SugaredLogger, backend string, collectorAddress string) error {
 	// Set up OpenCensus exporter.
 	// NOTE: We use revision as the component instead of queue because queue is
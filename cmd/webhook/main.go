@@ -20,6 +20,9 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	resourcequotainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/resourcequota"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
@@ -39,6 +42,7 @@ import (
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	extravalidation "knative.dev/serving/pkg/webhook"
+	"knative.dev/serving/pkg/webhook/policy"
 
 	// config validation constructors
 	network "knative.dev/networking/pkg"
@@ -80,7 +84,8 @@ func newDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 	store := apisconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 	store.WatchConfigs(cmw)
 
-	return defaulting.NewAdmissionController(ctx,
+	// The warnings about the defaulted fields are returned to the clients.
+	return extravalidation.WithWarnings(defaulting.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
 		"webhook.serving.knative.dev",
@@ -96,7 +101,7 @@ func newDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 
 		// Whether to disallow unknown fields.
 		true,
-	)
+	))
 }
 
 func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -104,7 +109,28 @@ func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	store := apisconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 	store.WatchConfigs(cmw)
 
-	return validation.NewAdmissionController(ctx,
+	// The annotations of the Namespaces may override the features, and the
+	// max-scale of the revision templates is checked against the quotas,
+	// which the queue-proxy containers count against too.
+	namespaceLister := namespaceinformer.Get(ctx).Lister()
+	quotaLister := resourcequotainformer.Get(ctx).Lister()
+	deploymentStore := configmap.NewUntypedStore("deployment",
+		logging.FromContext(ctx).Named("deployment-config-store"),
+		configmap.Constructors{deployment.ConfigName: deployment.NewConfigFromConfigMap})
+	deploymentStore.WatchConfigs(cmw)
+	withContext := func(ctx context.Context) context.Context {
+		ctx = apisconfig.WithNamespaceAnnotations(store.ToContext(ctx), namespaceAnnotations(namespaceLister))
+		deploymentConfig, _ := deploymentStore.UntypedLoad(deployment.ConfigName).(*deployment.Config)
+		return extravalidation.WithResourceQuotas(ctx, quotaLister, deploymentConfig)
+	}
+
+	// The admission policies are evaluated against the validated resources.
+	policyStore := policy.NewStore(logging.FromContext(ctx).Named("policy-store"))
+	policyStore.WatchConfigs(cmw)
+
+	// The warnings about the deprecated and risky settings are returned to
+	// the clients.
+	return extravalidation.WithWarnings(policy.WithPolicies(validation.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
 		"validation.webhook.serving.knative.dev",
@@ -116,14 +142,26 @@ func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 		types,
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-		store.ToContext,
+		withContext,
 
 		// Whether to disallow unknown fields.
 		true,
 
 		// Extra validating callbacks to be applied to resources.
 		callbacks,
-	)
+	), policyStore.ToContext))
+}
+
+// namespaceAnnotations returns the annotations of the Namespaces found by the
+// lister. The Namespaces are not found until the informer synced.
+func namespaceAnnotations(lister corev1listers.NamespaceLister) func(string) (map[string]string, bool) {
+	return func(namespace string) (map[string]string, bool) {
+		ns, err := lister.Get(namespace)
+		if err != nil {
+			return nil, false
+		}
+		return ns.Annotations, true
+	}
 }
 
 func newConfigValidationController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -147,7 +185,9 @@ func newConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 			logging.ConfigMapName():        logging.NewConfigFromConfigMap,
 			leaderelection.ConfigMapName(): leaderelection.NewConfigFromConfigMap,
 			domainconfig.DomainConfigName:  domainconfig.NewDomainFromConfigMap,
+			domainconfig.RolloutConfigName: domainconfig.NewRolloutFromConfigMap,
 			apisconfig.DefaultsConfigName:  apisconfig.NewDefaultsConfigFromConfigMap,
+			policy.ConfigName:              policy.NewConfigFromConfigMap,
 		},
 	)
 }
@@ -23,13 +23,30 @@ const (
 	RevisionHeaderName = "Knative-Serving-Revision"
 	// RevisionHeaderNamespace is the header key for revision's namespace.
 	RevisionHeaderNamespace = "Knative-Serving-Namespace"
+	// MirrorHeaderName is the header key for the revisions a copy of the
+	// request is sent to. Its value is a comma separated list of
+	// `<revision>=<percent>` pairs, percent being the probability of the
+	// request being copied.
+	MirrorHeaderName = "Knative-Serving-Mirror"
+	// SplitHeaderName is the header key for the revisions the activator
+	// splits the request over, for the splits finer than the Ingress
+	// supports. Its value is a comma separated list of
+	// `<revision>=<weight>` pairs, weight being in basis points.
+	SplitHeaderName = "Knative-Serving-Split"
+	// RouteHeaderName is the header key for the Route whose revisions the
+	// activator splits the request over.
+	RouteHeaderName = "Knative-Serving-Route"
 )
 
 var (
 	// RevisionHeaders are the headers the activator uses to identify the
-	// revision. They are removed before reaching the user container.
+	// revision, its mirrors and its split. They are removed before reaching the user
+	// container.
 	RevisionHeaders = []string{
 		RevisionHeaderName,
 		RevisionHeaderNamespace,
+		MirrorHeaderName,
+		SplitHeaderName,
+		RouteHeaderName,
 	}
 )
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/atomic"
 	"knative.dev/pkg/configmap"
 	tracingconfig "knative.dev/pkg/tracing/config"
+	apisconfig "knative.dev/serving/pkg/apis/config"
 )
 
 type cfgKey struct{}
@@ -29,6 +30,8 @@ type cfgKey struct{}
 // Config is the configuration for the activator.
 type Config struct {
 	Tracing *tracingconfig.Config
+	// Defaults bound the request body buffers of the revisions.
+	Defaults *apisconfig.Defaults
 }
 
 // FromContext obtains a Config injected into the passed context.
@@ -51,15 +54,27 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 	// Append an update function to run after a ConfigMap has updated to update the
 	// current state of the Config.
 	onAfterStore = append(onAfterStore, func(_ string, _ interface{}) {
-		s.current.Store(&Config{
-			Tracing: s.UntypedLoad(tracingconfig.ConfigName).(*tracingconfig.Config).DeepCopy(),
-		})
+		// The ConfigMaps are loaded one by one.
+		tracing, ok := s.UntypedLoad(tracingconfig.ConfigName).(*tracingconfig.Config)
+		if !ok {
+			return
+		}
+		cfg := &Config{
+			Tracing: tracing.DeepCopy(),
+		}
+		if defaults, ok := s.UntypedLoad(apisconfig.DefaultsConfigName).(*apisconfig.Defaults); ok {
+			cfg.Defaults = defaults.DeepCopy()
+		} else {
+			cfg.Defaults, _ = apisconfig.NewDefaultsConfigFromMap(nil)
+		}
+		s.current.Store(cfg)
 	})
 	s.UntypedStore = configmap.NewUntypedStore(
 		"activator",
 		logger,
 		configmap.Constructors{
-			tracingconfig.ConfigName: tracingconfig.NewTracingConfigFromConfigMap,
+			tracingconfig.ConfigName:      tracingconfig.NewTracingConfigFromConfigMap,
+			apisconfig.DefaultsConfigName: apisconfig.NewDefaultsConfigFromConfigMap,
 		},
 		onAfterStore...,
 	)
@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ltesting "knative.dev/pkg/logging/testing"
 	tracingconfig "knative.dev/pkg/tracing/config"
+	apisconfig "knative.dev/serving/pkg/apis/config"
 )
 
 var tracingConfig = &corev1.ConfigMap{
@@ -35,10 +36,20 @@ var tracingConfig = &corev1.ConfigMap{
 	},
 }
 
+var defaultsConfig = &corev1.ConfigMap{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: apisconfig.DefaultsConfigName,
+	},
+	Data: map[string]string{
+		"request-body-buffer-max-size": "10Mi",
+	},
+}
+
 func TestStore(t *testing.T) {
 	logger := ltesting.TestLogger(t)
 	store := NewStore(logger)
 	store.OnConfigChanged(tracingConfig)
+	store.OnConfigChanged(defaultsConfig)
 
 	ctx := store.ToContext(context.Background())
 	cfg := FromContext(ctx)
@@ -46,6 +57,9 @@ func TestStore(t *testing.T) {
 	if got, want := cfg.Tracing.Backend, tracingconfig.None; got != want {
 		t.Fatalf("Tracing.Backend = %v, want %v", got, want)
 	}
+	if got, want := cfg.Defaults.RequestBodyBufferMaxSize.String(), "10Mi"; got != want {
+		t.Fatalf("Defaults.RequestBodyBufferMaxSize = %v, want %v", got, want)
+	}
 
 	newConfig := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -70,6 +84,7 @@ func BenchmarkStoreToContext(b *testing.B) {
 	logger := ltesting.TestLogger(b)
 	store := NewStore(logger)
 	store.OnConfigChanged(tracingConfig)
+	store.OnConfigChanged(defaultsConfig)
 
 	b.Run("sequential", func(b *testing.B) {
 		for j := 0; j < b.N; j++ {
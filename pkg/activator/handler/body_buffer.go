@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"net/http"
+
+	activatorconfig "knative.dev/serving/pkg/activator/config"
+	"knative.dev/serving/pkg/apis/serving"
+	pkghttp "knative.dev/serving/pkg/http"
+)
+
+// BodyBufferConfigFor returns the request body buffer configuration of the
+// revision the request is routed to, or nil if the revision doesn't buffer
+// request bodies. The configuration is bounded by the limits of the
+// operator. It must be called after the context handler attached the
+// revision and the configuration to the request.
+func BodyBufferConfigFor(r *http.Request) *pkghttp.BodyBufferConfig {
+	annos := RevisionFrom(r.Context()).Annotations
+	_, size, _ := serving.RequestBodyBufferSizeAnnotation.Get(annos)
+	_, mem, _ := serving.RequestBodyBufferMemoryAnnotation.Get(annos)
+	// The values are validated by the webhook, so we ignore errors.
+	cfg, _ := pkghttp.NewBodyBufferConfig(size, mem, pkghttp.BodyBufferDirectory)
+	if cfg == nil {
+		return nil
+	}
+
+	// The revisions admitted before the operator lowered the limits may
+	// still exceed them, and the buffers are shared by all revisions.
+	defaults := activatorconfig.FromContext(r.Context()).Defaults
+	if max := defaults.RequestBodyBufferMaxSize.Value(); cfg.MaxSize > max {
+		cfg.MaxSize = max
+	}
+	if max := defaults.RequestBodyBufferMaxMemory.Value(); cfg.MemorySize > max {
+		cfg.MemorySize = max
+	}
+	if cfg.MemorySize > cfg.MaxSize {
+		cfg.MemorySize = cfg.MaxSize
+	}
+	return cfg
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	"knative.dev/serving/pkg/apis/serving"
+	pkghttp "knative.dev/serving/pkg/http"
+)
+
+func TestBodyBufferConfigFor(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  *pkghttp.BodyBufferConfig
+	}{{
+		name: "not buffered",
+	}, {
+		name: "buffered",
+		annos: map[string]string{
+			serving.RequestBodyBufferSizeAnnotationKey:   "1Mi",
+			serving.RequestBodyBufferMemoryAnnotationKey: "4Ki",
+		},
+		want: &pkghttp.BodyBufferConfig{
+			MaxSize:    1 << 20,
+			MemorySize: 4 << 10,
+			Dir:        pkghttp.BodyBufferDirectory,
+		},
+	}, {
+		name: "above the limits",
+		annos: map[string]string{
+			serving.RequestBodyBufferSizeAnnotationKey:   "1Gi",
+			serving.RequestBodyBufferMemoryAnnotationKey: "512Mi",
+		},
+		want: &pkghttp.BodyBufferConfig{
+			MaxSize:    100 << 20,
+			MemorySize: 1 << 20,
+			Dir:        pkghttp.BodyBufferDirectory,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rev := revision(testNamespace, testRevName)
+			rev.Annotations = test.annos
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
+			ctx := setupConfigStore(t, logging.FromContext(req.Context())).ToContext(req.Context())
+			req = req.WithContext(WithRevisionAndID(ctx, rev,
+				types.NamespacedName{Namespace: testNamespace, Name: testRevName}))
+
+			if got := BodyBufferConfigFor(req); !cmp.Equal(got, test.want) {
+				t.Error("BodyBufferConfigFor (-want, +got):", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
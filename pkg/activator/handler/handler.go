@@ -19,6 +19,7 @@ package handler
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"strconv"
@@ -31,6 +32,7 @@ import (
 
 	network "knative.dev/networking/pkg"
 	"knative.dev/pkg/logging/logkey"
+	pkgnet "knative.dev/pkg/network"
 	pkghandler "knative.dev/pkg/network/handlers"
 	tracingconfig "knative.dev/pkg/tracing/config"
 	"knative.dev/pkg/tracing/propagation/tracecontextb3"
@@ -75,7 +77,32 @@ func New(_ context.Context, t Throttler, transport http.RoundTripper, usePassthr
 	}
 }
 
+// maxProxyAttempts is the number of times a request with a replayable body
+// is sent to the revision, if the connection to the pod can't be established.
+const maxProxyAttempts = 3
+
 func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	revID := RevIDFrom(r.Context())
+	for attempt := 1; ; attempt++ {
+		retry := a.try(w, r, revID, attempt < maxProxyAttempts)
+		if retry == nil {
+			return
+		}
+		// The pod went away before we could connect, try again with a fresh
+		// copy of the body.
+		if !pkghttp.ReplayBody(r) {
+			pkghandler.Error(a.logger.With(zap.String(logkey.Key, revID.String())))(w, r, retry)
+			return
+		}
+		a.logger.Debugw("Retrying request", zap.String(logkey.Key, revID.String()),
+			zap.Int("attempt", attempt), zap.Error(retry))
+	}
+}
+
+// try proxies the request to a pod picked by the throttler. It returns the
+// error that caused the proxying to fail if the request should be retried,
+// in which case nothing was written to w.
+func (a *activationHandler) try(w http.ResponseWriter, r *http.Request, revID types.NamespacedName, canRetry bool) error {
 	config := activatorconfig.FromContext(r.Context())
 	tracingEnabled := config.Tracing.Backend != tracingconfig.None
 
@@ -84,7 +111,7 @@ func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		tryContext, trySpan = trace.StartSpan(r.Context(), "throttler_try")
 	}
 
-	revID := RevIDFrom(r.Context())
+	var retry error
 	if err := a.throttler.Try(tryContext, revID, func(dest string) error {
 		trySpan.End()
 
@@ -92,7 +119,7 @@ func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if tracingEnabled {
 			proxyCtx, proxySpan = trace.StartSpan(r.Context(), "activator_proxy")
 		}
-		a.proxyRequest(revID, w, r.WithContext(proxyCtx), dest, tracingEnabled, a.usePassthroughLb)
+		retry = a.proxyRequest(revID, w, r.WithContext(proxyCtx), dest, tracingEnabled, a.usePassthroughLb, canRetry)
 		proxySpan.End()
 
 		return nil
@@ -109,10 +136,14 @@ func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
+	return retry
 }
 
+// proxyRequest proxies the request to the target. If canRetry is set and the
+// request body can be replayed, connection errors are returned rather than
+// written to w, so the request can be retried.
 func (a *activationHandler) proxyRequest(revID types.NamespacedName, w http.ResponseWriter,
-	r *http.Request, target string, tracingEnabled bool, usePassthroughLb bool) {
+	r *http.Request, target string, tracingEnabled bool, usePassthroughLb bool, canRetry bool) error {
 	network.RewriteHostIn(r)
 	r.Header.Set(network.ProxyHeaderName, activator.Name)
 
@@ -135,11 +166,25 @@ func (a *activationHandler) proxyRequest(revID types.NamespacedName, w http.Resp
 		proxy.Transport = a.tracingTransport
 	}
 	proxy.FlushInterval = network.FlushInterval
+
+	var retry error
 	proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		if canRetry && req.GetBody != nil && isDialError(err) {
+			retry = err
+			return
+		}
 		pkghandler.Error(a.logger.With(zap.String(logkey.Key, revID.String())))(w, req, err)
 	}
 
 	proxy.ServeHTTP(w, r)
+	return retry
+}
+
+// isDialError returns true if the error happened while connecting to the
+// pod, i.e. before anything of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, pkgnet.ErrTimeoutDialing) || (errors.As(err, &opErr) && opErr.Op == "dial")
 }
 
 // useSecurePort replaces the default port with HTTPS port (8112).
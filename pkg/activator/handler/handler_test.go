@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,6 +45,7 @@ import (
 	activatortest "knative.dev/serving/pkg/activator/testing"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/queue"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -151,6 +154,96 @@ func TestActivationHandler(t *testing.T) {
 	}
 }
 
+func TestActivationHandlerRetry(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	tests := []struct {
+		name      string
+		buffered  bool
+		noReplay  bool
+		failures  int
+		wantCode  int
+		wantCalls int
+	}{{
+		name:      "buffered body is retried",
+		buffered:  true,
+		failures:  2,
+		wantCode:  http.StatusOK,
+		wantCalls: 3,
+	}, {
+		name:      "retries are bounded",
+		buffered:  true,
+		failures:  maxProxyAttempts,
+		wantCode:  http.StatusBadGateway,
+		wantCalls: maxProxyAttempts,
+	}, {
+		name:      "body that can't be replayed",
+		buffered:  true,
+		noReplay:  true,
+		failures:  1,
+		wantCode:  http.StatusBadGateway,
+		wantCalls: 1,
+	}, {
+		name:      "streamed body is not retried",
+		failures:  1,
+		wantCode:  http.StatusBadGateway,
+		wantCalls: 1,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int
+			var gotBody string
+			rt := pkgnet.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls++
+				if calls <= test.failures {
+					return nil, dialErr
+				}
+				b, err := io.ReadAll(r.Body)
+				if err != nil {
+					return nil, err
+				}
+				gotBody = string(b)
+				return httptest.NewRecorder().Result(), nil
+			})
+
+			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+			defer cancel()
+			handler := New(ctx, fakeThrottler{}, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */)
+
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(wantBody))
+			if test.buffered {
+				release, err := pkghttp.BufferBody(req, &pkghttp.BodyBufferConfig{MaxSize: 1024, MemorySize: 1024})
+				if err != nil {
+					t.Fatal("BufferBody() =", err)
+				}
+				defer release()
+			}
+			if test.noReplay {
+				req.GetBody = func() (io.ReadCloser, error) {
+					return nil, errors.New("body is gone")
+				}
+			}
+
+			configStore := setupConfigStore(t, logging.FromContext(ctx))
+			ctx = configStore.ToContext(ctx)
+			ctx = WithRevisionAndID(ctx, nil, types.NamespacedName{Namespace: testNamespace, Name: testRevName})
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req.WithContext(ctx))
+
+			if resp.Code != test.wantCode {
+				t.Errorf("Unexpected response status. Want %d, got %d", test.wantCode, resp.Code)
+			}
+			if calls != test.wantCalls {
+				t.Errorf("Transport calls = %d, want: %d", calls, test.wantCalls)
+			}
+			if test.wantCode == http.StatusOK && gotBody != wantBody {
+				t.Errorf("Proxied body = %q, want: %q", gotBody, wantBody)
+			}
+		})
+	}
+}
+
 func TestActivationHandlerProxyHeader(t *testing.T) {
 	interceptCh := make(chan *http.Request, 1)
 	rt := pkgnet.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/semaphore"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/logging/logkey"
+	"knative.dev/serving/pkg/activator"
+	"knative.dev/serving/pkg/apis/serving"
+	revisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision"
+	servinglisters "knative.dev/serving/pkg/client/listers/serving/v1"
+)
+
+const (
+	// mirrorTimeout bounds the time a copy of a request may take, as nobody
+	// waits for its response.
+	mirrorTimeout = 5 * time.Minute
+
+	// maxMirrorsPerRequest is the number of copies of a request sent at most.
+	maxMirrorsPerRequest = 4
+
+	// maxConcurrentMirrors is the number of copies in flight at most. The
+	// requests are not copied while it is reached.
+	maxConcurrentMirrors = 256
+
+	// maxMirrorBodySize is the largest request body that is copied, as
+	// every copy holds its body in memory.
+	maxMirrorBodySize = 1 << 20
+)
+
+var (
+	errBodyNotReplayable = errors.New("the request body is not buffered")
+	errBodyTooLarge      = errors.New("the request body is too large to be copied")
+)
+
+// MirrorHandler sends a copy of a share of the requests to the shadow
+// revisions listed in their mirror header, and discards their responses.
+// It must be called after the context handler attached the revision to the
+// request, and after the request body was buffered.
+type MirrorHandler struct {
+	// NextHandler serves the requests.
+	NextHandler http.Handler
+
+	// MirrorHandler serves the copies of the requests. It is the entry
+	// point of the activator handlers, so the copies are accounted to the
+	// shadow revisions like any other request.
+	MirrorHandler http.Handler
+
+	Logger *zap.SugaredLogger
+
+	revisionLister servinglisters.RevisionLister
+	sem            *semaphore.Weighted
+}
+
+// NewMirrorHandler creates a MirrorHandler passing the requests on to next.
+// Its MirrorHandler must be set before it serves requests.
+func NewMirrorHandler(ctx context.Context, next http.Handler) *MirrorHandler {
+	return &MirrorHandler{
+		NextHandler:    next,
+		Logger:         logging.FromContext(ctx),
+		revisionLister: revisioninformer.Get(ctx).Lister(),
+		sem:            semaphore.NewWeighted(maxConcurrentMirrors),
+	}
+}
+
+func (h *MirrorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if header := r.Header.Get(activator.MirrorHeaderName); header != "" {
+		r.Header.Del(activator.MirrorHeaderName)
+		for _, revision := range h.mirrors(r, header) {
+			if !h.sem.TryAcquire(1) {
+				h.Logger.Debugw("Not mirroring request, too many copies in flight",
+					zap.String(logkey.Key, RevIDFrom(r.Context()).String()), zap.String("mirror", revision))
+				break
+			}
+			mr, err := mirrorRequest(r, revision)
+			if err != nil {
+				h.sem.Release(1)
+				h.Logger.Debugw("Not mirroring request", zap.String(logkey.Key, RevIDFrom(r.Context()).String()),
+					zap.String("mirror", revision), zap.Error(err))
+				continue
+			}
+			go func() {
+				defer h.sem.Release(1)
+				defer mr.cancel()
+				h.MirrorHandler.ServeHTTP(&discardResponseWriter{header: make(http.Header)}, mr.Request)
+			}()
+		}
+	}
+
+	h.NextHandler.ServeHTTP(w, r)
+}
+
+// mirrors returns the revisions of the mirror header sampled for the request.
+// The header is set by the Ingress, which clears it on the Routes without
+// mirrors, but the revisions are checked to belong to a Route of the
+// revision of the request all the same, each one once, up to
+// maxMirrorsPerRequest of them.
+func (h *MirrorHandler) mirrors(r *http.Request, header string) []string {
+	rev := RevisionFrom(r.Context())
+	routes := routesOf(rev.Annotations)
+	var ret []string
+	seen := sets.NewString()
+	for _, m := range strings.Split(header, ",") {
+		revision, percent := parseRevisionShare(m)
+		if revision == "" || revision == rev.Name || seen.Has(revision) {
+			continue
+		}
+		if seen.Len() == maxMirrorsPerRequest {
+			break
+		}
+		seen.Insert(revision)
+		shadow, err := h.revisionLister.Revisions(rev.Namespace).Get(revision)
+		if err != nil || !routes.HasAny(routesOf(shadow.Annotations).UnsortedList()...) {
+			h.Logger.Debugw("Ignoring mirror not routed to along the revision",
+				zap.String(logkey.Key, RevIDFrom(r.Context()).String()), zap.String("mirror", revision))
+			continue
+		}
+		if rand.Intn(100) < percent { //nolint:gosec // We don't need cryptographic randomness here.
+			ret = append(ret, revision)
+		}
+	}
+	return ret
+}
+
+// routesOf returns the Routes routing to the revision with the annotations.
+func routesOf(annotations map[string]string) sets.String {
+	routes := sets.NewString()
+	if v := annotations[serving.RoutesAnnotationKey]; v != "" {
+		routes.Insert(strings.Split(v, ",")...)
+	}
+	return routes
+}
+
+// parseRevisionShare parses a `<revision>=<share>` pair of the mirror and
+// split headers. It returns an empty revision if the pair is malformed.
+func parseRevisionShare(s string) (string, int) {
+	i := strings.LastIndex(s, "=")
+	if i < 1 {
+		return "", 0
+	}
+	percent, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return "", 0
+	}
+	return strings.TrimSpace(s[:i]), percent
+}
+
+type mirroredRequest struct {
+	*http.Request
+	cancel context.CancelFunc
+}
+
+// mirrorRequest copies the request, along with its body, for the revision.
+// The copy outlives the request, so it gets its own context and body.
+func mirrorRequest(r *http.Request, revision string) (*mirroredRequest, error) {
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		if r.GetBody == nil {
+			return nil, errBodyNotReplayable
+		}
+		if r.ContentLength > maxMirrorBodySize {
+			return nil, errBodyTooLarge
+		}
+		rc, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		// Read one byte past the limit to tell a body of exactly the limit
+		// apart from a larger one.
+		body, err = io.ReadAll(io.LimitReader(rc, maxMirrorBodySize+1))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(body) > maxMirrorBodySize {
+			return nil, errBodyTooLarge
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mirrorTimeout)
+	mr := r.Clone(ctx)
+	mr.Body = http.NoBody
+	if len(body) > 0 {
+		mr.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	mr.GetBody = nil
+	mr.ContentLength = int64(len(body))
+	mr.Header.Set(activator.RevisionHeaderName, revision)
+	mr.Header.Set(activator.RevisionHeaderNamespace, RevIDFrom(r.Context()).Namespace)
+	return &mirroredRequest{Request: mr, cancel: cancel}, nil
+}
+
+// discardResponseWriter is the http.ResponseWriter of the copies of the
+// requests, whose responses nobody reads.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(int) {}
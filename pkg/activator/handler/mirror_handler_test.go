@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	rtesting "knative.dev/pkg/reconciler/testing"
+	"knative.dev/serving/pkg/activator"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	pkghttp "knative.dev/serving/pkg/http"
+)
+
+func routedRevision(name string, routes string) *v1.Revision {
+	rev := revision(testNamespace, name)
+	rev.Annotations = map[string]string{serving.RoutesAnnotationKey: routes}
+	return rev
+}
+
+type mirroredCopy struct {
+	revision, namespace, mirror, body string
+}
+
+func TestMirrorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		mirrors  string
+		body     string
+		buffered bool
+		want     []mirroredCopy
+	}{{
+		name: "no mirrors",
+	}, {
+		name:    "without body",
+		mirrors: "shadow=100",
+		want: []mirroredCopy{{
+			revision:  "shadow",
+			namespace: testNamespace,
+		}},
+	}, {
+		name:     "buffered body",
+		mirrors:  "shadow=100, other-shadow=100",
+		body:     "the body",
+		buffered: true,
+		want: []mirroredCopy{{
+			revision:  "shadow",
+			namespace: testNamespace,
+			body:      "the body",
+		}, {
+			revision:  "other-shadow",
+			namespace: testNamespace,
+			body:      "the body",
+		}},
+	}, {
+		name:    "unbuffered body",
+		mirrors: "shadow=100",
+		body:    "the body",
+	}, {
+		name:    "never sampled",
+		mirrors: "shadow=0",
+	}, {
+		name:    "malformed",
+		mirrors: "shadow,=100",
+	}, {
+		name:    "not routed along",
+		mirrors: "stranger=100, missing=100",
+	}, {
+		name:    "revision itself",
+		mirrors: testRevName + "=100",
+	}, {
+		name:    "duplicates",
+		mirrors: "shadow=100,shadow=100",
+		want: []mirroredCopy{{
+			revision:  "shadow",
+			namespace: testNamespace,
+		}},
+	}, {
+		name:    "capped",
+		mirrors: "shadow=100,other-shadow=100,shadow-3=100,shadow-4=100,shadow-5=100",
+		want: []mirroredCopy{{
+			revision:  "shadow",
+			namespace: testNamespace,
+		}, {
+			revision:  "other-shadow",
+			namespace: testNamespace,
+		}, {
+			revision:  "shadow-3",
+			namespace: testNamespace,
+		}, {
+			revision:  "shadow-4",
+			namespace: testNamespace,
+		}},
+	}, {
+		name:     "body too large",
+		mirrors:  "shadow=100",
+		body:     strings.Repeat("x", maxMirrorBodySize+1),
+		buffered: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+			defer cancel()
+			rev := routedRevision(testRevName, "route,other-route")
+			revisionInformer(ctx, rev, routedRevision("shadow", "route"), routedRevision("other-shadow", "other-route"),
+				routedRevision("shadow-3", "route"), routedRevision("shadow-4", "route"),
+				routedRevision("shadow-5", "route"), routedRevision("stranger", "stranger-route"))
+
+			copies := make(chan mirroredCopy, maxMirrorsPerRequest)
+			var primary mirroredCopy
+			h := NewMirrorHandler(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				primary = mirroredCopy{
+					revision: r.Header.Get(activator.RevisionHeaderName),
+					mirror:   r.Header.Get(activator.MirrorHeaderName),
+					body:     string(b),
+				}
+			}))
+			h.MirrorHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				copies <- mirroredCopy{
+					revision:  r.Header.Get(activator.RevisionHeaderName),
+					namespace: r.Header.Get(activator.RevisionHeaderNamespace),
+					mirror:    r.Header.Get(activator.MirrorHeaderName),
+					body:      string(b),
+				}
+				w.Write([]byte("discarded"))
+			})
+
+			var body io.Reader
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", body)
+			req.Header.Set(activator.RevisionHeaderName, testRevName)
+			if test.mirrors != "" {
+				req.Header.Set(activator.MirrorHeaderName, test.mirrors)
+			}
+			req = req.WithContext(WithRevisionAndID(req.Context(), rev,
+				types.NamespacedName{Namespace: testNamespace, Name: testRevName}))
+			if test.buffered {
+				release, err := pkghttp.BufferBody(req, &pkghttp.BodyBufferConfig{MaxSize: 2 * maxMirrorBodySize, MemorySize: 2 * maxMirrorBodySize})
+				if err != nil {
+					t.Fatal("BufferBody() =", err)
+				}
+				defer release()
+			}
+
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			want := mirroredCopy{revision: testRevName, body: test.body}
+			if primary != want {
+				t.Errorf("Primary request = %+v, want: %+v", primary, want)
+			}
+			got := map[string]mirroredCopy{}
+			for range test.want {
+				select {
+				case c := <-copies:
+					got[c.revision] = c
+				case <-time.After(5 * time.Second):
+					t.Fatal("Timed out waiting for the mirrored copies")
+				}
+			}
+			for _, w := range test.want {
+				if got[w.revision] != w {
+					t.Errorf("Copy for %s = %+v, want: %+v", w.revision, got[w.revision], w)
+				}
+			}
+			select {
+			case c := <-copies:
+				t.Errorf("Unexpected copy %+v", c)
+			case <-time.After(50 * time.Millisecond):
+			}
+			if got := resp.Body.String(); got != "" {
+				t.Errorf("Response body = %q, want the responses of the copies discarded", got)
+			}
+		})
+	}
+}
+
+func TestMirrorHandlerTooManyInFlight(t *testing.T) {
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+	defer cancel()
+	rev := routedRevision(testRevName, "route")
+	revisionInformer(ctx, rev, routedRevision("shadow", "route"))
+
+	served := false
+	h := NewMirrorHandler(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+	}))
+	h.MirrorHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Unexpected copy of the request")
+	})
+	// All the copies are in flight already.
+	h.sem.TryAcquire(maxConcurrentMirrors)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set(activator.MirrorHeaderName, "shadow=100")
+	req = req.WithContext(WithRevisionAndID(req.Context(), rev,
+		types.NamespacedName{Namespace: testNamespace, Name: testRevName}))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !served {
+		t.Error("The request was not served")
+	}
+	time.Sleep(50 * time.Millisecond)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"knative.dev/serving/pkg/activator"
+	revisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision"
+	servinglisters "knative.dev/serving/pkg/client/listers/serving/v1"
+)
+
+// SplitHandler routes the requests with a split header to one of the
+// revisions it lists, picked in proportion to their weights. The Ingress
+// routes the shares of the Routes split finer than whole percents through
+// the activator, and clears the header of the other requests.
+// It must be called before the context handler reads the revision of the
+// request.
+type SplitHandler struct {
+	nextHandler    http.Handler
+	revisionLister servinglisters.RevisionLister
+}
+
+// NewSplitHandler creates a SplitHandler passing the requests on to next.
+func NewSplitHandler(ctx context.Context, next http.Handler) *SplitHandler {
+	return &SplitHandler{
+		nextHandler:    next,
+		revisionLister: revisioninformer.Get(ctx).Lister(),
+	}
+}
+
+func (h *SplitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header := r.Header.Get(activator.SplitHeaderName)
+	route := r.Header.Get(activator.RouteHeaderName)
+	r.Header.Del(activator.SplitHeaderName)
+	r.Header.Del(activator.RouteHeaderName)
+	if header != "" {
+		if revision := pickRevision(header, h.routedBy(r.Header.Get(activator.RevisionHeaderNamespace), route)); revision != "" {
+			r.Header.Set(activator.RevisionHeaderName, revision)
+		}
+	}
+
+	h.nextHandler.ServeHTTP(w, r)
+}
+
+// routedBy returns whether the revision of the namespace is routed to by
+// the Route.
+func (h *SplitHandler) routedBy(namespace, route string) func(string) bool {
+	return func(revision string) bool {
+		if route == "" {
+			return false
+		}
+		rev, err := h.revisionLister.Revisions(namespace).Get(revision)
+		return err == nil && routesOf(rev.Annotations).Has(route)
+	}
+}
+
+// pickRevision picks one of the revisions of the split header accepted by
+// valid at random, in proportion to their weights. It returns an empty
+// revision if the header has no such revision with a positive weight.
+func pickRevision(header string, valid func(string) bool) string {
+	var (
+		revisions []string
+		weights   []int
+		total     int
+	)
+	for _, s := range strings.Split(header, ",") {
+		revision, weight := parseRevisionShare(s)
+		if revision == "" || weight <= 0 || !valid(revision) {
+			continue
+		}
+		revisions = append(revisions, revision)
+		weights = append(weights, weight)
+		total += weight
+	}
+	if total == 0 {
+		return ""
+	}
+	n := rand.Intn(total) //nolint:gosec // We don't need cryptographic randomness here.
+	for i, w := range weights {
+		if n < w {
+			return revisions[i]
+		}
+		n -= w
+	}
+	// Unreachable, the weights sum to total.
+	return revisions[len(revisions)-1]
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	rtesting "knative.dev/pkg/reconciler/testing"
+	"knative.dev/serving/pkg/activator"
+)
+
+func TestSplitHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		route string
+		split string
+		want  sets.String
+	}{{
+		name: "no split",
+		want: sets.NewString(testRevName),
+	}, {
+		name:  "single revision",
+		route: "route",
+		split: "canary=10000",
+		want:  sets.NewString("canary"),
+	}, {
+		name:  "zero weights",
+		route: "route",
+		split: "stable=0, canary=5",
+		want:  sets.NewString("canary"),
+	}, {
+		name:  "split",
+		route: "route",
+		split: "stable=9995,canary=5",
+		want:  sets.NewString("stable", "canary"),
+	}, {
+		name:  "malformed",
+		route: "route",
+		split: "stable,=100",
+		want:  sets.NewString(testRevName),
+	}, {
+		name:  "revision of another route",
+		route: "route",
+		split: "stable=9995,stranger=5",
+		want:  sets.NewString("stable"),
+	}, {
+		name:  "without route",
+		split: "stable=9995,canary=5",
+		want:  sets.NewString(testRevName),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+			defer cancel()
+			revisionInformer(ctx, routedRevision("stable", "route"), routedRevision("canary", "other-route,route"),
+				routedRevision("stranger", "stranger-route"))
+
+			h := NewSplitHandler(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, name := range []string{activator.SplitHeaderName, activator.RouteHeaderName} {
+					if got, ok := r.Header[name]; ok {
+						t.Errorf("%s header = %q, want it removed", name, got)
+					}
+				}
+				w.Write([]byte(r.Header.Get(activator.RevisionHeaderName)))
+			}))
+
+			for i := 0; i < 10; i++ {
+				req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+				req.Header.Set(activator.RevisionHeaderName, testRevName)
+				req.Header.Set(activator.RevisionHeaderNamespace, testNamespace)
+				// The Ingress clears the header of the requests it splits itself.
+				req.Header.Set(activator.SplitHeaderName, test.split)
+				if test.route != "" {
+					req.Header.Set(activator.RouteHeaderName, test.route)
+				}
+				resp := httptest.NewRecorder()
+				h.ServeHTTP(resp, req)
+
+				if got := resp.Body.String(); !test.want.Has(got) {
+					t.Errorf("Revision = %s, want one of %v", got, test.want.List())
+				}
+			}
+		})
+	}
+}
+
+func TestPickRevision(t *testing.T) {
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[pickRevision("stable=7500,canary=2500", func(string) bool { return true })]++
+	}
+	// The picks are random, allow for a wide margin.
+	if got := counts["canary"]; got < 2000 || got > 3000 {
+		t.Errorf("canary was picked %d times out of 10000, want about 2500", got)
+	}
+	if got := counts["stable"] + counts["canary"]; got != 10000 {
+		t.Errorf("Picked %d known revisions out of 10000", got)
+	}
+}
@@ -219,6 +219,19 @@ func (pas *PodAutoscalerStatus) MarkSKSNotReady(mes string) {
 	podCondSet.Manage(pas).MarkUnknown(PodAutoscalerConditionSKSReady, "NotReady", mes)
 }
 
+// MarkScaleTargetLimitedByQuota marks the PA condition denoting that the
+// ResourceQuota keeps the scale target from scaling to the desired scale.
+func (pas *PodAutoscalerStatus) MarkScaleTargetLimitedByQuota(quota string, admitted, desired int32) {
+	podCondSet.Manage(pas).MarkTrueWithReason(PodAutoscalerConditionScaleTargetLimitedByQuota, "ExceededQuota",
+		"The ResourceQuota %q admits %d of the %d desired pods.", quota, admitted, desired)
+}
+
+// ClearScaleTargetLimitedByQuota removes the ScaleTargetLimitedByQuota
+// condition once the ResourceQuotas do not limit the scale target anymore.
+func (pas *PodAutoscalerStatus) ClearScaleTargetLimitedByQuota() {
+	podCondSet.Manage(pas).ClearCondition(PodAutoscalerConditionScaleTargetLimitedByQuota)
+}
+
 // GetCondition gets the condition `t`.
 func (pas *PodAutoscalerStatus) GetCondition(t apis.ConditionType) *apis.Condition {
 	return podCondSet.Manage(pas).GetCondition(t)
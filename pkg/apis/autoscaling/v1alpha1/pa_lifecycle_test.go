@@ -655,6 +655,32 @@ func TestMarkResourceFailedCreation(t *testing.T) {
 	}
 }
 
+func TestScaleTargetLimitedByQuota(t *testing.T) {
+	pa := &PodAutoscalerStatus{}
+	pa.InitializeConditions()
+	pa.MarkActive()
+	pa.MarkScaleTargetInitialized()
+	pa.MarkSKSReady()
+
+	pa.MarkScaleTargetLimitedByQuota("compute", 3, 5)
+	apistest.CheckConditionSucceeded(pa, PodAutoscalerConditionScaleTargetLimitedByQuota, t)
+	// The quota informs about the scale, but does not make the PA unready.
+	apistest.CheckConditionSucceeded(pa, PodAutoscalerConditionReady, t)
+	limited := pa.GetCondition(PodAutoscalerConditionScaleTargetLimitedByQuota)
+	if got, want := limited.Message, `The ResourceQuota "compute" admits 3 of the 5 desired pods.`; got != want {
+		t.Errorf("Message = %q, want: %q", got, want)
+	}
+	if limited.Severity != apis.ConditionSeverityInfo {
+		t.Errorf("Severity = %q, want: %q", limited.Severity, apis.ConditionSeverityInfo)
+	}
+
+	pa.ClearScaleTargetLimitedByQuota()
+	if got := pa.GetCondition(PodAutoscalerConditionScaleTargetLimitedByQuota); got != nil {
+		t.Error("ScaleTargetLimitedByQuota =", got)
+	}
+	apistest.CheckConditionSucceeded(pa, PodAutoscalerConditionReady, t)
+}
+
 func TestClass(t *testing.T) {
 	cases := []struct {
 		name string
@@ -108,6 +108,10 @@ const (
 	PodAutoscalerConditionActive apis.ConditionType = "Active"
 	// PodAutoscalerConditionSKSReady is set when SKS is ready.
 	PodAutoscalerConditionSKSReady = "SKSReady"
+	// PodAutoscalerConditionScaleTargetLimitedByQuota is set to True when the
+	// ResourceQuotas of the namespace keep the ScaleTargetRef from scaling to
+	// the desired scale.
+	PodAutoscalerConditionScaleTargetLimitedByQuota apis.ConditionType = "ScaleTargetLimitedByQuota"
 )
 
 // PodAutoscalerStatus communicates the observed state of the PodAutoscaler (from the controller).
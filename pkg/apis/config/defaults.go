@@ -67,6 +67,15 @@ const (
 var (
 	DefaultInitContainerNameTemplate = mustParseTemplate(DefaultInitContainerName)
 	DefaultUserContainerNameTemplate = mustParseTemplate(DefaultUserContainerName)
+
+	// DefaultRequestBodyBufferMaxSize is the largest request body buffer
+	// the revisions can configure, if not configured otherwise.
+	DefaultRequestBodyBufferMaxSize = resource.MustParse("100Mi")
+
+	// DefaultRequestBodyBufferMaxMemory is the largest in-memory part of the
+	// request body buffer the revisions can configure, if not configured
+	// otherwise.
+	DefaultRequestBodyBufferMaxMemory = resource.MustParse("1Mi")
 )
 
 func defaultDefaultsConfig() *Defaults {
@@ -79,9 +88,15 @@ func defaultDefaultsConfig() *Defaults {
 		ContainerConcurrencyMaxLimit:  DefaultMaxRevisionContainerConcurrency,
 		AllowContainerConcurrencyZero: DefaultAllowContainerConcurrencyZero,
 		EnableServiceLinks:            ptr.Bool(false),
+		RequestBodyBufferMaxSize:      quantityPtr(DefaultRequestBodyBufferMaxSize),
+		RequestBodyBufferMaxMemory:    quantityPtr(DefaultRequestBodyBufferMaxMemory),
 	}
 }
 
+func quantityPtr(q resource.Quantity) *resource.Quantity {
+	return &q
+}
+
 func asTriState(key string, target **bool, defValue *bool) cm.ParseFunc {
 	return func(data map[string]string) error {
 		if raw, ok := data[key]; ok {
@@ -120,6 +135,8 @@ func NewDefaultsConfigFromMap(data map[string]string) (*Defaults, error) {
 		cm.AsQuantity("revision-cpu-limit", &nc.RevisionCPULimit),
 		cm.AsQuantity("revision-memory-limit", &nc.RevisionMemoryLimit),
 		cm.AsQuantity("revision-ephemeral-storage-limit", &nc.RevisionEphemeralStorageLimit),
+		cm.AsQuantity("request-body-buffer-max-size", &nc.RequestBodyBufferMaxSize),
+		cm.AsQuantity("request-body-buffer-max-memory", &nc.RequestBodyBufferMaxMemory),
 	); err != nil {
 		return nil, err
 	}
@@ -135,6 +152,14 @@ func NewDefaultsConfigFromMap(data map[string]string) (*Defaults, error) {
 		return nil, apis.ErrOutOfBoundsValue(
 			nc.ContainerConcurrency, 0, nc.ContainerConcurrencyMaxLimit, "container-concurrency")
 	}
+	if nc.RequestBodyBufferMaxSize.Sign() <= 0 {
+		return nil, apis.ErrOutOfBoundsValue(
+			nc.RequestBodyBufferMaxSize.String(), "1", "+inf", "request-body-buffer-max-size")
+	}
+	if nc.RequestBodyBufferMaxMemory.Sign() < 0 || nc.RequestBodyBufferMaxMemory.Cmp(*nc.RequestBodyBufferMaxSize) > 0 {
+		return nil, apis.ErrOutOfBoundsValue(nc.RequestBodyBufferMaxMemory.String(),
+			"0", nc.RequestBodyBufferMaxSize.String(), "request-body-buffer-max-memory")
+	}
 	// Check that the templates properly apply to ObjectMeta.
 	if err := nc.UserContainerNameTemplate.Execute(io.Discard, metav1.ObjectMeta{}); err != nil {
 		return nil, fmt.Errorf("error executing template: %w", err)
@@ -181,6 +206,14 @@ type Defaults struct {
 	RevisionMemoryLimit             *resource.Quantity
 	RevisionEphemeralStorageRequest *resource.Quantity
 	RevisionEphemeralStorageLimit   *resource.Quantity
+
+	// RequestBodyBufferMaxSize is the largest request body buffer the
+	// revisions are permitted to configure.
+	RequestBodyBufferMaxSize *resource.Quantity
+
+	// RequestBodyBufferMaxMemory is the largest in-memory part of the
+	// request body buffer the revisions are permitted to configure.
+	RequestBodyBufferMaxMemory *resource.Quantity
 }
 
 func containerNameFromTemplate(ctx context.Context, tmpl *ObjectMetaTemplate) string {
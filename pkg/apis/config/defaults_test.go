@@ -58,6 +58,7 @@ func TestDefaultsConfigurationFromFile(t *testing.T) {
 
 func TestDefaultsConfiguration(t *testing.T) {
 	oneTwoThree := resource.MustParse("123m")
+	tenMi, oneKi := resource.MustParse("10Mi"), resource.MustParse("1Ki")
 
 	configTests := []struct {
 		name         string
@@ -80,6 +81,8 @@ func TestDefaultsConfiguration(t *testing.T) {
 			UserContainerNameTemplate:    mustParseTemplate("{{.Name}}"),
 			InitContainerNameTemplate:    mustParseTemplate("{{.Name}}"),
 			EnableServiceLinks:           ptr.Bool(true),
+			RequestBodyBufferMaxSize:     &tenMi,
+			RequestBodyBufferMaxMemory:   &oneKi,
 		},
 		data: map[string]string{
 			"revision-timeout-seconds":         "123",
@@ -90,6 +93,8 @@ func TestDefaultsConfiguration(t *testing.T) {
 			"init-container-name-template":     "{{.Name}}",
 			"allow-container-concurrency-zero": "false",
 			"enable-service-links":             "true",
+			"request-body-buffer-max-size":     "10Mi",
+			"request-body-buffer-max-memory":   "1Ki",
 		},
 	}, {
 		name:    "service links false",
@@ -102,6 +107,8 @@ func TestDefaultsConfiguration(t *testing.T) {
 			ContainerConcurrencyMaxLimit:  DefaultMaxRevisionContainerConcurrency,
 			AllowContainerConcurrencyZero: true,
 			EnableServiceLinks:            ptr.Bool(false),
+			RequestBodyBufferMaxSize:      &DefaultRequestBodyBufferMaxSize,
+			RequestBodyBufferMaxMemory:    &DefaultRequestBodyBufferMaxMemory,
 		},
 		data: map[string]string{
 			"enable-service-links": "false",
@@ -117,6 +124,8 @@ func TestDefaultsConfiguration(t *testing.T) {
 			ContainerConcurrencyMaxLimit:  DefaultMaxRevisionContainerConcurrency,
 			AllowContainerConcurrencyZero: true,
 			EnableServiceLinks:            nil,
+			RequestBodyBufferMaxSize:      &DefaultRequestBodyBufferMaxSize,
+			RequestBodyBufferMaxMemory:    &DefaultRequestBodyBufferMaxMemory,
 		},
 		data: map[string]string{
 			"enable-service-links": "default",
@@ -178,6 +187,19 @@ func TestDefaultsConfiguration(t *testing.T) {
 		data: map[string]string{
 			"container-concurrency-max-limit": "0",
 		},
+	}, {
+		name:    "request-body-buffer-max-size is invalid",
+		wantErr: true,
+		data: map[string]string{
+			"request-body-buffer-max-size": "0",
+		},
+	}, {
+		name:    "request-body-buffer-max-memory is bigger than request-body-buffer-max-size",
+		wantErr: true,
+		data: map[string]string{
+			"request-body-buffer-max-size":   "1Mi",
+			"request-body-buffer-max-memory": "2Mi",
+		},
 	}, {
 		name:    "different user and init container name template values",
 		wantErr: false,
@@ -189,6 +211,8 @@ func TestDefaultsConfiguration(t *testing.T) {
 			EnableServiceLinks:            ptr.Bool(false),
 			UserContainerNameTemplate:     mustParseTemplate("{{.Name}}"),
 			InitContainerNameTemplate:     mustParseTemplate("my-template"),
+			RequestBodyBufferMaxSize:      &DefaultRequestBodyBufferMaxSize,
+			RequestBodyBufferMaxMemory:    &DefaultRequestBodyBufferMaxMemory,
 		},
 		data: map[string]string{
 			"container-name-template":      "{{.Name}}",
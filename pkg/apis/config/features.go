@@ -30,6 +30,10 @@ const (
 	// FeaturesConfigName is the name of the ConfigMap for the features.
 	FeaturesConfigName = "config-features"
 
+	// FeaturesAnnotationPrefix is the prefix of the annotations of namespaces
+	// overriding the Allowed flags for the namespace.
+	FeaturesAnnotationPrefix = "features.knative.dev/"
+
 	// Enabled turns on an optional behavior.
 	Enabled Flag = "Enabled"
 	// Disabled turns off an optional behavior.
@@ -62,6 +66,8 @@ func defaultFeaturesConfig() *Features {
 		PodSpecDNSConfig:                 Disabled,
 		TagHeaderBasedRouting:            Disabled,
 		AutoDetectHTTP2:                  Disabled,
+		RouteFaultInjection:              Disabled,
+		WildcardCertificates:             Disabled,
 	}
 }
 
@@ -69,28 +75,12 @@ func defaultFeaturesConfig() *Features {
 func NewFeaturesConfigFromMap(data map[string]string) (*Features, error) {
 	nc := defaultFeaturesConfig()
 
-	if err := cm.Parse(data,
-		asFlag("multi-container", &nc.MultiContainer),
-		asFlag("kubernetes.podspec-affinity", &nc.PodSpecAffinity),
-		asFlag("kubernetes.podspec-topologyspreadconstraints", &nc.PodSpecTopologySpreadConstraints),
-		asFlag("kubernetes.podspec-dryrun", &nc.PodSpecDryRun),
-		asFlag("kubernetes.podspec-hostaliases", &nc.PodSpecHostAliases),
-		asFlag("kubernetes.podspec-fieldref", &nc.PodSpecFieldRef),
-		asFlag("kubernetes.podspec-nodeselector", &nc.PodSpecNodeSelector),
-		asFlag("kubernetes.podspec-runtimeclassname", &nc.PodSpecRuntimeClassName),
-		asFlag("kubernetes.podspec-securitycontext", &nc.PodSpecSecurityContext),
-		asFlag("kubernetes.podspec-priorityclassname", &nc.PodSpecPriorityClassName),
-		asFlag("kubernetes.podspec-schedulername", &nc.PodSpecSchedulerName),
-		asFlag("kubernetes.containerspec-addcapabilities", &nc.ContainerSpecAddCapabilities),
-		asFlag("kubernetes.podspec-tolerations", &nc.PodSpecTolerations),
-		asFlag("kubernetes.podspec-volumes-emptydir", &nc.PodSpecVolumesEmptyDir),
-		asFlag("kubernetes.podspec-init-containers", &nc.PodSpecInitContainers),
-		asFlag("kubernetes.podspec-persistent-volume-claim", &nc.PodSpecPersistentVolumeClaim),
-		asFlag("kubernetes.podspec-persistent-volume-write", &nc.PodSpecPersistentVolumeWrite),
-		asFlag("kubernetes.podspec-dnspolicy", &nc.PodSpecDNSPolicy),
-		asFlag("kubernetes.podspec-dnsconfig", &nc.PodSpecDNSConfig),
-		asFlag("tag-header-based-routing", &nc.TagHeaderBasedRouting),
-		asFlag("autodetect-http2", &nc.AutoDetectHTTP2)); err != nil {
+	flags := nc.flags()
+	parsers := make([]cm.ParseFunc, 0, len(flags))
+	for key, flag := range flags {
+		parsers = append(parsers, asFlag(key, flag))
+	}
+	if err := cm.Parse(data, parsers...); err != nil {
 		return nil, err
 	}
 	return nc, nil
@@ -124,6 +114,56 @@ type Features struct {
 	PodSpecDNSConfig                 Flag
 	TagHeaderBasedRouting            Flag
 	AutoDetectHTTP2                  Flag
+	RouteFaultInjection              Flag
+	WildcardCertificates             Flag
+}
+
+// flags returns the flags of the features by key.
+func (f *Features) flags() map[string]*Flag {
+	return map[string]*Flag{
+		"multi-container":                              &f.MultiContainer,
+		"kubernetes.podspec-affinity":                  &f.PodSpecAffinity,
+		"kubernetes.podspec-topologyspreadconstraints": &f.PodSpecTopologySpreadConstraints,
+		"kubernetes.podspec-dryrun":                    &f.PodSpecDryRun,
+		"kubernetes.podspec-hostaliases":               &f.PodSpecHostAliases,
+		"kubernetes.podspec-fieldref":                  &f.PodSpecFieldRef,
+		"kubernetes.podspec-nodeselector":              &f.PodSpecNodeSelector,
+		"kubernetes.podspec-runtimeclassname":          &f.PodSpecRuntimeClassName,
+		"kubernetes.podspec-securitycontext":           &f.PodSpecSecurityContext,
+		"kubernetes.podspec-priorityclassname":         &f.PodSpecPriorityClassName,
+		"kubernetes.podspec-schedulername":             &f.PodSpecSchedulerName,
+		"kubernetes.containerspec-addcapabilities":     &f.ContainerSpecAddCapabilities,
+		"kubernetes.podspec-tolerations":               &f.PodSpecTolerations,
+		"kubernetes.podspec-volumes-emptydir":          &f.PodSpecVolumesEmptyDir,
+		"kubernetes.podspec-init-containers":           &f.PodSpecInitContainers,
+		"kubernetes.podspec-persistent-volume-claim":   &f.PodSpecPersistentVolumeClaim,
+		"kubernetes.podspec-persistent-volume-write":   &f.PodSpecPersistentVolumeWrite,
+		"kubernetes.podspec-dnspolicy":                 &f.PodSpecDNSPolicy,
+		"kubernetes.podspec-dnsconfig":                 &f.PodSpecDNSConfig,
+		"tag-header-based-routing":                     &f.TagHeaderBasedRouting,
+		"autodetect-http2":                             &f.AutoDetectHTTP2,
+		"route-fault-injection":                        &f.RouteFaultInjection,
+		"wildcard-certificates":                        &f.WildcardCertificates,
+	}
+}
+
+// ForNamespace returns the features of a namespace with the annotations.
+// Its annotation "features.knative.dev/<key>" narrows the flag <key> into
+// Enabled or Disabled, if the flag is Allowed cluster-wide.
+func (f *Features) ForNamespace(annotations map[string]string) *Features {
+	nf := f.DeepCopy()
+	for key, flag := range nf.flags() {
+		if *flag != Allowed {
+			continue
+		}
+		switch raw := annotations[FeaturesAnnotationPrefix+key]; {
+		case strings.EqualFold(raw, string(Enabled)):
+			*flag = Enabled
+		case strings.EqualFold(raw, string(Disabled)):
+			*flag = Disabled
+		}
+	}
+	return nf
 }
 
 // asFlag parses the value at key as a Flag into the target, if it exists.
@@ -369,6 +369,24 @@ func TestFeaturesConfiguration(t *testing.T) {
 		data: map[string]string{
 			"tag-header-based-routing": "Enabled",
 		},
+	}, {
+		name:    "route-fault-injection Enabled",
+		wantErr: false,
+		wantFeatures: defaultWith(&Features{
+			RouteFaultInjection: Enabled,
+		}),
+		data: map[string]string{
+			"route-fault-injection": "Enabled",
+		},
+	}, {
+		name:    "wildcard-certificates Enabled",
+		wantErr: false,
+		wantFeatures: defaultWith(&Features{
+			WildcardCertificates: Enabled,
+		}),
+		data: map[string]string{
+			"wildcard-certificates": "Enabled",
+		},
 	}, {
 		name:    "kubernetes.podspec-volumes-emptyDir Disabled",
 		wantErr: false,
@@ -569,6 +587,39 @@ func TestFeaturesConfiguration(t *testing.T) {
 	}
 }
 
+func TestFeaturesForNamespace(t *testing.T) {
+	features := defaultWith(&Features{
+		PodSpecAffinity:        Allowed,
+		PodSpecSecurityContext: Allowed,
+		PodSpecDryRun:          Allowed,
+		PodSpecNodeSelector:    Disabled,
+		MultiContainer:         Enabled,
+	})
+
+	got := features.ForNamespace(map[string]string{
+		"features.knative.dev/kubernetes.podspec-affinity":        "enabled",
+		"features.knative.dev/kubernetes.podspec-securitycontext": "Disabled",
+		"features.knative.dev/kubernetes.podspec-dryrun":          "sometimes",
+		// The flags not Allowed cluster-wide cannot be overridden.
+		"features.knative.dev/kubernetes.podspec-nodeselector": "Enabled",
+		"features.knative.dev/multi-container":                 "Disabled",
+	})
+
+	want := defaultWith(&Features{
+		PodSpecAffinity:        Enabled,
+		PodSpecSecurityContext: Disabled,
+		PodSpecDryRun:          Allowed,
+		PodSpecNodeSelector:    Disabled,
+		MultiContainer:         Enabled,
+	})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("ForNamespace (-want, +got):\n", diff)
+	}
+	if features.PodSpecAffinity != Allowed {
+		t.Error("ForNamespace mutated the cluster-wide features")
+	}
+}
+
 // defaultWith returns the default *Feature patched with the provided *Features.
 func defaultWith(p *Features) *Features {
 	f := defaultFeaturesConfig()
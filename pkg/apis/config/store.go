@@ -26,6 +26,8 @@ import (
 
 type cfgKey struct{}
 
+type namespaceAnnotationsKey struct{}
+
 // Config holds the collection of configurations that we attach to contexts.
 type Config struct {
 	Defaults   *Defaults
@@ -70,6 +72,35 @@ func ToContext(ctx context.Context, c *Config) context.Context {
 	return context.WithValue(ctx, cfgKey{}, c)
 }
 
+// WithNamespaceAnnotations attaches to the provided context the function
+// returning the annotations of a namespace, if found, for WithinNamespace.
+func WithNamespaceAnnotations(ctx context.Context, annotations func(namespace string) (map[string]string, bool)) context.Context {
+	return context.WithValue(ctx, namespaceAnnotationsKey{}, annotations)
+}
+
+// WithinNamespace returns the provided context with the Features of its
+// Config overridden for the namespace, when the context holds the function
+// returning the annotations of the namespaces.
+func WithinNamespace(ctx context.Context, namespace string) context.Context {
+	annotations, ok := ctx.Value(namespaceAnnotationsKey{}).(func(string) (map[string]string, bool))
+	if !ok {
+		return ctx
+	}
+	if annos, found := annotations(namespace); found {
+		return WithNamespaceFeatures(ctx, annos)
+	}
+	return ctx
+}
+
+// WithNamespaceFeatures returns the provided context with the Features of its
+// Config overridden by the annotations of a namespace, see Features.ForNamespace.
+func WithNamespaceFeatures(ctx context.Context, annotations map[string]string) context.Context {
+	cfg := FromContextOrDefaults(ctx)
+	nc := *cfg
+	nc.Features = cfg.Features.ForNamespace(annotations)
+	return ToContext(ctx, &nc)
+}
+
 // Store is a typed wrapper around configmap.Untyped store to handle our configmaps.
 // +k8s:deepcopy-gen=false
 type Store struct {
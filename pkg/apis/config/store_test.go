@@ -123,3 +123,34 @@ func TestStoreImmutableConfig(t *testing.T) {
 		t.Error("Autoscaler config is not immutable")
 	}
 }
+
+func TestWithinNamespace(t *testing.T) {
+	features := defaultFeaturesConfig()
+	features.PodSpecAffinity = Allowed
+	ctx := ToContext(context.Background(), &Config{Features: features})
+
+	// Without the annotations of the namespaces, the features are cluster-wide.
+	if got := FromContext(WithinNamespace(ctx, "trusted")).Features.PodSpecAffinity; got != Allowed {
+		t.Errorf("PodSpecAffinity = %v, want: %v", got, Allowed)
+	}
+
+	ctx = WithNamespaceAnnotations(ctx, func(namespace string) (map[string]string, bool) {
+		if namespace != "trusted" {
+			return nil, false
+		}
+		return map[string]string{
+			FeaturesAnnotationPrefix + "kubernetes.podspec-affinity": "Enabled",
+		}, true
+	})
+	for namespace, want := range map[string]Flag{
+		"trusted": Enabled,
+		"unknown": Allowed,
+	} {
+		if got := FromContext(WithinNamespace(ctx, namespace)).Features.PodSpecAffinity; got != want {
+			t.Errorf("PodSpecAffinity of %q = %v, want: %v", namespace, got, want)
+		}
+	}
+	if got := FromContext(ctx).Features.PodSpecAffinity; got != Allowed {
+		t.Error("WithinNamespace mutated the config of the context")
+	}
+}
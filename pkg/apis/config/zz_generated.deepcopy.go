@@ -104,6 +104,16 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.RequestBodyBufferMaxSize != nil {
+		in, out := &in.RequestBodyBufferMaxSize, &out.RequestBodyBufferMaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RequestBodyBufferMaxMemory != nil {
+		in, out := &in.RequestBodyBufferMaxMemory, &out.RequestBodyBufferMaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
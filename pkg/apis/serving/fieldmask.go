@@ -347,6 +347,7 @@ func HandlerMask(in *corev1.ProbeHandler) *corev1.ProbeHandler {
 	out.Exec = in.Exec
 	out.HTTPGet = in.HTTPGet
 	out.TCPSocket = in.TCPSocket
+	out.GRPC = in.GRPC
 
 	return out
 
@@ -402,6 +403,22 @@ func TCPSocketActionMask(in *corev1.TCPSocketAction) *corev1.TCPSocketAction {
 	return out
 }
 
+// GRPCActionMask performs a _shallow_ copy of the Kubernetes GRPCAction object to a new
+// Kubernetes GRPCAction object bringing over only the fields allowed in the Knative API. This
+// does not validate the contents or the bounds of the provided fields.
+func GRPCActionMask(in *corev1.GRPCAction) *corev1.GRPCAction {
+	if in == nil {
+		return nil
+	}
+	out := new(corev1.GRPCAction)
+
+	// Allowed fields
+	out.Port = in.Port
+	out.Service = in.Service
+
+	return out
+}
+
 // ContainerPortMask performs a _shallow_ copy of the Kubernetes ContainerPort object to a new
 // Kubernetes ContainerPort object bringing over only the fields allowed in the Knative API. This
 // does not validate the contents or the bounds of the provided fields.
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GCDisabled is the value (-1) of the garbage collection settings, of the
+// config map and of the annotations, which are disabled.
+const GCDisabled = -1
+
+// gcDisabled is the setting which disables a garbage collection setting.
+const gcDisabled = "disabled"
+
+// ParseDisabledOrInt64 parses a non-negative integer, or "disabled" as
+// GCDisabled, into toSet. It keeps toSet when val is empty.
+func ParseDisabledOrInt64(val string, toSet *int64) error {
+	switch {
+	case val == "":
+		// keep default value
+	case strings.EqualFold(val, gcDisabled):
+		*toSet = GCDisabled
+	default:
+		parsed, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		*toSet = int64(parsed)
+	}
+	return nil
+}
+
+// ParseDisabledOrDuration parses a non-negative duration, or "disabled" as
+// GCDisabled, into toSet. It keeps toSet when val is empty.
+func ParseDisabledOrDuration(val string, toSet *time.Duration) error {
+	switch {
+	case val == "":
+		// keep default value
+	case strings.EqualFold(val, gcDisabled):
+		*toSet = time.Duration(GCDisabled)
+	default:
+		parsed, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		if parsed < 0 {
+			return errors.New("must be non-negative")
+		}
+		*toSet = parsed
+	}
+	return nil
+}
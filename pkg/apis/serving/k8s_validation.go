@@ -791,13 +791,20 @@ func validateProbe(p *corev1.Probe, port corev1.ContainerPort) *apis.FieldError
 			errs = errs.Also(apis.ErrInvalidValue(tcpPort.String(), "tcpSocket.port", "Probe port must match container port"))
 		}
 	}
+	if h.GRPC != nil {
+		handlers = append(handlers, "grpc")
+		errs = errs.Also(apis.CheckDisallowedFields(*h.GRPC, *GRPCActionMask(h.GRPC))).ViaField("grpc")
+		if grpcPort := h.GRPC.Port; grpcPort < 1 || grpcPort > 65535 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(grpcPort, 1, 65535, "grpc.port"))
+		}
+	}
 	if h.Exec != nil {
 		handlers = append(handlers, "exec")
 		errs = errs.Also(apis.CheckDisallowedFields(*h.Exec, *ExecActionMask(h.Exec))).ViaField("exec")
 	}
 
 	if len(handlers) == 0 {
-		errs = errs.Also(apis.ErrMissingOneOf("httpGet", "tcpSocket", "exec"))
+		errs = errs.Also(apis.ErrMissingOneOf("httpGet", "tcpSocket", "grpc", "exec"))
 	} else if len(handlers) > 1 {
 		errs = errs.Also(apis.ErrMultipleOneOf(handlers...))
 	}
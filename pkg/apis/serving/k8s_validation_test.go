@@ -1542,7 +1542,7 @@ func TestContainerValidation(t *testing.T) {
 					ProbeHandler: corev1.ProbeHandler{},
 				},
 			},
-			want: apis.ErrMissingOneOf("livenessProbe.httpGet", "livenessProbe.tcpSocket", "livenessProbe.exec"),
+			want: apis.ErrMissingOneOf("livenessProbe.httpGet", "livenessProbe.tcpSocket", "livenessProbe.grpc", "livenessProbe.exec"),
 		}, {
 			name: "invalid with multiple handlers",
 			c: corev1.Container{
@@ -1580,6 +1580,49 @@ func TestContainerValidation(t *testing.T) {
 				},
 			},
 			want: nil,
+		}, {
+			name: "valid readiness grpc probe",
+			c: corev1.Container{
+				Image: "foo",
+				ReadinessProbe: &corev1.Probe{
+					PeriodSeconds:    1,
+					TimeoutSeconds:   1,
+					SuccessThreshold: 1,
+					FailureThreshold: 3,
+					ProbeHandler: corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{
+							Port:    8080,
+							Service: ptr.String("foo.Service"),
+						},
+					},
+				},
+			},
+			want: nil,
+		}, {
+			name: "invalid grpc probe port",
+			c: corev1.Container{
+				Image: "foo",
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{
+							Port: 70000,
+						},
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(70000, 1, 65535, "livenessProbe.grpc.port"),
+		}, {
+			name: "missing grpc probe port",
+			c: corev1.Container{
+				Image: "foo",
+				ReadinessProbe: &corev1.Probe{
+					SuccessThreshold: 1,
+					ProbeHandler: corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{},
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(0, 1, 65535, "readinessProbe.grpc.port"),
 		}, {
 			name: "valid liveness tcp probe with a different container port",
 			c: corev1.Container{
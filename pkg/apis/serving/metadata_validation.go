@@ -19,13 +19,16 @@ package serving
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmap"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/config"
 )
@@ -75,6 +78,104 @@ func ValidateRolloutDurationAnnotation(annos map[string]string) (errs *apis.Fiel
 	return errs
 }
 
+// ValidateRolloutAnalysisAnnotations validates the annotations overriding the
+// limits the rollout of the latest revision is gated on.
+// These annotations can be set on either service or route objects.
+func ValidateRolloutAnalysisAnnotations(annos map[string]string) (errs *apis.FieldError) {
+	if k, v, _ := RolloutMaxErrorRateAnnotation.Get(annos); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		} else if f < 0 || f > 1 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(v, 0, 1, k))
+		}
+	}
+	if k, v, _ := RolloutMaxLatencyAnnotation.Get(annos); v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		} else if d < 0 {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("rollout-max-latency=%s must not be negative", v),
+				Paths:   []string{k},
+			})
+		}
+	}
+	return errs
+}
+
+// ValidateRolloutStepsAnnotations validates the annotations specifying and
+// approving a custom rollout schedule.
+// These annotations can be set on either service or route objects.
+func ValidateRolloutStepsAnnotations(annos map[string]string) (errs *apis.FieldError) {
+	if k, v, _ := RolloutStepsAnnotation.Get(annos); v != "" {
+		if _, err := ParseRolloutSteps(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	if k, v, _ := RolloutApprovedAnnotation.Get(annos); v != "" {
+		if _, _, err := ParseRolloutApproval(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ValidateRolloutControlAnnotation validates the annotation controlling the
+// rollouts in progress.
+// This annotation can be set on either service or route objects.
+func ValidateRolloutControlAnnotation(annos map[string]string) *apis.FieldError {
+	if k, v, _ := RolloutControlAnnotation.Get(annos); v != "" {
+		switch v {
+		case RolloutControlPause, RolloutControlResume, RolloutControlAbort:
+		default:
+			return apis.ErrInvalidValue(v, k,
+				fmt.Sprintf("must be one of %s, %s or %s", RolloutControlPause, RolloutControlResume, RolloutControlAbort))
+		}
+	}
+	return nil
+}
+
+// ValidateGCAnnotations validates the annotations overriding the garbage
+// collection of the Revisions of a Configuration.
+// These annotations can be set on either service or configuration objects.
+func ValidateGCAnnotations(annos map[string]string) (errs *apis.FieldError) {
+	for _, anno := range []kmap.KeyPriority{GCRetainSinceCreateTimeAnnotation, GCRetainSinceLastActiveTimeAnnotation} {
+		if k, v, _ := anno.Get(annos); v != "" {
+			var d time.Duration
+			if err := ParseDisabledOrDuration(v, &d); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+			}
+		}
+	}
+
+	var min, max int64 = 0, GCDisabled
+	minKey, minVal, _ := GCMinNonActiveRevisionsAnnotation.Get(annos)
+	if minVal != "" {
+		if v, err := strconv.ParseUint(minVal, 10, 64); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(minVal, minKey, err.Error()))
+		} else {
+			min = int64(v)
+		}
+	}
+	if k, v, _ := GCMaxNonActiveRevisionsAnnotation.Get(annos); v != "" {
+		if err := ParseDisabledOrInt64(v, &max); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	if max != GCDisabled && min > max {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("gc-min-non-active-revisions=%d must not exceed gc-max-non-active-revisions=%d", min, max),
+			Paths:   []string{minKey},
+		})
+	}
+
+	if k, v, _ := GCProtectedRevisionsAnnotation.Get(annos); v != "" {
+		if _, err := labels.Parse(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	return errs
+}
+
 // ValidateHasNoAutoscalingAnnotation validates that the respective entity does not have
 // annotations from the autoscaling group. It's to be used to validate Service and
 // Configuration.
@@ -490,3 +490,175 @@ func TestValidateRolloutDurationAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRolloutAnalysisAnnotations(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  string
+	}{{
+		name: "empty",
+	}, {
+		name: "valid",
+		annos: map[string]string{
+			RolloutMaxErrorRateKey: "0.01",
+			RolloutMaxLatencyKey:   "300ms",
+		},
+	}, {
+		name: "disabled latency",
+		annos: map[string]string{
+			RolloutMaxLatencyKey: "0s",
+		},
+	}, {
+		name: "error rate not a number",
+		annos: map[string]string{
+			RolloutMaxErrorRateKey: "one percent",
+		},
+		want: "invalid value: one percent: serving.knative.dev/rollout-max-error-rate",
+	}, {
+		name: "error rate out of bounds",
+		annos: map[string]string{
+			RolloutMaxErrorRateKey: "5",
+		},
+		want: "expected 0 <= 5 <= 1: serving.knative.dev/rollout-max-error-rate",
+	}, {
+		name: "latency not a duration",
+		annos: map[string]string{
+			RolloutMaxLatencyKey: "fast",
+		},
+		want: "invalid value: fast: serving.knative.dev/rollout-max-latency",
+	}, {
+		name: "negative latency",
+		annos: map[string]string{
+			RolloutMaxLatencyKey: "-1s",
+		},
+		want: "rollout-max-latency=-1s must not be negative: serving.knative.dev/rollout-max-latency",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRolloutAnalysisAnnotations(tc.annos)
+			if got, want := err.Error(), tc.want; got != want {
+				t.Errorf("APIErr mismatch, diff(-want,+got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestValidateGCAnnotations(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  string
+	}{{
+		name: "empty",
+	}, {
+		name: "valid",
+		annos: map[string]string{
+			GCRetainSinceCreateTimeKey:     "168h",
+			GCRetainSinceLastActiveTimeKey: "disabled",
+			GCMinNonActiveRevisionsKey:     "10",
+			GCMaxNonActiveRevisionsKey:     "disabled",
+			GCProtectedRevisionsKey:        "release in (stable,previous)",
+		},
+	}, {
+		name: "retention not a duration",
+		annos: map[string]string{
+			GCRetainSinceCreateTimeKey: "a week",
+		},
+		want: "invalid value: a week: serving.knative.dev/gc-retain-since-create-time\ntime: invalid duration \"a week\"",
+	}, {
+		name: "negative retention",
+		annos: map[string]string{
+			GCRetainSinceLastActiveTimeKey: "-1h",
+		},
+		want: "invalid value: -1h: serving.knative.dev/gc-retain-since-last-active-time\nmust be non-negative",
+	}, {
+		name: "disabled minimum",
+		annos: map[string]string{
+			GCMinNonActiveRevisionsKey: "disabled",
+		},
+		want: "invalid value: disabled: serving.knative.dev/gc-min-non-active-revisions\nstrconv.ParseUint: parsing \"disabled\": invalid syntax",
+	}, {
+		name: "negative maximum",
+		annos: map[string]string{
+			GCMaxNonActiveRevisionsKey: "-1",
+		},
+		want: "invalid value: -1: serving.knative.dev/gc-max-non-active-revisions\nstrconv.ParseUint: parsing \"-1\": invalid syntax",
+	}, {
+		name: "minimum exceeds maximum",
+		annos: map[string]string{
+			GCMinNonActiveRevisionsKey: "10",
+			GCMaxNonActiveRevisionsKey: "5",
+		},
+		want: "gc-min-non-active-revisions=10 must not exceed gc-max-non-active-revisions=5: serving.knative.dev/gc-min-non-active-revisions",
+	}, {
+		name: "invalid selector",
+		annos: map[string]string{
+			GCProtectedRevisionsKey: "release in stable",
+		},
+		want: "invalid value: release in stable: serving.knative.dev/gc-protected-revisions\nunable to parse requirement: found 'stable' expected: '('",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGCAnnotations(tc.annos)
+			if got, want := err.Error(), tc.want; got != want {
+				t.Errorf("APIErr mismatch, diff(-want,+got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestValidateRolloutStepsAnnotations(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  string
+	}{{
+		name: "empty",
+	}, {
+		name: "valid",
+		annos: map[string]string{
+			RolloutStepsKey:    "1%:5m,10%:10m,25%:pause,50%:10m",
+			RolloutApprovedKey: "foo-00002@25",
+		},
+	}, {
+		name: "invalid steps",
+		annos: map[string]string{
+			RolloutStepsKey: "10%:5m,5%:5m",
+		},
+		want: "invalid value: 10%:5m,5%:5m: serving.knative.dev/rollout-steps\n" +
+			"step 2 percentage 5% must be larger than the one of the previous step",
+	}, {
+		name: "invalid approval",
+		annos: map[string]string{
+			RolloutApprovedKey: "foo-00002",
+		},
+		want: "invalid value: foo-00002: serving.knative.dev/rollout-approved\n" +
+			`"foo-00002" must be of the form <revision>@<percent>`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRolloutStepsAnnotations(tc.annos)
+			if got, want := err.Error(), tc.want; got != want {
+				t.Errorf("APIErr mismatch, diff(-want,+got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestValidateRolloutControlAnnotation(t *testing.T) {
+	for _, v := range []string{"", "pause", "resume", "abort"} {
+		if err := ValidateRolloutControlAnnotation(map[string]string{RolloutControlKey: v}); err != nil {
+			t.Errorf("ValidateRolloutControlAnnotation(%q) = %v", v, err)
+		}
+	}
+
+	err := ValidateRolloutControlAnnotation(map[string]string{RolloutControlKey: "stop"})
+	if got, want := err.Error(), "invalid value: stop: serving.knative.dev/rollout-control\n"+
+		"must be one of pause, resume or abort"; got != want {
+		t.Errorf("APIErr mismatch, diff(-want,+got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -40,6 +40,35 @@ const (
 	// from automatically deleting the revision.
 	RevisionPreservedAnnotationKey = GroupName + "/no-gc"
 
+	// RevisionGCCandidateAnnotationKey is the annotation key used by the
+	// garbage collector in dry-run mode to mark the Revisions it would delete,
+	// with the rule it would delete them by.
+	RevisionGCCandidateAnnotationKey = GroupName + "/gc-candidate"
+
+	// GCRetainSinceCreateTimeKey is an annotation attached to a Configuration,
+	// a Service or a Namespace to override the retain-since-create-time of
+	// config-gc for the Revisions of the Configuration, or of the Namespace.
+	GCRetainSinceCreateTimeKey = GroupName + "/gc-retain-since-create-time"
+
+	// GCRetainSinceLastActiveTimeKey overrides the
+	// retain-since-last-active-time of config-gc like
+	// GCRetainSinceCreateTimeKey.
+	GCRetainSinceLastActiveTimeKey = GroupName + "/gc-retain-since-last-active-time"
+
+	// GCMinNonActiveRevisionsKey overrides the min-non-active-revisions of
+	// config-gc like GCRetainSinceCreateTimeKey.
+	GCMinNonActiveRevisionsKey = GroupName + "/gc-min-non-active-revisions"
+
+	// GCMaxNonActiveRevisionsKey overrides the max-non-active-revisions of
+	// config-gc like GCRetainSinceCreateTimeKey.
+	GCMaxNonActiveRevisionsKey = GroupName + "/gc-max-non-active-revisions"
+
+	// GCProtectedRevisionsKey is an annotation attached to a Configuration, a
+	// Service or a Namespace with a label selector of the Revisions the
+	// garbage collector must not delete, e.g. `release in (stable,previous)`,
+	// like the Revisions annotated with RevisionPreservedAnnotationKey.
+	GCProtectedRevisionsKey = GroupName + "/gc-protected-revisions"
+
 	// RouteLabelKey is the label key attached to a Configuration indicating by
 	// which Route it is configured as traffic target.
 	// The key is also attached to Revision resources to indicate they are directly
@@ -60,6 +89,37 @@ const (
 	// The value can be specified with at most with a second precision.
 	RolloutDurationKey = GroupName + "/rollout-duration"
 
+	// RolloutMaxErrorRateKey is an annotation attached to a Route to override the
+	// largest fraction of requests failing with a 5xx response code the latest
+	// revision may have for its rollout to advance. The value must be in [0, 1].
+	RolloutMaxErrorRateKey = GroupName + "/rollout-max-error-rate"
+
+	// RolloutMaxLatencyKey is an annotation attached to a Route to override the
+	// largest 95th percentile request latency the latest revision may have for
+	// its rollout to advance. The value must be a non-negative Golang
+	// time.Duration value serialized to string, 0 disables the check.
+	RolloutMaxLatencyKey = GroupName + "/rollout-max-latency"
+
+	// RolloutStepsKey is an annotation attached to a Route to roll out the latest
+	// revision following a custom schedule rather than in uniform steps over the
+	// rollout duration. See ParseRolloutSteps for the format of the value.
+	RolloutStepsKey = GroupName + "/rollout-steps"
+
+	// RolloutApprovedKey is an annotation attached to a Route to approve a pause
+	// step of the rollout schedule. The value is of the form <revision>@<percent>
+	// and lets the rollout of the revision continue past the step at percent.
+	RolloutApprovedKey = GroupName + "/rollout-approved"
+
+	// RolloutControlKey is an annotation attached to a Route to control the
+	// rollouts in progress. The value is one of:
+	// - pause: the rollouts hold the current traffic split, including the
+	//   rollouts started while the annotation is set,
+	// - resume: the paused rollouts continue, same as removing the annotation,
+	// - abort: the rollouts in progress when the annotation is set are rolled
+	//   back to the previous traffic split. The rollouts started later proceed,
+	//   the annotation has to be removed and set again to abort them.
+	RolloutControlKey = GroupName + "/rollout-control"
+
 	// RoutingStateLabelKey is the label attached to a Revision indicating
 	// its state in relation to serving a Route.
 	RoutingStateLabelKey = GroupName + "/routingState"
@@ -125,6 +185,16 @@ const (
 
 	// ProgressDeadlineAnnotationKey is the label key for the per revision progress deadline to set for the deployment
 	ProgressDeadlineAnnotationKey = GroupName + "/progress-deadline"
+
+	// RequestBodyBufferSizeAnnotationKey is the annotation key for the largest request body
+	// the activator buffers for a revision. Setting it enables buffering, which allows the
+	// activator to retry requests, and rejects larger bodies with a 413 while the activator
+	// is in the request path.
+	RequestBodyBufferSizeAnnotationKey = GroupName + "/request-body-buffer-size"
+
+	// RequestBodyBufferMemoryAnnotationKey is the annotation key for the part of a buffered
+	// request body that is kept in memory. The remainder is spilled to disk.
+	RequestBodyBufferMemoryAnnotationKey = GroupName + "/request-body-buffer-memory"
 )
 
 var (
@@ -158,6 +228,36 @@ var (
 		RolloutDurationKey,
 		GroupName + "/rolloutDuration",
 	}
+	RolloutMaxErrorRateAnnotation = kmap.KeyPriority{
+		RolloutMaxErrorRateKey,
+	}
+	RolloutMaxLatencyAnnotation = kmap.KeyPriority{
+		RolloutMaxLatencyKey,
+	}
+	RolloutStepsAnnotation = kmap.KeyPriority{
+		RolloutStepsKey,
+	}
+	RolloutApprovedAnnotation = kmap.KeyPriority{
+		RolloutApprovedKey,
+	}
+	RolloutControlAnnotation = kmap.KeyPriority{
+		RolloutControlKey,
+	}
+	GCRetainSinceCreateTimeAnnotation = kmap.KeyPriority{
+		GCRetainSinceCreateTimeKey,
+	}
+	GCRetainSinceLastActiveTimeAnnotation = kmap.KeyPriority{
+		GCRetainSinceLastActiveTimeKey,
+	}
+	GCMinNonActiveRevisionsAnnotation = kmap.KeyPriority{
+		GCMinNonActiveRevisionsKey,
+	}
+	GCMaxNonActiveRevisionsAnnotation = kmap.KeyPriority{
+		GCMaxNonActiveRevisionsKey,
+	}
+	GCProtectedRevisionsAnnotation = kmap.KeyPriority{
+		GCProtectedRevisionsKey,
+	}
 	QueueSidecarResourcePercentageAnnotation = kmap.KeyPriority{
 		QueueSidecarResourcePercentageAnnotationKey,
 		"queue.sidecar." + GroupName + "/resourcePercentage",
@@ -165,4 +265,10 @@ var (
 	ProgressDeadlineAnnotation = kmap.KeyPriority{
 		ProgressDeadlineAnnotationKey,
 	}
+	RequestBodyBufferSizeAnnotation = kmap.KeyPriority{
+		RequestBodyBufferSizeAnnotationKey,
+	}
+	RequestBodyBufferMemoryAnnotation = kmap.KeyPriority{
+		RequestBodyBufferMemoryAnnotationKey,
+	}
 )
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RolloutPauseStep is the duration of the steps of a rollout schedule
+// which last until they are approved.
+const RolloutPauseStep = "pause"
+
+// The values of the rollout-control annotation.
+const (
+	// RolloutControlPause pauses the rollouts in progress.
+	RolloutControlPause = "pause"
+	// RolloutControlResume resumes the paused rollouts.
+	RolloutControlResume = "resume"
+	// RolloutControlAbort rolls back the rollouts in progress.
+	RolloutControlAbort = "abort"
+)
+
+// RolloutStep is a step of a custom rollout schedule.
+type RolloutStep struct {
+	// Percent is the share of the traffic of the configuration that is
+	// routed to the latest revision during the step.
+	Percent int
+
+	// Duration is how long the step lasts. It is 0 for pause steps.
+	Duration time.Duration
+
+	// Pause is true if the step lasts until it is approved.
+	Pause bool
+}
+
+// ParseRolloutSteps parses a rollout schedule as specified by the
+// rollout-steps annotation: a comma separated list of steps of the form
+// `<percent>%:<duration>` or `<percent>%:pause`. The percentages must be
+// strictly increasing and below 100%, once the last step is over the
+// latest revision receives all the traffic. The durations must be positive
+// and have at most a second precision.
+func ParseRolloutSteps(s string) ([]RolloutStep, error) {
+	parts := strings.Split(s, ",")
+	steps := make([]RolloutStep, 0, len(parts))
+	for i, p := range parts {
+		kv := strings.SplitN(strings.TrimSpace(p), ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("step %d %q must be of the form <percent>%%:<duration>", i+1, p)
+		}
+		pct, dur := kv[0], kv[1]
+		var step RolloutStep
+		v, err := strconv.Atoi(strings.TrimSuffix(pct, "%"))
+		if err != nil {
+			return nil, fmt.Errorf("step %d has an invalid percentage %q", i+1, pct)
+		}
+		if v < 1 || v > 99 {
+			return nil, fmt.Errorf("step %d percentage %d%% must be in [1, 99] range", i+1, v)
+		}
+		if i > 0 && v <= steps[i-1].Percent {
+			return nil, fmt.Errorf("step %d percentage %d%% must be larger than the one of the previous step", i+1, v)
+		}
+		step.Percent = v
+
+		if dur == RolloutPauseStep {
+			step.Pause = true
+		} else {
+			d, err := time.ParseDuration(dur)
+			if err != nil {
+				return nil, fmt.Errorf("step %d has an invalid duration %q", i+1, dur)
+			}
+			if d <= 0 || d.Round(time.Second) != d {
+				return nil, fmt.Errorf("step %d duration %s must be positive and at second precision", i+1, dur)
+			}
+			step.Duration = d
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// RolloutApproval returns the value of the rollout-approved annotation that
+// approves the pause step at percent of the rollout of revision.
+func RolloutApproval(revision string, percent int) string {
+	return revision + "@" + strconv.Itoa(percent)
+}
+
+// ParseRolloutApproval parses the value of the rollout-approved annotation
+// into the revision and the percentage of the approved pause step.
+func ParseRolloutApproval(s string) (string, int, error) {
+	i := strings.LastIndex(s, "@")
+	if i < 1 {
+		return "", 0, fmt.Errorf("%q must be of the form <revision>@<percent>", s)
+	}
+	v, err := strconv.Atoi(s[i+1:])
+	if err != nil || v < 1 || v > 99 {
+		return "", 0, fmt.Errorf("%q must approve a percentage in [1, 99] range", s)
+	}
+	return s[:i], v, nil
+}
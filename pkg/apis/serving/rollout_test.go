@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRolloutSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []RolloutStep
+		wantErr string
+	}{{
+		name: "single step",
+		in:   "10%:5m",
+		want: []RolloutStep{{Percent: 10, Duration: 5 * time.Minute}},
+	}, {
+		name: "schedule with pause",
+		in:   "1%:5m, 10%:10m, 25%:pause, 50:10m",
+		want: []RolloutStep{
+			{Percent: 1, Duration: 5 * time.Minute},
+			{Percent: 10, Duration: 10 * time.Minute},
+			{Percent: 25, Pause: true},
+			{Percent: 50, Duration: 10 * time.Minute},
+		},
+	}, {
+		name:    "missing duration",
+		in:      "10%",
+		wantErr: `step 1 "10%" must be of the form <percent>%:<duration>`,
+	}, {
+		name:    "bad percentage",
+		in:      "ten%:5m",
+		wantErr: `step 1 has an invalid percentage "ten%"`,
+	}, {
+		name:    "percentage too large",
+		in:      "10%:5m,100%:5m",
+		wantErr: "step 2 percentage 100% must be in [1, 99] range",
+	}, {
+		name:    "percentage not increasing",
+		in:      "10%:5m,10%:5m",
+		wantErr: "step 2 percentage 10% must be larger than the one of the previous step",
+	}, {
+		name:    "bad duration",
+		in:      "10%:soon",
+		wantErr: `step 1 has an invalid duration "soon"`,
+	}, {
+		name:    "sub second duration",
+		in:      "10%:1500ms",
+		wantErr: "step 1 duration 1500ms must be positive and at second precision",
+	}, {
+		name:    "zero duration",
+		in:      "10%:0s",
+		wantErr: "step 1 duration 0s must be positive and at second precision",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRolloutSteps(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("ParseRolloutSteps() error = %v, want: %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("ParseRolloutSteps() =", err)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("ParseRolloutSteps() (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestRolloutApproval(t *testing.T) {
+	v := RolloutApproval("foo-00002", 25)
+	if want := "foo-00002@25"; v != want {
+		t.Errorf("RolloutApproval() = %s, want: %s", v, want)
+	}
+	rev, pct, err := ParseRolloutApproval(v)
+	if err != nil {
+		t.Fatal("ParseRolloutApproval() =", err)
+	}
+	if rev != "foo-00002" || pct != 25 {
+		t.Errorf("ParseRolloutApproval() = %s, %d, want: foo-00002, 25", rev, pct)
+	}
+
+	for _, v := range []string{"", "foo", "@25", "foo@", "foo@0", "foo@100", "foo@many"} {
+		if _, _, err := ParseRolloutApproval(v); err == nil {
+			t.Errorf("ParseRolloutApproval(%q) succeeded, want an error", v)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 // SetDefaults implements apis.Defaultable
 func (c *Configuration) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, c.ObjectMeta)
+	serving.Warn(ctx, c.Spec.Template.Spec.defaultingWarnings(ctx).ViaField("spec.template.spec"))
 	c.Spec.SetDefaults(apis.WithinSpec(ctx))
 	if c.GetOwnerReferences() == nil {
 		if apis.IsInUpdate(ctx) {
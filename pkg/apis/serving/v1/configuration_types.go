@@ -98,6 +98,12 @@ type ConfigurationStatus struct {
 	duckv1.Status `json:",inline"`
 
 	ConfigurationStatusFields `json:",inline"`
+
+	// GCCandidates are the names of the Revisions the garbage collector would
+	// delete if it were not in dry-run mode, which it annotates with
+	// serving.knative.dev/gc-candidate.
+	// +optional
+	GCCandidates []string `json:"gcCandidates,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -21,22 +21,29 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
 // Validate makes sure that Configuration is properly configured.
 func (c *Configuration) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// The annotations of the Namespace may override the features.
+	ctx = config.WithinNamespace(ctx, c.Namespace)
+
 	// If we are in a status sub resource update, the metadata and spec cannot change.
 	// So, to avoid rejecting controller status updates due to validations that may
 	// have changed (i.e. due to config-defaults changes), we elide the metadata and
 	// spec validation.
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(serving.ValidateObjectMetadata(ctx, c.GetObjectMeta(), false))
+		errs = errs.Also(serving.ValidateGCAnnotations(c.GetAnnotations()).ViaField("annotations"))
 		errs = errs.Also(c.validateLabels().ViaField("labels"))
 		errs = errs.ViaField("metadata")
 
 		ctx = apis.WithinParent(ctx, c.ObjectMeta)
 		errs = errs.Also(c.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+		serving.Warn(ctx, c.Spec.Template.warnings().ViaField("spec.template"))
 	}
 
 	if apis.IsInUpdate(ctx) {
@@ -76,6 +76,29 @@ func TestConfigurationValidation(t *testing.T) {
 		want: apis.ErrOutOfBoundsValue(
 			-10, 0, config.DefaultMaxRevisionContainerConcurrency,
 			"spec.template.spec.containerConcurrency"),
+	}, {
+		name: "invalid gc annotation",
+		c: &Configuration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.GCMaxNonActiveRevisionsKey: "many",
+				},
+			},
+			Spec: ConfigurationSpec{
+				Template: RevisionTemplateSpec{
+					Spec: RevisionSpec{
+						PodSpec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Image: "busybox",
+							}},
+						},
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("many", "metadata.annotations."+serving.GCMaxNonActiveRevisionsKey,
+			`strconv.ParseUint: parsing "many": invalid syntax`),
 	}, {
 		name: "valid BYO name",
 		c: &Configuration{
@@ -270,6 +293,48 @@ func TestConfigurationValidation(t *testing.T) {
 	}
 }
 
+func TestConfigurationNamespaceFeatures(t *testing.T) {
+	features, _ := config.NewFeaturesConfigFromMap(map[string]string{
+		"kubernetes.podspec-affinity": "Allowed",
+	})
+	ctx := config.ToContext(context.Background(), &config.Config{Features: features})
+	ctx = config.WithNamespaceAnnotations(ctx, func(namespace string) (map[string]string, bool) {
+		return map[string]string{
+			config.FeaturesAnnotationPrefix + "kubernetes.podspec-affinity": namespace,
+		}, true
+	})
+
+	cfg := func(namespace string) *Configuration {
+		return &Configuration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "affinity",
+				Namespace: namespace,
+			},
+			Spec: ConfigurationSpec{
+				Template: RevisionTemplateSpec{
+					Spec: RevisionSpec{
+						PodSpec: corev1.PodSpec{
+							Affinity: &corev1.Affinity{},
+							Containers: []corev1.Container{{
+								Image: "busybox",
+							}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for namespace, want := range map[string]*apis.FieldError{
+		"Enabled":  nil,
+		"Disabled": apis.ErrDisallowedFields("spec.template.spec.affinity"),
+	} {
+		if got := cfg(namespace).Validate(ctx); !cmp.Equal(want.Error(), got.Error()) {
+			t.Errorf("Validate() in namespace %q (-want, +got): %s", namespace, cmp.Diff(want.Error(), got.Error()))
+		}
+	}
+}
+
 func TestConfigurationLabelValidation(t *testing.T) {
 	validConfigSpec := ConfigurationSpec{
 		Template: RevisionTemplateSpec{
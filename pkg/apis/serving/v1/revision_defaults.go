@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +28,7 @@ import (
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
 	"knative.dev/serving/pkg/apis/config"
+	"knative.dev/serving/pkg/apis/serving"
 )
 
 // SetDefaults implements apis.Defaultable
@@ -36,6 +38,7 @@ func (r *Revision) SetDefaults(ctx context.Context) {
 	if apis.IsInUpdate(ctx) {
 		return
 	}
+	serving.Warn(ctx, r.Spec.defaultingWarnings(ctx).ViaField("spec"))
 	r.Spec.SetDefaults(apis.WithinSpec(ctx))
 }
 
@@ -75,6 +78,18 @@ func (rs *RevisionSpec) SetDefaults(ctx context.Context) {
 	}
 }
 
+// defaultingWarnings warns about the values of the RevisionSpec which
+// SetDefaults replaces.
+func (rs *RevisionSpec) defaultingWarnings(ctx context.Context) *apis.FieldError {
+	if rs.TimeoutSeconds != nil && *rs.TimeoutSeconds == 0 {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("0 is replaced with the default of %d", config.FromContextOrDefaults(ctx).Defaults.RevisionTimeoutSeconds),
+			Paths:   []string{"timeoutSeconds"},
+		}
+	}
+	return nil
+}
+
 func (rs *RevisionSpec) applyDefault(ctx context.Context, container *corev1.Container, cfg *config.Config) {
 	if container.Resources.Requests == nil {
 		container.Resources.Requests = corev1.ResourceList{}
@@ -139,6 +154,7 @@ func (*RevisionSpec) applyProbes(container *corev1.Container) {
 	}
 	if container.ReadinessProbe.TCPSocket == nil &&
 		container.ReadinessProbe.HTTPGet == nil &&
+		container.ReadinessProbe.GRPC == nil &&
 		container.ReadinessProbe.Exec == nil {
 		container.ReadinessProbe.TCPSocket = &corev1.TCPSocketAction{}
 	}
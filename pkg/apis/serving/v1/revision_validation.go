@@ -23,8 +23,10 @@ import (
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmap"
 	"knative.dev/pkg/kmp"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/config"
@@ -33,6 +35,9 @@ import (
 
 // Validate ensures Revision is properly configured.
 func (r *Revision) Validate(ctx context.Context) *apis.FieldError {
+	// The annotations of the Namespace may override the features.
+	ctx = config.WithinNamespace(ctx, r.Namespace)
+
 	errs := serving.ValidateObjectMetadata(ctx, r.GetObjectMeta(), true).Also(
 		r.ValidateLabels().ViaField("labels")).ViaField("metadata")
 	errs = errs.Also(r.Status.Validate(apis.WithinStatus(ctx)).ViaField("status"))
@@ -54,6 +59,7 @@ func (r *Revision) Validate(ctx context.Context) *apis.FieldError {
 		}
 	} else {
 		errs = errs.Also(r.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+		serving.Warn(ctx, revisionWarnings(r.Annotations, &r.Spec))
 	}
 
 	return errs
@@ -70,9 +76,64 @@ func (rts *RevisionTemplateSpec) Validate(ctx context.Context) *apis.FieldError
 	errs = errs.Also(validateRevisionName(ctx, rts.Name, rts.GenerateName))
 	errs = errs.Also(validateQueueSidecarAnnotation(rts.Annotations).ViaField("metadata.annotations"))
 	errs = errs.Also(validateProgressDeadlineAnnotation(rts.Annotations).ViaField("metadata.annotations"))
+	errs = errs.Also(validateRequestBodyBufferAnnotations(ctx, rts.Annotations).ViaField("metadata.annotations"))
 	return errs
 }
 
+// deprecatedRevisionAnnotations are the annotations of the Revisions which
+// still accept deprecated keys.
+var deprecatedRevisionAnnotations = []kmap.KeyPriority{
+	autoscaling.InitialScaleAnnotation,
+	autoscaling.MaxScaleAnnotation,
+	autoscaling.MetricAggregationAlgorithmAnnotation,
+	autoscaling.MinScaleAnnotation,
+	autoscaling.PanicThresholdPercentageAnnotation,
+	autoscaling.PanicWindowPercentageAnnotation,
+	autoscaling.ScaleDownDelayAnnotation,
+	autoscaling.ScaleToZeroPodRetentionPeriodAnnotation,
+	autoscaling.TargetBurstCapacityAnnotation,
+	autoscaling.TargetUtilizationPercentageAnnotation,
+	serving.QueueSidecarResourcePercentageAnnotation,
+}
+
+// warnings returns the warnings about the settings of the
+// RevisionTemplateSpec which are valid but likely unintended.
+func (rts *RevisionTemplateSpec) warnings() *apis.FieldError {
+	return revisionWarnings(rts.Annotations, &rts.Spec)
+}
+
+// revisionWarnings returns the warnings about the annotations and spec of a
+// Revision which are valid but likely unintended.
+func revisionWarnings(annos map[string]string, rs *RevisionSpec) *apis.FieldError {
+	return serving.DeprecatedAnnotationWarnings(annos, deprecatedRevisionAnnotations...).ViaField("metadata.annotations").
+		Also(targetWarning(annos, rs.ContainerConcurrency))
+}
+
+// targetWarning warns when the target concurrency of the autoscaler exceeds
+// the containerConcurrency, which then caps the target in its place.
+func targetWarning(annos map[string]string, containerConcurrency *int64) *apis.FieldError {
+	if containerConcurrency == nil || *containerConcurrency == 0 {
+		return nil
+	}
+	if _, class, ok := autoscaling.ClassAnnotation.Get(annos); ok && class != autoscaling.KPA {
+		return nil
+	}
+	if _, metric, ok := autoscaling.MetricAnnotation.Get(annos); ok && metric != autoscaling.Concurrency {
+		return nil
+	}
+	k, v, ok := autoscaling.TargetAnnotation.Get(annos)
+	if !ok {
+		return nil
+	}
+	if target, err := strconv.ParseFloat(v, 64); err != nil || target <= float64(*containerConcurrency) {
+		return nil
+	}
+	return &apis.FieldError{
+		Message: fmt.Sprintf("target %s exceeds containerConcurrency %d, which caps it", v, *containerConcurrency),
+		Paths:   []string{"metadata.annotations." + k, "spec.containerConcurrency"},
+	}
+}
+
 // VerifyNameChange checks that if a user brought their own name previously that it
 // changes at the appropriate times.
 func (rts *RevisionTemplateSpec) VerifyNameChange(_ context.Context, og *RevisionTemplateSpec) *apis.FieldError {
@@ -224,3 +285,42 @@ func validateProgressDeadlineAnnotation(annos map[string]string) *apis.FieldErro
 	}
 	return nil
 }
+
+// validateRequestBodyBufferAnnotations validates the request body buffer
+// annotations against the limits of the operator.
+func validateRequestBodyBufferAnnotations(ctx context.Context, annos map[string]string) *apis.FieldError {
+	cfg := config.FromContextOrDefaults(ctx).Defaults
+	var errs *apis.FieldError
+	sizeKey, sizeValue, sizeFound := serving.RequestBodyBufferSizeAnnotation.Get(annos)
+	var size resource.Quantity
+	if sizeFound {
+		q, err := resource.ParseQuantity(sizeValue)
+		switch {
+		case err != nil:
+			errs = errs.Also(apis.ErrInvalidValue(sizeValue, sizeKey))
+		case q.Sign() <= 0 || q.Cmp(*cfg.RequestBodyBufferMaxSize) > 0:
+			errs = errs.Also(apis.ErrOutOfBoundsValue(sizeValue, "1", cfg.RequestBodyBufferMaxSize.String(), sizeKey))
+		}
+		size = q
+	}
+
+	if k, v, found := serving.RequestBodyBufferMemoryAnnotation.Get(annos); found {
+		mem, err := resource.ParseQuantity(v)
+		maxMem := *cfg.RequestBodyBufferMaxMemory
+		if sizeFound && size.Cmp(maxMem) < 0 {
+			maxMem = size
+		}
+		switch {
+		case err != nil:
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		case !sizeFound:
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("%s requires %s to be set", k, sizeKey),
+				Paths:   []string{k},
+			})
+		case mem.Sign() < 0 || mem.Cmp(maxMem) > 0:
+			errs = errs.Also(apis.ErrOutOfBoundsValue(v, "0", maxMem.String(), k))
+		}
+	}
+	return errs
+}
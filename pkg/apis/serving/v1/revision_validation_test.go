@@ -1047,6 +1047,138 @@ func TestRevisionTemplateSpecValidation(t *testing.T) {
 			Message: "progress-deadline=-1m3s must be positive",
 			Paths:   []string{serving.ProgressDeadlineAnnotationKey},
 		}).ViaField("metadata.annotations"),
+	}, {
+		name: "valid request body buffer",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferSizeAnnotationKey:   "10Mi",
+					serving.RequestBodyBufferMemoryAnnotationKey: "128Ki",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid request body buffer size",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferSizeAnnotationKey: "lots",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("lots", serving.RequestBodyBufferSizeAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "zero request body buffer size",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferSizeAnnotationKey: "0",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue("0", "1", "100Mi", serving.RequestBodyBufferSizeAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "request body buffer size above the limit",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferSizeAnnotationKey: "1Gi",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue("1Gi", "1", "100Mi", serving.RequestBodyBufferSizeAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "request body buffer memory above the limit",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferSizeAnnotationKey:   "10Mi",
+					serving.RequestBodyBufferMemoryAnnotationKey: "2Mi",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue("2Mi", "0", "1Mi", serving.RequestBodyBufferMemoryAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "request body buffer memory larger than size",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferSizeAnnotationKey:   "512Ki",
+					serving.RequestBodyBufferMemoryAnnotationKey: "768Ki",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue("768Ki", "0", "512Ki", serving.RequestBodyBufferMemoryAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "request body buffer memory without size",
+		ctx:  autoscalerConfigCtx(true, 1),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.RequestBodyBufferMemoryAnnotationKey: "2Mi",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: serving.RequestBodyBufferMemoryAnnotationKey + " requires " + serving.RequestBodyBufferSizeAnnotationKey + " to be set",
+			Paths:   []string{serving.RequestBodyBufferMemoryAnnotationKey},
+		}).ViaField("metadata.annotations"),
 	}}
 
 	for _, test := range tests {
@@ -59,7 +59,8 @@ func (tt *TrafficTarget) SetDefaults(ctx context.Context) {
 	// as required, historically we were lenient about checking this.
 	// But by setting explicit `0` we can eliminate lots of checking
 	// downstream in validation and controllers.
-	if tt.Percent == nil {
+	// The targets with a weight need no percent.
+	if tt.Percent == nil && tt.Weight == nil {
 		tt.Percent = ptr.Int64(0)
 	}
 }
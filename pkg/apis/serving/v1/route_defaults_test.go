@@ -76,6 +76,32 @@ func TestRouteDefaulting(t *testing.T) {
 			},
 		},
 		wc: WithDefaultConfigurationName,
+	}, {
+		name: "weight without percent",
+		in: &Route{
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					Weight:         ptr.Int64(9999),
+					LatestRevision: ptr.Bool(true),
+				}, {
+					RevisionName: "bar",
+					Weight:       ptr.Int64(1),
+				}},
+			},
+		},
+		want: &Route{
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					Weight:         ptr.Int64(9999),
+					LatestRevision: ptr.Bool(true),
+				}, {
+					RevisionName:   "bar",
+					LatestRevision: ptr.Bool(false),
+					Weight:         ptr.Int64(1),
+				}},
+			},
+		},
+		wc: WithDefaultConfigurationName,
 	}, {
 		// Just to make sure it doesn't convert a 'zero' into a 'nil'
 		name: "explicit zero percent",
@@ -73,6 +73,33 @@ func (r *Route) RolloutDuration() time.Duration {
 	return 0
 }
 
+// RolloutSteps returns the custom rollout schedule specified as an
+// annotation.
+// nil is returned if missing or cannot be parsed.
+func (r *Route) RolloutSteps() []serving.RolloutStep {
+	if _, v, ok := serving.RolloutStepsAnnotation.Get(r.Annotations); ok && v != "" {
+		// WH should've declined all the invalid values for this annotation.
+		if steps, err := serving.ParseRolloutSteps(v); err == nil {
+			return steps
+		}
+	}
+	return nil
+}
+
+// RolloutApproved returns true if the pause step at percent of the rollout
+// of revision was approved with an annotation.
+func (r *Route) RolloutApproved(revision string, percent int) bool {
+	_, v, _ := serving.RolloutApprovedAnnotation.Get(r.Annotations)
+	return v == serving.RolloutApproval(revision, percent)
+}
+
+// RolloutControl returns the value of the rollout-control annotation,
+// which is empty if missing.
+func (r *Route) RolloutControl() string {
+	_, v, _ := serving.RolloutControlAnnotation.Get(r.Annotations)
+	return v
+}
+
 // InitializeConditions sets the initial values to the conditions.
 func (rs *RouteStatus) InitializeConditions() {
 	routeCondSet.Manage(rs).InitializeConditions()
@@ -99,6 +126,34 @@ func (rs *RouteStatus) MarkIngressRolloutInProgress() {
 		"RolloutInProgress", "A gradual rollout of the latest revision(s) is in progress.")
 }
 
+// MarkIngressRolloutPaused changes the IngressReady condition to be unknown to reflect
+// that the rollout of the latest revision(s) was paused with an annotation.
+func (rs *RouteStatus) MarkIngressRolloutPaused() {
+	routeCondSet.Manage(rs).MarkUnknown(RouteConditionIngressReady, "RolloutPaused",
+		"The rollout of the latest revision(s) is paused with the %s annotation.", serving.RolloutControlKey)
+}
+
+// MarkIngressRolloutAwaitingApproval changes the IngressReady condition to be unknown
+// to reflect that the rollout of the revision is paused at percent until it is approved.
+func (rs *RouteStatus) MarkIngressRolloutAwaitingApproval(revision string, percent int) {
+	routeCondSet.Manage(rs).MarkUnknown(RouteConditionIngressReady, "RolloutAwaitingApproval",
+		"The rollout of revision %q is paused at %d%% until the %s annotation is set to %q.",
+		revision, percent, serving.RolloutApprovedKey, serving.RolloutApproval(revision, percent))
+}
+
+// MarkRolloutRolledBack changes the RolloutHealthy condition to be false to
+// reflect that the rollout of the given revision was rolled back.
+func (rs *RouteStatus) MarkRolloutRolledBack(revision, reason string) {
+	routeCondSet.Manage(rs).MarkFalse(RouteConditionRolloutHealthy, "RolledBack",
+		"The rollout of revision %q was rolled back: %s", revision, reason)
+}
+
+// ClearRolloutRolledBack removes the RolloutHealthy condition once no
+// rolled back rollout is reflected in the Route anymore.
+func (rs *RouteStatus) ClearRolloutRolledBack() {
+	routeCondSet.Manage(rs).ClearCondition(RouteConditionRolloutHealthy)
+}
+
 // MarkIngressNotConfigured changes the IngressReady condition to be unknown to reflect
 // that the Ingress does not yet have a Status
 func (rs *RouteStatus) MarkIngressNotConfigured() {
@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -499,6 +500,63 @@ func TestMarkInRollout(t *testing.T) {
 	apistest.CheckConditionOngoing(r, RouteConditionIngressReady, t)
 }
 
+func TestMarkRolloutPaused(t *testing.T) {
+	r := &RouteStatus{}
+	r.InitializeConditions()
+	r.MarkIngressRolloutPaused()
+
+	apistest.CheckConditionOngoing(r, RouteConditionIngressReady, t)
+	if got, want := r.GetCondition(RouteConditionIngressReady).Reason, "RolloutPaused"; got != want {
+		t.Errorf("Reason = %q, want: %q", got, want)
+	}
+}
+
+func TestMarkRolloutAwaitingApproval(t *testing.T) {
+	r := &RouteStatus{}
+	r.InitializeConditions()
+	r.MarkIngressRolloutAwaitingApproval("rev-2", 25)
+
+	apistest.CheckConditionOngoing(r, RouteConditionIngressReady, t)
+	c := r.GetCondition(RouteConditionIngressReady)
+	if got, want := c.Reason, "RolloutAwaitingApproval"; got != want {
+		t.Errorf("Reason = %q, want: %q", got, want)
+	}
+	if got, want := c.Message,
+		`The rollout of revision "rev-2" is paused at 25% until the serving.knative.dev/rollout-approved annotation is set to "rev-2@25".`; got != want {
+		t.Errorf("Message = %q, want: %q", got, want)
+	}
+}
+
+func TestMarkRolloutRolledBack(t *testing.T) {
+	r := &RouteStatus{}
+	r.InitializeConditions()
+	r.MarkTrafficAssigned()
+	r.MarkTLSNotEnabled(AutoTLSNotEnabledMessage)
+	r.PropagateIngressStatus(netv1alpha1.IngressStatus{
+		Status: duckv1.Status{
+			Conditions: duckv1.Conditions{{
+				Type:   netv1alpha1.IngressConditionReady,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	})
+
+	r.MarkRolloutRolledBack("rev-2", "error rate too high")
+	apistest.CheckConditionFailed(r, RouteConditionRolloutHealthy, t)
+	// The rolled back Route keeps serving the previous revision.
+	apistest.CheckConditionSucceeded(r, RouteConditionReady, t)
+	if got, want := r.GetCondition(RouteConditionRolloutHealthy).Message,
+		`The rollout of revision "rev-2" was rolled back: error rate too high`; got != want {
+		t.Errorf("Message = %q, want: %q", got, want)
+	}
+
+	r.ClearRolloutRolledBack()
+	if c := r.GetCondition(RouteConditionRolloutHealthy); c != nil {
+		t.Errorf("RolloutHealthy = %#v, want: nil", c)
+	}
+	apistest.CheckConditionSucceeded(r, RouteConditionReady, t)
+}
+
 func TestRolloutDuration(t *testing.T) {
 	tests := []struct {
 		name string
@@ -532,3 +590,62 @@ func TestRolloutDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestRolloutSteps(t *testing.T) {
+	tests := []struct {
+		name string
+		val  string
+		want []serving.RolloutStep
+	}{{
+		name: "empty",
+	}, {
+		name: "invalid",
+		val:  "10%",
+	}, {
+		name: "steps",
+		val:  "10%:5m,50%:pause",
+		want: []serving.RolloutStep{
+			{Percent: 10, Duration: 5 * time.Minute},
+			{Percent: 50, Pause: true},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						serving.RolloutStepsKey: tc.val,
+					},
+				},
+			}
+			if got, want := r.RolloutSteps(), tc.want; !cmp.Equal(got, want) {
+				t.Error("RolloutSteps (-want, +got):", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestRolloutApproved(t *testing.T) {
+	r := &Route{}
+	if r.RolloutApproved("rev-2", 25) {
+		t.Error("RolloutApproved() = true without the annotation")
+	}
+	r.Annotations = map[string]string{serving.RolloutApprovedKey: "rev-2@25"}
+	if !r.RolloutApproved("rev-2", 25) {
+		t.Error("RolloutApproved() = false for the approved step")
+	}
+	if r.RolloutApproved("rev-2", 50) || r.RolloutApproved("rev-3", 25) {
+		t.Error("RolloutApproved() = true for a step that was not approved")
+	}
+}
+
+func TestRolloutControl(t *testing.T) {
+	r := &Route{}
+	if got := r.RolloutControl(); got != "" {
+		t.Errorf("RolloutControl() = %q without the annotation", got)
+	}
+	r.Annotations = map[string]string{serving.RolloutControlKey: serving.RolloutControlPause}
+	if got, want := r.RolloutControl(), "pause"; got != want {
+		t.Errorf("RolloutControl() = %q, want: %q", got, want)
+	}
+}
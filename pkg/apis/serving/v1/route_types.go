@@ -64,6 +64,10 @@ var (
 	_ duckv1.KRShaped = (*Route)(nil)
 )
 
+// MaxTrafficWeight is the weight of all the traffic of a Route, in basis
+// points.
+const MaxTrafficWeight = 10000
+
 // TrafficTarget holds a single entry of the routing table for a Route.
 type TrafficTarget struct {
 	// Tag is optionally used to expose a dedicated url for referencing
@@ -103,6 +107,38 @@ type TrafficTarget struct {
 	// +optional
 	Percent *int64 `json:"percent,omitempty"`
 
+	// Weight is the share of the traffic routed to this Revision or
+	// Configuration in basis points, hundredths of a percent, for finer
+	// grained splits: `10000` means all traffic. It is mutually exclusive
+	// with Percent, a percent of `n` weighs `n*100`. When a Route has targets
+	// with a weight, the weights of all its targets sum to 10000 and are
+	// reported in status in place of their percents.
+	// +optional
+	Weight *int64 `json:"weight,omitempty"`
+
+	// MirrorPercent makes this target the shadow of the other targets of its
+	// group: the given percentage of their requests is copied to it, and
+	// its responses are discarded. A shadow target receives no share of the
+	// traffic of its group, so its percent must be 0. The requests of the
+	// group are routed through the activator, which only mirrors the
+	// requests with a body if the revisions of the group buffer them.
+	// +optional
+	MirrorPercent *int64 `json:"mirrorPercent,omitempty"`
+
+	// Path is the prefix of the request paths routed to this target. The
+	// targets with the same path form a group whose percentages are split
+	// separately and must sum to 100, the requests matching the paths of no
+	// group are split over the targets without a path. The longest matching
+	// path wins. A target with a path may not have a tag.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Headers mutates the headers of the requests routed to this target
+	// and of their responses. They override the header mutations of the
+	// Route for the same headers.
+	// +optional
+	Headers *HeaderMutations `json:"headers,omitempty"`
+
 	// URL displays the URL for accessing named traffic targets. URL is displayed in
 	// status, and is disallowed on spec. URL must contain a scheme (e.g. http://) and
 	// a hostname, but may not contain anything else (e.g. basic auth, url path, etc.)
@@ -116,6 +152,117 @@ type RouteSpec struct {
 	// revisions and configurations.
 	// +optional
 	Traffic []TrafficTarget `json:"traffic,omitempty"`
+
+	// Match routes the requests matching its rules to the tagged targets of
+	// Traffic. The rules are evaluated in order before the percentages of
+	// Traffic, the requests matching none of them are split by percentage.
+	// +optional
+	Match []TrafficMatch `json:"match,omitempty"`
+
+	// Headers mutates the headers of all the requests routed by the Route
+	// and of their responses.
+	// +optional
+	Headers *HeaderMutations `json:"headers,omitempty"`
+
+	// Fault injects a fault into a share of the requests routed by the
+	// Route, to test the resilience of their clients. It requires the
+	// route-fault-injection feature to be enabled.
+	// +optional
+	Fault *TrafficFault `json:"fault,omitempty"`
+}
+
+// TrafficFault injects a fault into a share of the requests: it either
+// delays them or aborts them. Exactly one of Delay and AbortStatus must be
+// set.
+type TrafficFault struct {
+	// Percent of the requests the fault is injected into.
+	Percent int64 `json:"percent"`
+
+	// Delay delays the requests by a fixed duration before they are served.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+
+	// AbortStatus aborts the requests with the HTTP status code, rather
+	// than serving them.
+	// +optional
+	AbortStatus int `json:"abortStatus,omitempty"`
+
+	// Tag restricts the fault to the requests routed to a tagged traffic
+	// target by its tag.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Headers restricts the fault to the requests with matching headers.
+	// +optional
+	Headers []HeaderMatch `json:"headers,omitempty"`
+}
+
+// HeaderMutations mutates the headers of requests and of their responses.
+// The headers used by Knative to route the requests, prefixed with
+// `Knative-` or `K-`, may not be mutated.
+type HeaderMutations struct {
+	// Request mutates the headers of the requests.
+	// +optional
+	Request *HeaderOperations `json:"request,omitempty"`
+
+	// Response mutates the headers of the responses.
+	// +optional
+	Response *HeaderOperations `json:"response,omitempty"`
+}
+
+// HeaderOperations are the operations on a set of headers. A header may
+// only be the subject of a single operation.
+type HeaderOperations struct {
+	// Set sets the headers to the values, replacing their current values.
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+
+	// Add adds the values to the headers, keeping their current values.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+
+	// Remove removes the headers.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// TrafficMatch routes the requests matching all of its conditions to a
+// tagged traffic target.
+type TrafficMatch struct {
+	// Tag of the traffic target the matching requests are routed to.
+	Tag string `json:"tag"`
+
+	// Headers the requests must have, with matching values.
+	Headers []TrafficHeaderMatch `json:"headers"`
+}
+
+// TrafficHeaderMatch matches the requests whose header has exactly the
+// value, the only condition the Ingress can route on.
+type TrafficHeaderMatch struct {
+	// Name of the header.
+	Name string `json:"name"`
+
+	// Exact matches the header values equal to it.
+	Exact string `json:"exact"`
+}
+
+// HeaderMatch matches the value of a request header. Exactly one of Exact,
+// Prefix and Regex must be set.
+type HeaderMatch struct {
+	// Name of the header.
+	Name string `json:"name"`
+
+	// Exact matches the header values equal to it.
+	// +optional
+	Exact string `json:"exact,omitempty"`
+
+	// Prefix matches the header values starting with it.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Regex matches the header values matching the RE2 regular expression.
+	// +optional
+	Regex string `json:"regex,omitempty"`
 }
 
 const (
@@ -135,6 +282,11 @@ const (
 	// RouteConditionCertificateProvisioned is set to False when the
 	// Knative Certificates fail to be provisioned for the Route.
 	RouteConditionCertificateProvisioned apis.ConditionType = "CertificateProvisioned"
+
+	// RouteConditionRolloutHealthy is set to False when the gradual rollout
+	// of the latest revision was rolled back, because the revision did not
+	// meet the limits the rollout is gated on.
+	RouteConditionRolloutHealthy apis.ConditionType = "RolloutHealthy"
 )
 
 // IsRouteCondition returns true if the ConditionType is a route condition type
@@ -144,7 +296,8 @@ func IsRouteCondition(t apis.ConditionType) bool {
 		RouteConditionReady,
 		RouteConditionAllTrafficAssigned,
 		RouteConditionIngressReady,
-		RouteConditionCertificateProvisioned:
+		RouteConditionCertificateProvisioned,
+		RouteConditionRolloutHealthy:
 		return true
 	}
 	return false
@@ -169,6 +322,11 @@ type RouteStatusFields struct {
 	// LatestReadyRevisionName that we last observed.
 	// +optional
 	Traffic []TrafficTarget `json:"traffic,omitempty"`
+
+	// Rollouts describes the gradual rollouts of the latest revisions of
+	// the configurations referenced by the traffic targets, which are in progress.
+	// +optional
+	Rollouts []RolloutStatus `json:"rollouts,omitempty"`
 }
 
 // RouteStatus communicates the observed state of the Route (from the controller).
@@ -178,6 +336,79 @@ type RouteStatus struct {
 	RouteStatusFields `json:",inline"`
 }
 
+// RolloutState is the state of the gradual rollout of a revision.
+type RolloutState string
+
+const (
+	// RolloutStateProgressing is the state of rollouts that move traffic to
+	// the latest revision as scheduled.
+	RolloutStateProgressing RolloutState = "Progressing"
+
+	// RolloutStatePaused is the state of rollouts that were paused with the
+	// rollout-control annotation and hold the current traffic split.
+	RolloutStatePaused RolloutState = "Paused"
+
+	// RolloutStateAwaitingApproval is the state of rollouts that hold the
+	// current traffic split at a pause step until it is approved.
+	RolloutStateAwaitingApproval RolloutState = "AwaitingApproval"
+)
+
+// RolloutStatus describes the gradual rollout of the latest revision of
+// a configuration.
+type RolloutStatus struct {
+	// ConfigurationName is the name of the configuration whose latest
+	// revision is being rolled out.
+	ConfigurationName string `json:"configurationName"`
+
+	// Tag is the tag of the traffic target the revision is rolled out to,
+	// it is empty for the traffic targets without a tag.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Path is the path of the traffic targets the revision is rolled out
+	// to, it is empty for the traffic targets without a path.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// State is the state of the rollout.
+	State RolloutState `json:"state"`
+
+	// Revisions are the revisions of the configuration receiving traffic,
+	// from the oldest to the revision being rolled out.
+	// +optional
+	Revisions []RolloutRevisionStatus `json:"revisions,omitempty"`
+
+	// StartTime is when the rollout started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// NextStepTime is when the next traffic shift of the rollout is
+	// scheduled. It is unset while the rollout is paused.
+	// +optional
+	NextStepTime *metav1.Time `json:"nextStepTime,omitempty"`
+
+	// EstimatedCompletionTime is when the revision being rolled out is
+	// expected to receive all the traffic of the configuration. It is unset
+	// while the rollout is paused, or if it has to be approved to complete.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
+
+// RolloutRevisionStatus describes the traffic a revision receives during a
+// gradual rollout.
+type RolloutRevisionStatus struct {
+	// RevisionName is the name of the revision.
+	RevisionName string `json:"revisionName"`
+
+	// Percent is the share of the Route traffic routed to the revision.
+	Percent int64 `json:"percent"`
+
+	// Weight is the share of the Route traffic routed to the revision in
+	// basis points, for the Routes splitting their traffic by weight.
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // RouteList is a list of Route resources
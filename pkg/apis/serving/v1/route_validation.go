@@ -19,20 +19,33 @@ package v1
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
 // Validate makes sure that Route is properly configured.
 func (r *Route) Validate(ctx context.Context) *apis.FieldError {
+	// The annotations of the Namespace may override the features.
+	ctx = config.WithinNamespace(ctx, r.Namespace)
+
 	errs := serving.ValidateObjectMetadata(ctx, r.GetObjectMeta(), false).Also(
 		r.validateLabels().ViaField("labels"))
 	errs = errs.Also(serving.ValidateRolloutDurationAnnotation(r.GetAnnotations()).ViaField("annotations"))
+	errs = errs.Also(serving.ValidateRolloutAnalysisAnnotations(r.GetAnnotations()).ViaField("annotations"))
+	errs = errs.Also(serving.ValidateRolloutStepsAnnotations(r.GetAnnotations()).ViaField("annotations"))
+	errs = errs.Also(serving.ValidateRolloutControlAnnotation(r.GetAnnotations()).ViaField("annotations"))
 	errs = errs.ViaField("metadata")
 	errs = errs.Also(r.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 
+	serving.Warn(ctx, serving.DeprecatedAnnotationWarnings(r.GetAnnotations(),
+		serving.RolloutDurationAnnotation).ViaField("metadata.annotations"))
+
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*Route)
 		// Don't validate annotations(creator and lastModifier) when route owned by service
@@ -51,12 +64,22 @@ func validateTrafficList(ctx context.Context, traffic []TrafficTarget) *apis.Fie
 	// Track the targets of named TrafficTarget entries (to detect duplicates).
 	trafficMap := make(map[string]int)
 
+	// The shares are summed in basis points, and reported in the unit of
+	// the Route.
+	weighted := false
 	sum := int64(0)
+	// The targets with a path are split separately, keyed by their path.
+	pathSums := make(map[string]int64)
 	for i, tt := range traffic {
 		errs = errs.Also(tt.Validate(ctx).ViaIndex(i))
 
-		if tt.Percent != nil {
-			sum += *tt.Percent
+		if tt.Path != "" {
+			pathSums[tt.Path] += tt.weight()
+		} else {
+			sum += tt.weight()
+		}
+		if tt.Weight != nil {
+			weighted = true
 		}
 
 		if tt.Tag == "" {
@@ -82,18 +105,262 @@ func validateTrafficList(ctx context.Context, traffic []TrafficTarget) *apis.Fie
 		}
 	}
 
-	if sum != 100 {
+	total, unit := int64(100), ""
+	if weighted {
+		total, unit = MaxTrafficWeight, " basis points"
+	}
+	if sum != MaxTrafficWeight {
 		errs = errs.Also(&apis.FieldError{
-			Message: fmt.Sprintf("Traffic targets sum to %d, want 100", sum),
+			Message: fmt.Sprintf("Traffic targets sum to %d%s, want %d", sum*total/MaxTrafficWeight, unit, total),
 			Paths:   []string{apis.CurrentField},
 		})
 	}
+	for _, path := range sets.StringKeySet(pathSums).List() {
+		if pathSums[path] != MaxTrafficWeight {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("Traffic targets with path %q sum to %d%s, want %d", path, pathSums[path]*total/MaxTrafficWeight, unit, total),
+				Paths:   []string{apis.CurrentField},
+			})
+		}
+	}
+	if weighted {
+		errs = errs.Also(validateWeightedHeaders(traffic))
+	}
+	return errs
+}
+
+// validateWeightedHeaders checks that the targets of a Route splitting its
+// traffic by weight do not mutate headers: their finer splits are made by
+// the activator for the whole Route.
+func validateWeightedHeaders(traffic []TrafficTarget) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, tt := range traffic {
+		if tt.Headers != nil {
+			errs = errs.Also(apis.ErrGeneric(
+				"may not set headers on the traffic targets of a Route split by weight, set them on the Route",
+				"headers").ViaIndex(i))
+		}
+	}
 	return errs
 }
 
 // Validate implements apis.Validatable
 func (rs *RouteSpec) Validate(ctx context.Context) *apis.FieldError {
-	return validateTrafficList(ctx, rs.Traffic).ViaField("traffic")
+	errs := validateTrafficList(ctx, rs.Traffic).ViaField("traffic")
+	errs = errs.Also(rs.Headers.validate().ViaField("headers"))
+	errs = errs.Also(rs.validateFault(ctx).ViaField("fault"))
+	return errs.Also(validateTrafficMatches(rs.Match, rs.Traffic).ViaField("match"))
+}
+
+func (rs *RouteSpec) validateFault(ctx context.Context) *apis.FieldError {
+	if rs.Fault == nil {
+		return nil
+	}
+	if config.FromContextOrDefaults(ctx).Features.RouteFaultInjection == config.Disabled {
+		return &apis.FieldError{
+			Message: "fault injection is disabled",
+			Paths:   []string{apis.CurrentField},
+			Details: "enable the route-fault-injection feature in the config-features ConfigMap",
+		}
+	}
+	return rs.Fault.validate(trafficTags(rs.Traffic))
+}
+
+// validate verifies that the TrafficFault injects a single valid fault,
+// restricted to the requests for one of the tags if any.
+func (tf *TrafficFault) validate(tags sets.String) *apis.FieldError {
+	var errs *apis.FieldError
+	if tf.Percent < 0 || tf.Percent > 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(tf.Percent, 0, 100, "percent"))
+	}
+	switch {
+	case tf.Delay == nil && tf.AbortStatus == 0:
+		errs = errs.Also(apis.ErrMissingOneOf("delay", "abortStatus"))
+	case tf.Delay != nil && tf.AbortStatus != 0:
+		errs = errs.Also(apis.ErrMultipleOneOf("delay", "abortStatus"))
+	case tf.Delay != nil && tf.Delay.Duration <= 0:
+		errs = errs.Also(apis.ErrInvalidValue(tf.Delay.Duration.String(), "delay", "must be positive"))
+	case tf.AbortStatus != 0 && (tf.AbortStatus < 400 || tf.AbortStatus > 599):
+		errs = errs.Also(apis.ErrOutOfBoundsValue(tf.AbortStatus, 400, 599, "abortStatus"))
+	}
+	if tf.Tag != "" && !tags.Has(tf.Tag) {
+		errs = errs.Also(apis.ErrInvalidValue(tf.Tag, "tag", "must be the tag of a traffic target"))
+	}
+	return errs.Also(validateHeaderMatches(tf.Headers))
+}
+
+// reservedHeaderPrefixes are the prefixes of the headers Knative uses to
+// route the requests, like the tag header or the revision headers of the
+// activator, which may not be mutated.
+var reservedHeaderPrefixes = []string{"knative-", "k-"}
+
+// validate verifies that the HeaderMutations only mutate valid headers
+// which are not reserved.
+func (hm *HeaderMutations) validate() *apis.FieldError {
+	if hm == nil {
+		return nil
+	}
+	return hm.Request.validate().ViaField("request").Also(
+		hm.Response.validate().ViaField("response"))
+}
+
+func (ho *HeaderOperations) validate() *apis.FieldError {
+	if ho == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	// Track the operations on each header (to detect conflicts).
+	ops := make(map[string]string, len(ho.Set)+len(ho.Add)+len(ho.Remove))
+	check := func(name, path string) {
+		if msgs := validation.IsHTTPHeaderName(name); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, path, msgs...))
+			return
+		}
+		lower := strings.ToLower(name)
+		for _, prefix := range reservedHeaderPrefixes {
+			if strings.HasPrefix(lower, prefix) {
+				errs = errs.Also(apis.ErrInvalidKeyName(name, path, "the header is reserved by Knative"))
+				return
+			}
+		}
+		if other, ok := ops[lower]; ok {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("Multiple operations on header %q", name),
+				Paths:   []string{path, other},
+			})
+			return
+		}
+		ops[lower] = path
+	}
+	for _, name := range sets.StringKeySet(ho.Set).List() {
+		check(name, "set")
+	}
+	for _, name := range sets.StringKeySet(ho.Add).List() {
+		check(name, "add")
+	}
+	for i, name := range ho.Remove {
+		check(name, fmt.Sprintf("remove[%d]", i))
+	}
+	return errs
+}
+
+func validateTrafficMatches(matches []TrafficMatch, traffic []TrafficTarget) *apis.FieldError {
+	if len(matches) == 0 {
+		return nil
+	}
+	tags := trafficTags(traffic)
+
+	var errs *apis.FieldError
+	for i := range matches {
+		errs = errs.Also(matches[i].validate(tags).ViaIndex(i))
+	}
+	return errs
+}
+
+// trafficTags returns the tags of the traffic targets.
+func trafficTags(traffic []TrafficTarget) sets.String {
+	tags := make(sets.String, len(traffic))
+	for _, tt := range traffic {
+		if tt.Tag != "" {
+			tags.Insert(tt.Tag)
+		}
+	}
+	return tags
+}
+
+// validate verifies that the TrafficMatch has valid header conditions and
+// routes the requests to one of the tags.
+func (tm *TrafficMatch) validate(tags sets.String) *apis.FieldError {
+	var errs *apis.FieldError
+	if tm.Tag == "" {
+		errs = errs.Also(apis.ErrMissingField("tag"))
+	} else if !tags.Has(tm.Tag) {
+		errs = errs.Also(apis.ErrInvalidValue(tm.Tag, "tag", "must be the tag of a traffic target"))
+	}
+
+	if len(tm.Headers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("headers"))
+	}
+	names := make(sets.String, len(tm.Headers))
+	for i := range tm.Headers {
+		errs = errs.Also(tm.Headers[i].validate().ViaFieldIndex("headers", i))
+		errs = errs.Also(checkDuplicateHeader(names, tm.Headers[i].Name, i))
+	}
+	return errs
+}
+
+// validate verifies that the TrafficHeaderMatch has a valid name and value.
+func (hm *TrafficHeaderMatch) validate() *apis.FieldError {
+	errs := validateHeaderName(hm.Name)
+	if hm.Exact == "" {
+		errs = errs.Also(apis.ErrMissingField("exact"))
+	}
+	return errs
+}
+
+// validateHeaderMatches verifies that the HeaderMatches are valid and
+// match distinct headers.
+func validateHeaderMatches(headers []HeaderMatch) *apis.FieldError {
+	var errs *apis.FieldError
+	names := make(sets.String, len(headers))
+	for i := range headers {
+		errs = errs.Also(headers[i].validate().ViaFieldIndex("headers", i))
+		errs = errs.Also(checkDuplicateHeader(names, headers[i].Name, i))
+	}
+	return errs
+}
+
+// checkDuplicateHeader returns an error if the i-th match of a list is for
+// one of the headers in names, which are matched case-insensitively, and
+// records the name otherwise.
+func checkDuplicateHeader(names sets.String, name string, i int) *apis.FieldError {
+	key := strings.ToLower(name)
+	if names.Has(key) {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("Multiple matches for header %q", name),
+			Paths:   []string{fmt.Sprintf("headers[%d].name", i)},
+		}
+	}
+	names.Insert(key)
+	return nil
+}
+
+// validateHeaderName verifies that the name of a matched header is valid.
+func validateHeaderName(name string) *apis.FieldError {
+	if name == "" {
+		return apis.ErrMissingField("name")
+	} else if msgs := validation.IsHTTPHeaderName(name); len(msgs) > 0 {
+		return apis.ErrInvalidValue(name, "name", msgs...)
+	}
+	return nil
+}
+
+// validate verifies that the HeaderMatch has a valid name and a single
+// valid matcher.
+func (hm *HeaderMatch) validate() *apis.FieldError {
+	errs := validateHeaderName(hm.Name)
+
+	var set []string
+	if hm.Exact != "" {
+		set = append(set, "exact")
+	}
+	if hm.Prefix != "" {
+		set = append(set, "prefix")
+	}
+	if hm.Regex != "" {
+		set = append(set, "regex")
+		if _, err := regexp.Compile(hm.Regex); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(hm.Regex, "regex", err.Error()))
+		}
+	}
+	switch len(set) {
+	case 0:
+		errs = errs.Also(apis.ErrMissingOneOf("exact", "prefix", "regex"))
+	case 1:
+	default:
+		errs = errs.Also(apis.ErrMultipleOneOf(set...))
+	}
+	return errs
 }
 
 // Validate verifies that TrafficTarget is properly configured.
@@ -101,9 +368,37 @@ func (tt *TrafficTarget) Validate(ctx context.Context) *apis.FieldError {
 	errs := tt.validateLatestRevision(ctx)
 	errs = tt.validateRevisionAndConfiguration(ctx, errs)
 	errs = tt.validateTrafficPercentage(errs)
+	errs = tt.validatePath(errs)
+	errs = tt.validateHeaders(ctx, errs)
 	return tt.validateURL(ctx, errs)
 }
 
+func (tt *TrafficTarget) validateHeaders(ctx context.Context, errs *apis.FieldError) *apis.FieldError {
+	if tt.Headers == nil {
+		return errs
+	}
+	// The header mutations are not reflected in status.
+	if apis.IsInStatus(ctx) {
+		return errs.Also(apis.ErrDisallowedFields("headers"))
+	}
+	return errs.Also(tt.Headers.validate().ViaField("headers"))
+}
+
+func (tt *TrafficTarget) validatePath(errs *apis.FieldError) *apis.FieldError {
+	if tt.Path == "" {
+		return errs
+	}
+	// The path is matched as a literal prefix of the request paths.
+	if !strings.HasPrefix(tt.Path, "/") || strings.ContainsAny(tt.Path, "?# \t") {
+		errs = errs.Also(apis.ErrInvalidValue(tt.Path, "path", "must be an absolute URL path"))
+	}
+	// The tagged hostnames are served by the tagged targets alone.
+	if tt.Tag != "" {
+		errs = errs.Also(apis.ErrGeneric("may not set a tag on a traffic target with a path", "tag"))
+	}
+	return errs
+}
+
 func (tt *TrafficTarget) validateRevisionAndConfiguration(ctx context.Context, errs *apis.FieldError) *apis.FieldError {
 	// We only validate the sense of latestRevision in the context of a Spec,
 	// and only when it is specified.
@@ -154,9 +449,44 @@ func (tt *TrafficTarget) validateTrafficPercentage(errs *apis.FieldError) *apis.
 		errs = errs.Also(apis.ErrOutOfBoundsValue(
 			*tt.Percent, 0, 100, "percent"))
 	}
+	if tt.Weight != nil {
+		if *tt.Weight < 0 || *tt.Weight > MaxTrafficWeight {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(
+				*tt.Weight, 0, MaxTrafficWeight, "weight"))
+		}
+		// The percent defaults to 0 in older clients.
+		if tt.Percent != nil && *tt.Percent != 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("percent", "weight"))
+		}
+	}
+	if tt.MirrorPercent != nil {
+		if *tt.MirrorPercent < 1 || *tt.MirrorPercent > 100 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(
+				*tt.MirrorPercent, 1, 100, "mirrorPercent"))
+		}
+		// Shadow targets only receive copies of the requests.
+		if tt.Percent != nil && *tt.Percent != 0 {
+			errs = errs.Also(apis.ErrGeneric("must be 0 for a target with a mirrorPercent", "percent"))
+		}
+		if tt.Weight != nil && *tt.Weight != 0 {
+			errs = errs.Also(apis.ErrGeneric("must be 0 for a target with a mirrorPercent", "weight"))
+		}
+	}
 	return errs
 }
 
+// weight returns the share of the traffic of the target in basis points.
+func (tt *TrafficTarget) weight() int64 {
+	switch {
+	case tt.Weight != nil:
+		return *tt.Weight
+	case tt.Percent != nil:
+		return *tt.Percent * (MaxTrafficWeight / 100)
+	default:
+		return 0
+	}
+}
+
 func (tt *TrafficTarget) validateLatestRevision(ctx context.Context) *apis.FieldError {
 	if apis.IsInSpec(ctx) && tt.LatestRevision != nil {
 		lr := *tt.LatestRevision
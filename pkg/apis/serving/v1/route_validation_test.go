@@ -20,12 +20,14 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	network "knative.dev/networking/pkg"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
@@ -244,6 +246,120 @@ func TestTrafficTargetValidation(t *testing.T) {
 			Percent:      ptr.Int64(101),
 		},
 		want: apis.ErrOutOfBoundsValue("101", "0", "100", "percent"),
+	}, {
+		name: "valid shadow",
+		tt: &TrafficTarget{
+			RevisionName:  "foo",
+			Percent:       ptr.Int64(0),
+			MirrorPercent: ptr.Int64(10),
+		},
+	}, {
+		name: "invalid mirror percent",
+		tt: &TrafficTarget{
+			RevisionName:  "foo",
+			MirrorPercent: ptr.Int64(0),
+		},
+		want: apis.ErrOutOfBoundsValue("0", "1", "100", "mirrorPercent"),
+	}, {
+		name: "shadow with traffic",
+		tt: &TrafficTarget{
+			RevisionName:  "foo",
+			Percent:       ptr.Int64(10),
+			MirrorPercent: ptr.Int64(10),
+		},
+		want: apis.ErrGeneric("must be 0 for a target with a mirrorPercent", "percent"),
+	}, {
+		name: "valid weight",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Weight:       ptr.Int64(25),
+		},
+	}, {
+		name: "valid weight with defaulted percent",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Percent:      ptr.Int64(0),
+			Weight:       ptr.Int64(9975),
+		},
+	}, {
+		name: "invalid weight",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Weight:       ptr.Int64(10001),
+		},
+		want: apis.ErrOutOfBoundsValue("10001", "0", "10000", "weight"),
+	}, {
+		name: "percent and weight",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Percent:      ptr.Int64(10),
+			Weight:       ptr.Int64(1000),
+		},
+		want: apis.ErrMultipleOneOf("percent", "weight"),
+	}, {
+		name: "shadow with weight",
+		tt: &TrafficTarget{
+			RevisionName:  "foo",
+			Weight:        ptr.Int64(5),
+			MirrorPercent: ptr.Int64(10),
+		},
+		want: apis.ErrGeneric("must be 0 for a target with a mirrorPercent", "weight"),
+	}, {
+		name: "valid header mutations",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Percent:      ptr.Int64(100),
+			Headers: &HeaderMutations{
+				Request: &HeaderOperations{
+					Set:    map[string]string{"Canary": "true"},
+					Add:    map[string]string{"Via": "canary"},
+					Remove: []string{"Cookie"},
+				},
+				Response: &HeaderOperations{
+					Remove: []string{"Server"},
+				},
+			},
+		},
+		wc: apis.WithinSpec,
+	}, {
+		name: "invalid header mutations",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Percent:      ptr.Int64(100),
+			Headers: &HeaderMutations{
+				Request: &HeaderOperations{
+					Set:    map[string]string{"Knative-Serving-Tag": "canary", "Canary": "true"},
+					Remove: []string{"canary", "K-Network-Hash", "not a header"},
+				},
+				Response: &HeaderOperations{
+					Add: map[string]string{"Knative-Serving-Revision": "foo"},
+				},
+			},
+		},
+		wc: apis.WithinSpec,
+		want: apis.ErrInvalidKeyName("Knative-Serving-Tag", "headers.request.set", "the header is reserved by Knative").Also(
+			&apis.FieldError{
+				Message: `Multiple operations on header "canary"`,
+				Paths:   []string{"headers.request.remove[0]", "headers.request.set"},
+			},
+			apis.ErrInvalidKeyName("K-Network-Hash", "headers.request.remove[1]", "the header is reserved by Knative"),
+			apis.ErrInvalidKeyName("not a header", "headers.request.remove[2]",
+				"a valid HTTP header must consist of alphanumeric characters or '-' (e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+')"),
+			apis.ErrInvalidKeyName("Knative-Serving-Revision", "headers.response.add", "the header is reserved by Knative"),
+		),
+	}, {
+		name: "header mutations in status",
+		tt: &TrafficTarget{
+			RevisionName: "foo",
+			Percent:      ptr.Int64(100),
+			Headers: &HeaderMutations{
+				Request: &HeaderOperations{
+					Set: map[string]string{"Canary": "true"},
+				},
+			},
+		},
+		wc:   apis.WithinStatus,
+		want: apis.ErrDisallowedFields("headers"),
 	}, {
 		name: "disallowed url set",
 		tt: &TrafficTarget{
@@ -437,6 +553,174 @@ func TestRouteValidation(t *testing.T) {
 			Message: "not a DNS 1035 label: [must be no more than 63 characters]",
 			Paths:   []string{"metadata.name"},
 		},
+	}, {
+		name: "valid path groups",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Percent:           ptr.Int64(100),
+				}, {
+					ConfigurationName: "api",
+					Path:              "/api",
+					Percent:           ptr.Int64(90),
+				}, {
+					RevisionName: "api-00001",
+					Path:         "/api",
+					Percent:      ptr.Int64(10),
+				}, {
+					ConfigurationName: "static",
+					Path:              "/static/",
+					Percent:           ptr.Int64(100),
+				}},
+			},
+		},
+	}, {
+		name: "path groups do not sum to 100",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Percent:           ptr.Int64(100),
+				}, {
+					ConfigurationName: "api",
+					Path:              "/api",
+					Percent:           ptr.Int64(90),
+				}, {
+					ConfigurationName: "static",
+					Path:              "/static",
+				}},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: `Traffic targets with path "/api" sum to 90, want 100`,
+			Paths:   []string{"spec.traffic"},
+		}).Also(&apis.FieldError{
+			Message: `Traffic targets with path "/static" sum to 0, want 100`,
+			Paths:   []string{"spec.traffic"},
+		}),
+	}, {
+		name: "valid weights",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Percent:           ptr.Int64(99),
+				}, {
+					RevisionName: "web-00001",
+					Weight:       ptr.Int64(75),
+				}, {
+					RevisionName: "web-00002",
+					Weight:       ptr.Int64(25),
+				}, {
+					ConfigurationName: "api",
+					Path:              "/api",
+					Weight:            ptr.Int64(10000),
+				}},
+				Headers: &HeaderMutations{
+					Request: &HeaderOperations{
+						Set: map[string]string{"Via": "web"},
+					},
+				},
+			},
+		},
+	}, {
+		name: "weights do not sum to 10000",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Percent:           ptr.Int64(99),
+				}, {
+					RevisionName: "web-00001",
+					Weight:       ptr.Int64(75),
+				}, {
+					ConfigurationName: "api",
+					Path:              "/api",
+					Weight:            ptr.Int64(9999),
+				}},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: "Traffic targets sum to 9975 basis points, want 10000",
+			Paths:   []string{"spec.traffic"},
+		}).Also(&apis.FieldError{
+			Message: `Traffic targets with path "/api" sum to 9999 basis points, want 10000`,
+			Paths:   []string{"spec.traffic"},
+		}),
+	}, {
+		name: "target headers with weights",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Weight:            ptr.Int64(9990),
+				}, {
+					RevisionName: "web-00001",
+					Weight:       ptr.Int64(10),
+					Headers: &HeaderMutations{
+						Request: &HeaderOperations{
+							Set: map[string]string{"Canary": "true"},
+						},
+					},
+				}},
+			},
+		},
+		want: apis.ErrGeneric(
+			"may not set headers on the traffic targets of a Route split by weight, set them on the Route",
+			"spec.traffic[1].headers"),
+	}, {
+		name: "invalid path",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Percent:           ptr.Int64(100),
+				}, {
+					ConfigurationName: "api",
+					Path:              "api?v=1",
+					Percent:           ptr.Int64(100),
+				}},
+			},
+		},
+		want: apis.ErrInvalidValue("api?v=1", "spec.traffic[1].path", "must be an absolute URL path"),
+	}, {
+		name: "path with tag",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					ConfigurationName: "web",
+					Percent:           ptr.Int64(100),
+				}, {
+					Tag:               "api",
+					ConfigurationName: "api",
+					Path:              "/api",
+					Percent:           ptr.Int64(100),
+				}},
+			},
+		},
+		want: apis.ErrGeneric("may not set a tag on a traffic target with a path", "spec.traffic[1].tag"),
 	}, {
 		name: "invalid tag name",
 		r: &Route{
@@ -455,6 +739,25 @@ func TestRouteValidation(t *testing.T) {
 			Message: "invalid value: not a DNS 1035 label: [a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')]",
 			Paths:   []string{"spec.traffic.tag[0]"},
 		},
+	}, {
+		name: "reserved route header",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "reserved-header",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					RevisionName: "bar",
+					Percent:      ptr.Int64(100),
+				}},
+				Headers: &HeaderMutations{
+					Request: &HeaderOperations{
+						Set: map[string]string{"Knative-Serving-Default-Route": "false"},
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidKeyName("Knative-Serving-Default-Route", "spec.headers.request.set", "the header is reserved by Knative"),
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -467,6 +770,213 @@ func TestRouteValidation(t *testing.T) {
 	}
 }
 
+func TestRouteMatchValidation(t *testing.T) {
+	traffic := []TrafficTarget{{
+		RevisionName: "foo",
+		Percent:      ptr.Int64(100),
+	}, {
+		Tag:          "canary",
+		RevisionName: "bar",
+		Percent:      ptr.Int64(0),
+	}}
+	tests := []struct {
+		name  string
+		match []TrafficMatch
+		want  *apis.FieldError
+	}{{
+		name: "valid",
+		match: []TrafficMatch{{
+			Tag: "canary",
+			Headers: []TrafficHeaderMatch{{
+				Name:  "X-User",
+				Exact: "alice",
+			}, {
+				Name:  "X-Version",
+				Exact: "v2",
+			}},
+		}},
+	}, {
+		name: "missing tag",
+		match: []TrafficMatch{{
+			Headers: []TrafficHeaderMatch{{Name: "X-User", Exact: "alice"}},
+		}},
+		want: apis.ErrMissingField("spec.match[0].tag"),
+	}, {
+		name: "unknown tag",
+		match: []TrafficMatch{{
+			Tag:     "stable",
+			Headers: []TrafficHeaderMatch{{Name: "X-User", Exact: "alice"}},
+		}},
+		want: apis.ErrInvalidValue("stable", "spec.match[0].tag", "must be the tag of a traffic target"),
+	}, {
+		name: "no conditions",
+		match: []TrafficMatch{{
+			Tag: "canary",
+		}},
+		want: apis.ErrMissingField("spec.match[0].headers"),
+	}, {
+		name: "header without value",
+		match: []TrafficMatch{{
+			Tag:     "canary",
+			Headers: []TrafficHeaderMatch{{Name: "X-User"}},
+		}},
+		want: apis.ErrMissingField("spec.match[0].headers[0].exact"),
+	}, {
+		name: "invalid header name",
+		match: []TrafficMatch{{
+			Tag:     "canary",
+			Headers: []TrafficHeaderMatch{{Name: "X User", Exact: "alice"}},
+		}},
+		want: &apis.FieldError{
+			Message: "invalid value: X User",
+			Paths:   []string{"spec.match[0].headers[0].name"},
+			Details: "a valid HTTP header must consist of alphanumeric characters or '-' (e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+')",
+		},
+	}, {
+		name: "duplicate header",
+		match: []TrafficMatch{{
+			Tag: "canary",
+			Headers: []TrafficHeaderMatch{{
+				Name:  "X-User",
+				Exact: "alice",
+			}, {
+				Name:  "x-user",
+				Exact: "bob",
+			}},
+		}},
+		want: &apis.FieldError{
+			Message: `Multiple matches for header "x-user"`,
+			Paths:   []string{"spec.match[0].headers[1].name"},
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: RouteSpec{
+					Traffic: traffic,
+					Match:   test.match,
+				},
+			}
+			got := r.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
+
+func TestRouteFaultValidation(t *testing.T) {
+	traffic := []TrafficTarget{{
+		RevisionName: "foo",
+		Percent:      ptr.Int64(100),
+	}, {
+		Tag:          "canary",
+		RevisionName: "bar",
+		Percent:      ptr.Int64(0),
+	}}
+	tests := []struct {
+		name     string
+		fault    *TrafficFault
+		disabled bool
+		want     *apis.FieldError
+	}{{
+		name: "valid delay",
+		fault: &TrafficFault{
+			Percent: 10,
+			Delay:   &metav1.Duration{Duration: 2 * time.Second},
+		},
+	}, {
+		name: "valid abort",
+		fault: &TrafficFault{
+			Percent:     100,
+			AbortStatus: 503,
+			Tag:         "canary",
+			Headers: []HeaderMatch{{
+				Name:   "User-Agent",
+				Prefix: "curl/",
+			}},
+		},
+	}, {
+		name: "disabled",
+		fault: &TrafficFault{
+			Percent:     100,
+			AbortStatus: 503,
+		},
+		disabled: true,
+		want: &apis.FieldError{
+			Message: "fault injection is disabled",
+			Paths:   []string{"spec.fault"},
+			Details: "enable the route-fault-injection feature in the config-features ConfigMap",
+		},
+	}, {
+		name: "missing fault",
+		fault: &TrafficFault{
+			Percent: 100,
+		},
+		want: apis.ErrMissingOneOf("spec.fault.delay", "spec.fault.abortStatus"),
+	}, {
+		name: "both faults",
+		fault: &TrafficFault{
+			Percent:     100,
+			Delay:       &metav1.Duration{Duration: time.Second},
+			AbortStatus: 503,
+		},
+		want: apis.ErrMultipleOneOf("spec.fault.delay", "spec.fault.abortStatus"),
+	}, {
+		name: "invalid values",
+		fault: &TrafficFault{
+			Percent:     101,
+			AbortStatus: 200,
+			Tag:         "stable",
+			Headers: []HeaderMatch{{
+				Name: "User-Agent",
+			}},
+		},
+		want: apis.ErrOutOfBoundsValue(101, 0, 100, "spec.fault.percent").Also(
+			apis.ErrOutOfBoundsValue(200, 400, 599, "spec.fault.abortStatus"),
+			apis.ErrInvalidValue("stable", "spec.fault.tag", "must be the tag of a traffic target"),
+			apis.ErrMissingOneOf("spec.fault.headers[0].exact", "spec.fault.headers[0].prefix", "spec.fault.headers[0].regex"),
+		),
+	}, {
+		name: "negative delay",
+		fault: &TrafficFault{
+			Percent: 100,
+			Delay:   &metav1.Duration{Duration: -time.Second},
+		},
+		want: apis.ErrInvalidValue("-1s", "spec.fault.delay", "must be positive"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			features, _ := config.NewFeaturesConfigFromMap(map[string]string{
+				"route-fault-injection": "Enabled",
+			})
+			if test.disabled {
+				features.RouteFaultInjection = config.Disabled
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{Features: features})
+			r := &Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: RouteSpec{
+					Traffic: traffic,
+					Fault:   test.fault,
+				},
+			}
+			got := r.Validate(ctx)
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
+
 func TestRouteLabelValidation(t *testing.T) {
 	validRouteSpec := RouteSpec{
 		Traffic: []TrafficTarget{{
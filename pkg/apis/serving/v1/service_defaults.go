@@ -26,6 +26,7 @@ import (
 // SetDefaults implements apis.Defaultable
 func (s *Service) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, s.ObjectMeta)
+	serving.Warn(ctx, s.Spec.Template.Spec.defaultingWarnings(ctx).ViaField("spec.template.spec"))
 	s.Spec.SetDefaults(apis.WithinSpec(ctx))
 
 	if apis.IsInUpdate(ctx) {
@@ -696,6 +696,17 @@ func TestRouteStatusPropagation(t *testing.T) {
 			Percent:      nil,
 			RevisionName: "oldstuff",
 		}},
+		Rollouts: []RolloutStatus{{
+			ConfigurationName: "stuff",
+			State:             RolloutStateProgressing,
+			Revisions: []RolloutRevisionStatus{{
+				RevisionName: "oldstuff",
+				Percent:      60,
+			}, {
+				RevisionName: "newstuff",
+				Percent:      40,
+			}},
+		}},
 	}
 
 	svc.Status.PropagateRouteStatus(&RouteStatus{
@@ -20,11 +20,15 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
 // Validate makes sure that Service is properly configured.
 func (s *Service) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// The annotations of the Namespace may override the features.
+	ctx = config.WithinNamespace(ctx, s.Namespace)
+
 	// If we are in a status sub resource update, the metadata and spec cannot change.
 	// So, to avoid rejecting controller status updates due to validations that may
 	// have changed (i.e. due to config-defaults changes), we elide the metadata and
@@ -32,10 +36,18 @@ func (s *Service) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(serving.ValidateObjectMetadata(ctx, s.GetObjectMeta(), false))
 		errs = errs.Also(serving.ValidateRolloutDurationAnnotation(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateRolloutAnalysisAnnotations(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateRolloutStepsAnnotations(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateRolloutControlAnnotation(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateGCAnnotations(s.GetAnnotations()).ViaField("annotations"))
 		errs = errs.ViaField("metadata")
 
 		ctx = apis.WithinParent(ctx, s.ObjectMeta)
 		errs = errs.Also(s.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+		serving.Warn(ctx, serving.DeprecatedAnnotationWarnings(s.GetAnnotations(),
+			serving.RolloutDurationAnnotation).ViaField("metadata.annotations").Also(
+			s.Spec.Template.warnings().ViaField("spec.template")))
 	}
 
 	if apis.IsInUpdate(ctx) {
@@ -26,6 +26,7 @@ import (
 	network "knative.dev/networking/pkg"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
@@ -788,3 +789,76 @@ func TestServiceAnnotationUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceWarnings(t *testing.T) {
+	service := func(annos, templateAnnos map[string]string, containerConcurrency, timeout int64) *Service {
+		s := &Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "valid",
+				Annotations: annos,
+			},
+			Spec: getServiceSpec("busybox"),
+		}
+		s.Spec.Template.Annotations = templateAnnos
+		s.Spec.Template.Spec.ContainerConcurrency = ptr.Int64(containerConcurrency)
+		s.Spec.Template.Spec.TimeoutSeconds = ptr.Int64(timeout)
+		return s
+	}
+
+	tests := []struct {
+		name string
+		s    *Service
+		want string
+	}{{
+		name: "no warnings",
+		s: service(map[string]string{
+			serving.RolloutDurationKey: "60s",
+		}, map[string]string{
+			autoscaling.MinScaleAnnotationKey: "1",
+			autoscaling.TargetAnnotationKey:   "10",
+		}, 10, 300),
+	}, {
+		name: "deprecated annotations",
+		s: service(map[string]string{
+			serving.GroupName + "/rolloutDuration": "60s",
+		}, map[string]string{
+			autoscaling.GroupName + "/minScale": "1",
+		}, 0, 300),
+		want: "deprecated annotation, use autoscaling.knative.dev/min-scale instead: spec.template.metadata.annotations.autoscaling.knative.dev/minScale\n" +
+			"deprecated annotation, use serving.knative.dev/rollout-duration instead: metadata.annotations.serving.knative.dev/rolloutDuration",
+	}, {
+		name: "target above containerConcurrency",
+		s: service(nil, map[string]string{
+			autoscaling.TargetAnnotationKey: "100",
+		}, 1, 300),
+		want: "target 100 exceeds containerConcurrency 1, which caps it: " +
+			"spec.template.metadata.annotations.autoscaling.knative.dev/target, spec.template.spec.containerConcurrency",
+	}, {
+		name: "rps target above containerConcurrency",
+		s: service(nil, map[string]string{
+			autoscaling.MetricAnnotationKey: autoscaling.RPS,
+			autoscaling.TargetAnnotationKey: "100",
+		}, 1, 300),
+	}, {
+		name: "target with unlimited containerConcurrency",
+		s: service(nil, map[string]string{
+			autoscaling.TargetAnnotationKey: "100",
+		}, 0, 300),
+	}, {
+		name: "defaulted timeout",
+		s:    service(nil, nil, 0, 0),
+		want: "0 is replaced with the default of 300: spec.template.spec.timeoutSeconds",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := serving.WithWarnings(context.Background())
+			test.s.SetDefaults(ctx)
+			if err := test.s.Validate(ctx); err != nil {
+				t.Fatal("Validate() =", err)
+			}
+			if got := serving.GetWarnings(ctx).Error(); got != test.want {
+				t.Errorf("Warnings = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
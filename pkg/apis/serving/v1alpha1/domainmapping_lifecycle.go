@@ -112,6 +112,16 @@ func (dms *DomainMappingStatus) MarkCertificateProvisionFailed(name string) {
 		"Certificate %s failed to be provisioned.", name)
 }
 
+// MarkWildcardCertificateNotSupported marks the
+// DomainMappingConditionCertificateProvisioned condition to indicate that no
+// certificate can be provisioned for the wildcard host, as the certificate
+// class does not issue wildcard certificates.
+func (dms *DomainMappingStatus) MarkWildcardCertificateNotSupported(host string) {
+	domainMappingCondSet.Manage(dms).MarkFalse(DomainMappingConditionCertificateProvisioned,
+		"WildcardCertificateNotSupported",
+		"Wildcard host %s requires a certificate issued through DNS-01 challenges, which is not enabled.", host)
+}
+
 // MarkHTTPDowngrade sets DomainMappingConditionCertificateProvisioned to true when plain
 // HTTP is enabled even when Certificate is not ready.
 func (dms *DomainMappingStatus) MarkHTTPDowngrade(name string) {
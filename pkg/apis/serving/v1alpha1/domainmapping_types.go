@@ -80,20 +80,62 @@ type SecretTLS struct {
 type DomainMappingSpec struct {
 	// Ref specifies the target of the Domain Mapping.
 	//
-	// The object identified by the Ref must be an Addressable with a URL of the
-	// form `{name}.{namespace}.{domain}` where `{domain}` is the cluster domain,
-	// and `{name}` and `{namespace}` are the name and namespace of a Kubernetes
-	// Service.
+	// The object identified by the Ref must be an Addressable with a URL
+	// without a path. This contract is satisfied by Knative types such as
+	// Knative Services and Knative Routes, and by Kubernetes Services.
 	//
-	// This contract is satisfied by Knative types such as Knative Services and
-	// Knative Routes, and by Kubernetes Services.
+	// The object may be in another namespace when a DomainMappingGrant of
+	// its namespace allows it. Exactly one of Ref and URL must be set.
+	// +optional
 	Ref duckv1.KReference `json:"ref"`
 
+	// URL is an external URL targeted instead of the Ref, reached through
+	// an ExternalName Service. It may only have the http scheme, a host and
+	// a port, e.g. `http://legacy.example.com:8080`.
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+
+	// Hosts are additional hostnames mapped to the Ref along with the name of
+	// the DomainMapping. A host may be a wildcard, `*.` followed by a domain,
+	// to map all the subdomains of the domain: `*.customer.example.com`
+	// maps `a.customer.example.com` but not `customer.example.com`. Like the
+	// name, each host is claimed by the namespace of the DomainMapping, and
+	// the wildcards of different namespaces may not overlap. The requests of
+	// a wildcard host reach the Ref with the host of the Ref, as the original
+	// host cannot be forwarded to it.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Paths map the requests whose path starts with a prefix to other targets
+	// than the Ref, which receives the remaining requests. The longest
+	// matching prefix wins.
+	// +optional
+	Paths []DomainMappingPath `json:"paths,omitempty"`
+
 	// TLS allows the DomainMapping to terminate TLS traffic with an existing secret.
 	// +optional
 	TLS *SecretTLS `json:"tls,omitempty"`
 }
 
+// DomainMappingPath maps the requests whose path starts with a prefix to a
+// target.
+type DomainMappingPath struct {
+	// Prefix is matched as a literal prefix of the request paths, e.g.
+	// `/billing`.
+	Prefix string `json:"prefix"`
+
+	// Ref specifies the target of the requests, it follows the contract of
+	// the Ref of the DomainMapping, including DomainMappingGrants.
+	Ref duckv1.KReference `json:"ref"`
+
+	// RewritePrefix replaces the prefix of the request paths before they
+	// reach the Ref: `/` serves `/billing/invoices` as `/invoices`. The
+	// paths are rewritten by the queue proxy, so only the Revisions of
+	// Knative Services and Routes receive rewritten paths.
+	// +optional
+	RewritePrefix *string `json:"rewritePrefix,omitempty"`
+}
+
 // DomainMappingStatus describes the current state of the DomainMapping.
 type DomainMappingStatus struct {
 	duckv1.Status `json:",inline"`
@@ -105,6 +147,28 @@ type DomainMappingStatus struct {
 	// Address holds the information needed for a DomainMapping to be the target of an event.
 	// +optional
 	Address *duckv1.Addressable `json:"address,omitempty"`
+
+	// Paths reports the resolution of the Ref of each path of the spec.
+	// +optional
+	Paths []DomainMappingPathStatus `json:"paths,omitempty"`
+}
+
+// DomainMappingPathStatus describes the resolution of the Ref of a path.
+type DomainMappingPathStatus struct {
+	// Prefix is the prefix of the path.
+	Prefix string `json:"prefix"`
+
+	// URL is the URL the Ref of the path resolved to.
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+
+	// ReferenceResolved is whether the Ref of the path was resolved to an
+	// existing object.
+	ReferenceResolved bool `json:"referenceResolved"`
+
+	// Message explains why the Ref of the path was not resolved.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 const (
@@ -19,11 +19,14 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/network"
 	"knative.dev/serving/pkg/apis/serving"
 )
@@ -55,6 +58,10 @@ func (dm *DomainMapping) validateMetadata(ctx context.Context) (errs *apis.Field
 		errs = errs.Also(apis.ErrGeneric(
 			fmt.Sprintf("invalid name %q: must not be a subdomain of cluster local domain %q", dm.Name, clusterLocalDomain), "name"))
 	}
+	if label, ok := reservedLabel(dm.Name); ok {
+		errs = errs.Also(apis.ErrGeneric(
+			fmt.Sprintf("invalid name %q: label %q is reserved", dm.Name, label), "name"))
+	}
 
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*DomainMapping)
@@ -69,5 +76,128 @@ func (dm *DomainMapping) validateMetadata(ctx context.Context) (errs *apis.Field
 
 // Validate makes sure the DomainMappingSpec is properly configured.
 func (spec *DomainMappingSpec) Validate(ctx context.Context) *apis.FieldError {
-	return spec.Ref.Validate(ctx).ViaField("ref")
+	// The references to other namespaces are allowed by DomainMappingGrants.
+	ctx = apis.AllowDifferentNamespace(ctx)
+	var errs *apis.FieldError
+	switch {
+	case spec.URL != nil && spec.Ref != (duckv1.KReference{}):
+		errs = apis.ErrMultipleOneOf("ref", "url")
+	case spec.URL != nil:
+		errs = validateExternalURL(spec.URL).ViaField("url")
+	default:
+		errs = spec.Ref.Validate(ctx).ViaField("ref")
+	}
+	errs = errs.Also(spec.validateHosts(ctx))
+	return errs.Also(spec.validatePaths(ctx))
+}
+
+// validateHosts validates the additional hosts of the DomainMapping, which
+// must be distinct from its name and from each other.
+func (spec *DomainMappingSpec) validateHosts(ctx context.Context) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	if name := apis.ParentMeta(ctx).Name; name != "" {
+		seen.Insert(name)
+	}
+	clusterLocalDomain := network.GetClusterDomainName()
+	for i, host := range spec.Hosts {
+		if seen.Has(host) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate host %q", host), apis.CurrentField).ViaFieldIndex("hosts", i))
+			continue
+		}
+		seen.Insert(host)
+
+		domain := strings.TrimPrefix(host, "*.")
+		if err := validation.IsFullyQualifiedDomainName(field.NewPath("hosts").Index(i), domain); err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(
+				"invalid host %q: %s", host, err.ToAggregate()), apis.CurrentField).ViaFieldIndex("hosts", i))
+		} else if domain == clusterLocalDomain || strings.HasSuffix(domain, "."+clusterLocalDomain) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(
+				"invalid host %q: must not be a subdomain of cluster local domain %q", host, clusterLocalDomain), apis.CurrentField).ViaFieldIndex("hosts", i))
+		} else if label, ok := reservedLabel(domain); ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(
+				"invalid host %q: label %q is reserved", host, label), apis.CurrentField).ViaFieldIndex("hosts", i))
+		}
+	}
+	return errs
+}
+
+// reservedLabel returns the first label of the host with hyphens in its third
+// and fourth positions, which IDNA reserves, save for the `xn--` labels of
+// internationalized names. The ClusterDomainClaims of wildcard hosts are
+// named with such a label.
+func reservedLabel(host string) (string, bool) {
+	for _, label := range strings.Split(host, ".") {
+		if len(label) >= 4 && label[2:4] == "--" && !strings.HasPrefix(label, "xn--") {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// validatePaths validates the paths of the DomainMapping, whose prefixes
+// must be distinct absolute URL paths.
+func (spec *DomainMappingSpec) validatePaths(ctx context.Context) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, path := range spec.Paths {
+		errs = errs.Also(path.Validate(ctx).ViaFieldIndex("paths", i))
+		if seen.Has(path.Prefix) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate prefix %q", path.Prefix), "prefix").ViaFieldIndex("paths", i))
+		}
+		seen.Insert(path.Prefix)
+	}
+	return errs
+}
+
+// Validate makes sure the DomainMappingPath is properly configured.
+func (path *DomainMappingPath) Validate(ctx context.Context) *apis.FieldError {
+	errs := path.Ref.Validate(ctx).ViaField("ref")
+	// The prefix "/" would shadow the Ref of the DomainMapping.
+	if !isAbsolutePath(path.Prefix) || path.Prefix == "/" {
+		errs = errs.Also(apis.ErrInvalidValue(path.Prefix, "prefix", "must be an absolute URL path other than /"))
+	}
+	if path.RewritePrefix != nil && !isAbsolutePath(*path.RewritePrefix) {
+		errs = errs.Also(apis.ErrInvalidValue(*path.RewritePrefix, "rewritePrefix", "must be an absolute URL path"))
+	}
+	return errs
+}
+
+// validateExternalURL validates an external URL, which the Ingress can reach
+// through an ExternalName Service with its host and port alone. The host must
+// be a fully qualified domain name outside the cluster local domain: the IP
+// addresses, such as that of the metadata server of the cloud provider, and
+// the Services of the cluster are not external targets.
+func validateExternalURL(u *apis.URL) (errs *apis.FieldError) {
+	if u.Scheme != "http" {
+		errs = errs.Also(apis.ErrInvalidValue(u.Scheme, "scheme", "must be http"))
+	}
+	clusterLocalDomain := network.GetClusterDomainName()
+	if host := u.URL().Hostname(); host == "" {
+		errs = errs.Also(apis.ErrMissingField("host"))
+	} else if isIPAddress(host) {
+		errs = errs.Also(apis.ErrInvalidValue(host, "host", "must not be an IP address"))
+	} else if msgs := validation.IsDNS1123Subdomain(host); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(host, "host", msgs...))
+	} else if !strings.Contains(host, ".") {
+		// The search domains of the cluster would resolve it.
+		errs = errs.Also(apis.ErrInvalidValue(host, "host", "must be a fully qualified domain name"))
+	} else if host == clusterLocalDomain || strings.HasSuffix(host, "."+clusterLocalDomain) {
+		errs = errs.Also(apis.ErrInvalidValue(host, "host",
+			fmt.Sprintf("must not be a subdomain of cluster local domain %q, use ref instead", clusterLocalDomain)))
+	}
+	if u.User != nil || strings.TrimSuffix(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+		errs = errs.Also(apis.ErrInvalidValue(u.String(), apis.CurrentField, "may only have a scheme, a host and a port"))
+	}
+	return errs
+}
+
+// isIPAddress returns whether the host is an IP address, including the
+// shorthands of IPv4 addresses, such as 127.1, whose last label is numeric
+// while no top-level domain is.
+func isIPAddress(host string) bool {
+	tld := host[strings.LastIndex(host, ".")+1:]
+	return net.ParseIP(host) != nil || strings.Trim(tld, "0123456789") == ""
+}
+
+func isAbsolutePath(path string) bool {
+	return strings.HasPrefix(path, "/") && !strings.ContainsAny(path, "?# \t")
 }
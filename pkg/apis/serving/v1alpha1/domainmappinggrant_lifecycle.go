@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (g *DomainMappingGrant) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("DomainMappingGrant")
+}
+
+// Allows returns whether the grant allows the DomainMappings of the namespace
+// to reference the object of its namespace identified by ref.
+func (g *DomainMappingGrant) Allows(namespace string, ref *duckv1.KReference) bool {
+	if ref.Namespace != g.Namespace {
+		return false
+	}
+	from := false
+	for _, f := range g.Spec.From {
+		if f.Namespace == namespace {
+			from = true
+			break
+		}
+	}
+	if !from {
+		return false
+	}
+
+	group := ref.Group
+	if group == "" {
+		// The APIVersion was validated, the core API group has no group.
+		gv, _ := schema.ParseGroupVersion(ref.APIVersion)
+		group = gv.Group
+	}
+	for _, t := range g.Spec.To {
+		if t.Group == group && t.Kind == ref.Kind && (t.Name == "" || t.Name == ref.Name) {
+			return true
+		}
+	}
+	return false
+}
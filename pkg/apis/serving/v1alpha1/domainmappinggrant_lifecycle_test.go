@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestDomainMappingGrantAllows(t *testing.T) {
+	g := &DomainMappingGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant",
+			Namespace: "eventing",
+		},
+		Spec: DomainMappingGrantSpec{
+			From: []DomainMappingGrantFrom{{Namespace: "web"}},
+			To: []DomainMappingGrantTo{{
+				Group: "eventing.knative.dev",
+				Kind:  "Broker",
+				Name:  "default",
+			}, {
+				Kind: "Service",
+			}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		ref       duckv1.KReference
+		want      bool
+	}{{
+		name:      "named object",
+		namespace: "web",
+		ref:       duckv1.KReference{Namespace: "eventing", APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "default"},
+		want:      true,
+	}, {
+		name:      "named object by group",
+		namespace: "web",
+		ref:       duckv1.KReference{Namespace: "eventing", Group: "eventing.knative.dev", Kind: "Broker", Name: "default"},
+		want:      true,
+	}, {
+		name:      "other name",
+		namespace: "web",
+		ref:       duckv1.KReference{Namespace: "eventing", APIVersion: "eventing.knative.dev/v1", Kind: "Broker", Name: "other"},
+	}, {
+		name:      "any object of the core group",
+		namespace: "web",
+		ref:       duckv1.KReference{Namespace: "eventing", APIVersion: "v1", Kind: "Service", Name: "any"},
+		want:      true,
+	}, {
+		name:      "other group",
+		namespace: "web",
+		ref:       duckv1.KReference{Namespace: "eventing", APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "any"},
+	}, {
+		name:      "other namespace of the DomainMappings",
+		namespace: "other",
+		ref:       duckv1.KReference{Namespace: "eventing", APIVersion: "v1", Kind: "Service", Name: "any"},
+	}, {
+		name:      "other namespace of the object",
+		namespace: "web",
+		ref:       duckv1.KReference{Namespace: "other", APIVersion: "v1", Kind: "Service", Name: "any"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := g.Allows(test.namespace, &test.ref); got != test.want {
+				t.Errorf("Allows() = %t, want: %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDomainMappingGrantGetGroupVersionKind(t *testing.T) {
+	g := &DomainMappingGrant{}
+	want := SchemeGroupVersion.WithKind("DomainMappingGrant")
+	if got := g.GetGroupVersionKind(); got != want {
+		t.Errorf("GetGroupVersionKind() = %v, want: %v", got, want)
+	}
+}
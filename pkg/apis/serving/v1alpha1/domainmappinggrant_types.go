@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/apis"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainMappingGrant allows the DomainMappings of other namespaces to
+// reference objects in its namespace.
+type DomainMappingGrant struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the DomainMappingGrant.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Spec DomainMappingGrantSpec `json:"spec,omitempty"`
+}
+
+// Verify that DomainMappingGrant adheres to the appropriate interfaces.
+var (
+	// Check that DomainMappingGrant may be validated and defaulted.
+	_ apis.Validatable = (*DomainMappingGrant)(nil)
+	_ apis.Defaultable = (*DomainMappingGrant)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainMappingGrantList is a collection of DomainMappingGrant objects.
+type DomainMappingGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of DomainMappingGrant objects.
+	Items []DomainMappingGrant `json:"items"`
+}
+
+// DomainMappingGrantSpec describes the references the DomainMappingGrant
+// allows. The DomainMappings of every namespace of From may reference every
+// object of To.
+type DomainMappingGrantSpec struct {
+	// From are the namespaces whose DomainMappings may reference the objects.
+	From []DomainMappingGrantFrom `json:"from"`
+
+	// To are the objects of the namespace of the DomainMappingGrant which
+	// may be referenced.
+	To []DomainMappingGrantTo `json:"to"`
+}
+
+// DomainMappingGrantFrom identifies the DomainMappings of a namespace.
+type DomainMappingGrantFrom struct {
+	// Namespace is the namespace of the DomainMappings.
+	Namespace string `json:"namespace"`
+}
+
+// DomainMappingGrantTo identifies the objects of a kind, or one of them.
+type DomainMappingGrantTo struct {
+	// Group is the API group of the objects, empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the objects.
+	Kind string `json:"kind"`
+
+	// Name restricts the objects to the object with the name.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate makes sure that DomainMappingGrant is properly configured.
+func (g *DomainMappingGrant) Validate(ctx context.Context) *apis.FieldError {
+	return g.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate makes sure the DomainMappingGrantSpec is properly configured.
+func (spec *DomainMappingGrantSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(spec.From) == 0 {
+		errs = errs.Also(apis.ErrMissingField("from"))
+	}
+	for i, f := range spec.From {
+		if msgs := validation.IsDNS1123Label(f.Namespace); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(f.Namespace, "namespace", msgs...).ViaFieldIndex("from", i))
+		}
+	}
+
+	if len(spec.To) == 0 {
+		errs = errs.Also(apis.ErrMissingField("to"))
+	}
+	for i, t := range spec.To {
+		if t.Kind == "" {
+			errs = errs.Also(apis.ErrMissingField("kind").ViaFieldIndex("to", i))
+		}
+		if t.Group != "" {
+			if msgs := validation.IsDNS1123Subdomain(t.Group); len(msgs) > 0 {
+				errs = errs.Also(apis.ErrInvalidValue(t.Group, "group", msgs...).ViaFieldIndex("to", i))
+			}
+		}
+	}
+	return errs
+}
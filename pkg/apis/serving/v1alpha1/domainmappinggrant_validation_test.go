@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestDomainMappingGrantValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *DomainMappingGrant
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		g: &DomainMappingGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "grant",
+				Namespace: "eventing",
+			},
+			Spec: DomainMappingGrantSpec{
+				From: []DomainMappingGrantFrom{{Namespace: "web"}},
+				To: []DomainMappingGrantTo{{
+					Group: "eventing.knative.dev",
+					Kind:  "Broker",
+					Name:  "default",
+				}, {
+					Kind: "Service",
+				}},
+			},
+		},
+	}, {
+		name: "empty",
+		g:    &DomainMappingGrant{},
+		want: apis.ErrMissingField("spec.from", "spec.to"),
+	}, {
+		name: "invalid",
+		g: &DomainMappingGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "grant",
+				Namespace: "eventing",
+			},
+			Spec: DomainMappingGrantSpec{
+				From: []DomainMappingGrantFrom{{Namespace: "Web"}},
+				To: []DomainMappingGrantTo{{
+					Group: "eventing..knative.dev",
+				}},
+			},
+		},
+		want: apis.ErrInvalidValue("Web", "spec.from[0].namespace",
+			"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')").Also(
+			apis.ErrMissingField("spec.to[0].kind"),
+			apis.ErrInvalidValue("eventing..knative.dev", "spec.to[0].group",
+				"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+		),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.g.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got):\n%s", cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
@@ -26,7 +26,12 @@ import (
 // SetDefaults implements apis.Defaultable.
 func (dm *DomainMapping) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, dm.ObjectMeta)
-	dm.Spec.Ref.SetDefaults(apis.WithinSpec(ctx))
+	if dm.Spec.URL == nil {
+		dm.Spec.Ref.SetDefaults(apis.WithinSpec(ctx))
+	}
+	for i := range dm.Spec.Paths {
+		dm.Spec.Paths[i].Ref.SetDefaults(apis.WithinSpec(ctx))
+	}
 
 	if apis.IsInUpdate(ctx) {
 		serving.SetUserInfo(ctx, apis.GetBaseline(ctx).(*DomainMapping).Spec, dm.Spec, dm)
@@ -75,6 +75,55 @@ func TestDomainMappingDefaulting(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "url",
+		in: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "legacy.example.com"},
+			},
+		},
+		out: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "legacy.example.com"},
+			},
+		},
+	}, {
+		name: "empty path ref namespace",
+		in: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "some-namespace",
+				},
+				Paths: []DomainMappingPath{{
+					Prefix: "/billing",
+				}},
+			},
+		},
+		out: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "some-namespace",
+				},
+				Paths: []DomainMappingPath{{
+					Prefix: "/billing",
+					Ref: duckv1.KReference{
+						Namespace: "some-namespace",
+					},
+				}},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -149,6 +149,14 @@ func TestCertificateProvisionFailed(t *testing.T) {
 	apistest.CheckConditionFailed(dms, DomainMappingConditionCertificateProvisioned, t)
 }
 
+func TestWildcardCertificateNotSupported(t *testing.T) {
+	dms := &DomainMappingStatus{}
+	dms.InitializeConditions()
+	dms.MarkWildcardCertificateNotSupported("*.example.com")
+
+	apistest.CheckConditionFailed(dms, DomainMappingConditionCertificateProvisioned, t)
+}
+
 func TestDomainMappingNotOwnCertificate(t *testing.T) {
 	dms := &DomainMappingStatus{}
 	dms.InitializeConditions()
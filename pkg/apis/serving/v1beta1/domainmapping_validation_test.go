@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
@@ -68,21 +69,17 @@ func TestDomainMappingValidation(t *testing.T) {
 			},
 		},
 	}, {
-		name: "ref in wrong namespace",
-		want: &apis.FieldError{
-			Paths:   []string{"spec.ref.namespace"},
-			Details: `parent namespace: "good-namespace" does not match ref: "bad-namespace"`,
-			Message: `mismatched namespaces`,
-		},
+		// DomainMappingGrants allow the references to other namespaces.
+		name: "ref in other namespace",
 		dm: &DomainMapping{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "wrong-ref-ns.example.com",
+				Name:      "other-ref-ns.example.com",
 				Namespace: "good-namespace",
 			},
 			Spec: DomainMappingSpec{
 				Ref: duckv1.KReference{
 					Name:       "some-name",
-					Namespace:  "bad-namespace",
+					Namespace:  "other-namespace",
 					APIVersion: "serving.knative.dev/v1",
 					Kind:       "Service",
 				},
@@ -137,6 +134,238 @@ func TestDomainMappingValidation(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "valid hosts",
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "customer.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Hosts: []string{"*.customer.example.com", "www.customer.example.com", "xn--bcher-kva.example.com"},
+			},
+		},
+	}, {
+		name: "invalid hosts",
+		want: apis.ErrGeneric(`duplicate host "customer.example.com"`, "spec.hosts[0]").Also(
+			apis.ErrGeneric(`invalid host "*.com": hosts[1]: Invalid value: "com": should be a domain with at least two segments separated by dots`, "spec.hosts[1]"),
+			apis.ErrGeneric(`invalid host "*.*.example.com": hosts[2]: Invalid value: "*.example.com": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`, "spec.hosts[2]"),
+			apis.ErrGeneric(`invalid host "*.svc.cluster.local": must not be a subdomain of cluster local domain "cluster.local"`, "spec.hosts[3]"),
+			apis.ErrGeneric(`duplicate host "www.example.com"`, "spec.hosts[5]"),
+			apis.ErrGeneric(`invalid host "wc--wildcard.example.com": label "wc--wildcard" is reserved`, "spec.hosts[6]"),
+		),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "customer.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Hosts: []string{"customer.example.com", "*.com", "*.*.example.com", "*.svc.cluster.local", "www.example.com", "www.example.com",
+					"wc--wildcard.example.com"},
+			},
+		},
+	}, {
+		name: "reserved name",
+		want: apis.ErrGeneric(`invalid name "wc--wildcard.example.com": label "wc--wildcard" is reserved`, "metadata.name"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "wc--wildcard.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+			},
+		},
+	}, {
+		name: "valid url",
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "legacy.example.org:8080"},
+			},
+		},
+	}, {
+		name: "invalid url",
+		want: apis.ErrInvalidValue("https", "spec.url.scheme", "must be http").Also(
+			apis.ErrInvalidValue("https://legacy.example.org/v1?x=y", "spec.url", "may only have a scheme, a host and a port"),
+		),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "https", Host: "legacy.example.org", Path: "/v1", RawQuery: "x=y"},
+			},
+		},
+	}, {
+		name: "ip address url",
+		want: apis.ErrInvalidValue("169.254.169.254", "spec.url.host", "must not be an IP address"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "169.254.169.254"},
+			},
+		},
+	}, {
+		name: "ipv4 shorthand url",
+		want: apis.ErrInvalidValue("127.1", "spec.url.host", "must not be an IP address"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "127.1:8080"},
+			},
+		},
+	}, {
+		name: "single label url",
+		want: apis.ErrInvalidValue("kubernetes", "spec.url.host", "must be a fully qualified domain name"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "kubernetes"},
+			},
+		},
+	}, {
+		name: "cluster local url",
+		want: apis.ErrInvalidValue("users.default.svc.cluster.local", "spec.url.host", `must not be a subdomain of cluster local domain "cluster.local", use ref instead`),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				URL: &apis.URL{Scheme: "http", Host: "users.default.svc.cluster.local"},
+			},
+		},
+	}, {
+		name: "both ref and url",
+		want: apis.ErrMultipleOneOf("spec.ref", "spec.url"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				URL: &apis.URL{Scheme: "http", Host: "legacy.example.org"},
+			},
+		},
+	}, {
+		name: "valid paths",
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Paths: []DomainMappingPath{{
+					Prefix: "/billing",
+					Ref: duckv1.KReference{
+						Name:       "billing",
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+					RewritePrefix: ptr.String("/"),
+				}, {
+					Prefix: "/users/",
+					Ref: duckv1.KReference{
+						Name:       "users",
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+				}},
+			},
+		},
+	}, {
+		name: "invalid paths",
+		want: apis.ErrMissingField("spec.paths[0].ref.name").Also(
+			apis.ErrInvalidValue("/", "spec.paths[0].prefix", "must be an absolute URL path other than /"),
+			apis.ErrInvalidValue("users", "spec.paths[1].prefix", "must be an absolute URL path other than /"),
+			apis.ErrInvalidValue("users", "spec.paths[2].prefix", "must be an absolute URL path other than /"),
+			apis.ErrInvalidValue("/v1?x", "spec.paths[1].rewritePrefix", "must be an absolute URL path"),
+			apis.ErrGeneric(`duplicate prefix "users"`, "spec.paths[2].prefix"),
+		),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Paths: []DomainMappingPath{{
+					Prefix: "/",
+					Ref: duckv1.KReference{
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+				}, {
+					Prefix: "users",
+					Ref: duckv1.KReference{
+						Name:       "users",
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+					RewritePrefix: ptr.String("/v1?x"),
+				}, {
+					Prefix: "users",
+					Ref: duckv1.KReference{
+						Name:       "other-users",
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+				}},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -104,10 +104,70 @@ func (in *DomainMappingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainMappingPath) DeepCopyInto(out *DomainMappingPath) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.RewritePrefix != nil {
+		in, out := &in.RewritePrefix, &out.RewritePrefix
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainMappingPath.
+func (in *DomainMappingPath) DeepCopy() *DomainMappingPath {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainMappingPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainMappingPathStatus) DeepCopyInto(out *DomainMappingPathStatus) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainMappingPathStatus.
+func (in *DomainMappingPathStatus) DeepCopy() *DomainMappingPathStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainMappingPathStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainMappingSpec) DeepCopyInto(out *DomainMappingSpec) {
 	*out = *in
 	out.Ref = in.Ref
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]DomainMappingPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(SecretTLS)
@@ -140,6 +200,13 @@ func (in *DomainMappingStatus) DeepCopyInto(out *DomainMappingStatus) {
 		*out = new(v1.Addressable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]DomainMappingPathStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
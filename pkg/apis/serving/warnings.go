@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmap"
+)
+
+// warningsKey is used as the key for associating the warnings collector
+// with the context.
+type warningsKey struct{}
+
+// WithWarnings returns a context collecting the warnings reported by the
+// validation and defaulting of the resources within it.
+func WithWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningsKey{}, new(*apis.FieldError))
+}
+
+// Warn reports warnings, e.g. about deprecated or risky settings, which do
+// not make the resource invalid. The warnings are dropped unless the context
+// collects them.
+func Warn(ctx context.Context, warnings *apis.FieldError) {
+	if w, ok := ctx.Value(warningsKey{}).(**apis.FieldError); ok {
+		*w = (*w).Also(warnings)
+	}
+}
+
+// GetWarnings returns the warnings reported within the context.
+func GetWarnings(ctx context.Context) *apis.FieldError {
+	if w, ok := ctx.Value(warningsKey{}).(**apis.FieldError); ok {
+		return *w
+	}
+	return nil
+}
+
+// DeprecatedAnnotationWarnings warns about the annotations which are
+// set through a deprecated synonym of their key.
+func DeprecatedAnnotationWarnings(annos map[string]string, keys ...kmap.KeyPriority) (warnings *apis.FieldError) {
+	for _, key := range keys {
+		if k, _, ok := key.Get(annos); ok && k != key.Key() {
+			warnings = warnings.Also(&apis.FieldError{
+				Message: fmt.Sprintf("deprecated annotation, use %s instead", key.Key()),
+				Paths:   []string{k},
+			})
+		}
+	}
+	return warnings
+}
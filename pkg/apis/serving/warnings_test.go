@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"context"
+	"testing"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/autoscaling"
+)
+
+func TestWarn(t *testing.T) {
+	warning := apis.ErrGeneric("risky", "spec")
+
+	// Without a collector the warnings are dropped.
+	ctx := context.Background()
+	Warn(ctx, warning)
+	if got := GetWarnings(ctx); got != nil {
+		t.Errorf("GetWarnings() = %v, want none", got)
+	}
+
+	ctx = WithWarnings(ctx)
+	Warn(ctx, nil)
+	if got := GetWarnings(ctx); got != nil {
+		t.Errorf("GetWarnings() = %v, want none", got)
+	}
+	// The warnings reported within derived contexts are collected too.
+	Warn(apis.WithinSpec(ctx), warning)
+	Warn(ctx, apis.ErrGeneric("stale", "metadata"))
+	if got, want := GetWarnings(ctx).Error(), "risky: spec\nstale: metadata"; got != want {
+		t.Errorf("GetWarnings() = %q, want: %q", got, want)
+	}
+}
+
+func TestDeprecatedAnnotationWarnings(t *testing.T) {
+	cases := []struct {
+		name  string
+		annos map[string]string
+		want  string
+	}{{
+		name: "no annotations",
+	}, {
+		name: "current keys",
+		annos: map[string]string{
+			autoscaling.MinScaleAnnotationKey: "1",
+			autoscaling.MaxScaleAnnotationKey: "10",
+		},
+	}, {
+		name: "deprecated keys",
+		annos: map[string]string{
+			autoscaling.GroupName + "/minScale": "1",
+			autoscaling.GroupName + "/maxScale": "10",
+		},
+		want: "deprecated annotation, use autoscaling.knative.dev/max-scale instead: autoscaling.knative.dev/maxScale\n" +
+			"deprecated annotation, use autoscaling.knative.dev/min-scale instead: autoscaling.knative.dev/minScale",
+	}, {
+		name: "both keys",
+		annos: map[string]string{
+			autoscaling.MinScaleAnnotationKey:   "1",
+			autoscaling.GroupName + "/minScale": "2",
+		},
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := DeprecatedAnnotationWarnings(c.annos, autoscaling.MinScaleAnnotation, autoscaling.MaxScaleAnnotation)
+			if got.Error() != c.want {
+				t.Errorf("DeprecatedAnnotationWarnings() = %q, want: %q", got.Error(), c.want)
+			}
+		})
+	}
+}
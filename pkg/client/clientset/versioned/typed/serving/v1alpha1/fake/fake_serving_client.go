@@ -32,6 +32,10 @@ func (c *FakeServingV1alpha1) DomainMappings(namespace string) v1alpha1.DomainMa
 	return &FakeDomainMappings{c, namespace}
 }
 
+func (c *FakeServingV1alpha1) DomainMappingGrants(namespace string) v1alpha1.DomainMappingGrantInterface {
+	return &FakeDomainMappingGrants{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeServingV1alpha1) RESTClient() rest.Interface {
@@ -29,6 +29,7 @@ import (
 type ServingV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	DomainMappingsGetter
+	DomainMappingGrantsGetter
 }
 
 // ServingV1alpha1Client is used to interact with features provided by the serving.knative.dev group.
@@ -40,6 +41,10 @@ func (c *ServingV1alpha1Client) DomainMappings(namespace string) DomainMappingIn
 	return newDomainMappings(c, namespace)
 }
 
+func (c *ServingV1alpha1Client) DomainMappingGrants(namespace string) DomainMappingGrantInterface {
+	return newDomainMappingGrants(c, namespace)
+}
+
 // NewForConfig creates a new ServingV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -74,6 +74,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=serving.knative.dev, Version=v1alpha1
 	case servingv1alpha1.SchemeGroupVersion.WithResource("domainmappings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().DomainMappings().Informer()}, nil
+	case servingv1alpha1.SchemeGroupVersion.WithResource("domainmappinggrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().DomainMappingGrants().Informer()}, nil
 
 		// Group=serving.knative.dev, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("domainmappings"):
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// DomainMappings returns a DomainMappingInformer.
 	DomainMappings() DomainMappingInformer
+	// DomainMappingGrants returns a DomainMappingGrantInformer.
+	DomainMappingGrants() DomainMappingGrantInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) DomainMappings() DomainMappingInformer {
 	return &domainMappingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// DomainMappingGrants returns a DomainMappingGrantInformer.
+func (v *version) DomainMappingGrants() DomainMappingGrantInformer {
+	return &domainMappingGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
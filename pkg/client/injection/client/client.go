@@ -1209,3 +1209,134 @@ func (w *wrapServingV1alpha1DomainMappingImpl) UpdateStatus(ctx context.Context,
 func (w *wrapServingV1alpha1DomainMappingImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
+
+func (w *wrapServingV1alpha1) DomainMappingGrants(namespace string) typedservingv1alpha1.DomainMappingGrantInterface {
+	return &wrapServingV1alpha1DomainMappingGrantImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "serving.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "domainmappinggrants",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapServingV1alpha1DomainMappingGrantImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedservingv1alpha1.DomainMappingGrantInterface = (*wrapServingV1alpha1DomainMappingGrantImpl)(nil)
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) Create(ctx context.Context, in *servingv1alpha1.DomainMappingGrant, opts v1.CreateOptions) (*servingv1alpha1.DomainMappingGrant, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "serving.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "DomainMappingGrant",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &servingv1alpha1.DomainMappingGrant{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*servingv1alpha1.DomainMappingGrant, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &servingv1alpha1.DomainMappingGrant{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) List(ctx context.Context, opts v1.ListOptions) (*servingv1alpha1.DomainMappingGrantList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &servingv1alpha1.DomainMappingGrantList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *servingv1alpha1.DomainMappingGrant, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &servingv1alpha1.DomainMappingGrant{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) Update(ctx context.Context, in *servingv1alpha1.DomainMappingGrant, opts v1.UpdateOptions) (*servingv1alpha1.DomainMappingGrant, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "serving.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "DomainMappingGrant",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &servingv1alpha1.DomainMappingGrant{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) UpdateStatus(ctx context.Context, in *servingv1alpha1.DomainMappingGrant, opts v1.UpdateOptions) (*servingv1alpha1.DomainMappingGrant, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "serving.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "DomainMappingGrant",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &servingv1alpha1.DomainMappingGrant{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapServingV1alpha1DomainMappingGrantImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
@@ -25,3 +25,11 @@ type DomainMappingListerExpansion interface{}
 // DomainMappingNamespaceListerExpansion allows custom methods to be added to
 // DomainMappingNamespaceLister.
 type DomainMappingNamespaceListerExpansion interface{}
+
+// DomainMappingGrantListerExpansion allows custom methods to be added to
+// DomainMappingGrantLister.
+type DomainMappingGrantListerExpansion interface{}
+
+// DomainMappingGrantNamespaceListerExpansion allows custom methods to be added to
+// DomainMappingGrantNamespaceLister.
+type DomainMappingGrantNamespaceListerExpansion interface{}
@@ -19,12 +19,11 @@ package gc
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	cm "knative.dev/pkg/configmap"
+	"knative.dev/serving/pkg/apis/serving"
 )
 
 const (
@@ -33,7 +32,7 @@ const (
 
 	// Disabled is the value (-1) used by various config map values to indicate
 	// the setting is disabled.
-	Disabled = -1
+	Disabled = serving.GCDisabled
 
 	disabled = "disabled"
 )
@@ -54,14 +53,28 @@ type Config struct {
 	// regardless of creation or staleness time-bounds.
 	// Set Disabled (-1) to disable/ignore max.
 	MaxNonActiveRevisions int64
+	// DryRun reports the Revisions GC would delete, as Events and in the
+	// status of their Configuration, instead of deleting them.
+	DryRun bool
+	// Period before the next sweep of the child resources of Revisions which
+	// outlived their Revision, e.g. Images, Metrics, PodAutoscalers and
+	// ServerlessServices, when a sweep deferred some of them. The Namespaces
+	// are otherwise swept after the deletion of their Revisions.
+	// Set Disabled (-1) to disable the sweeps.
+	OrphanSweepPeriod time.Duration
+	// Maximum number of orphaned child resources deleted by a sweep of a
+	// Namespace. The remaining ones are deleted by the next sweeps.
+	MaxOrphanDeletionsPerSweep int64
 }
 
 func defaultConfig() *Config {
 	return &Config{
-		RetainSinceCreateTime:     48 * time.Hour,
-		RetainSinceLastActiveTime: 15 * time.Hour,
-		MinNonActiveRevisions:     20,
-		MaxNonActiveRevisions:     1000,
+		RetainSinceCreateTime:      48 * time.Hour,
+		RetainSinceLastActiveTime:  15 * time.Hour,
+		MinNonActiveRevisions:      20,
+		MaxNonActiveRevisions:      1000,
+		OrphanSweepPeriod:          time.Hour,
+		MaxOrphanDeletionsPerSweep: 50,
 	}
 }
 
@@ -70,26 +83,38 @@ func NewConfigFromConfigMapFunc(ctx context.Context) func(configMap *corev1.Conf
 	return func(configMap *corev1.ConfigMap) (*Config, error) {
 		c := defaultConfig()
 
-		var retainCreate, retainActive, max string
+		var retainCreate, retainActive, max, orphanSweep string
 		if err := cm.Parse(configMap.Data,
 			cm.AsString("retain-since-create-time", &retainCreate),
 			cm.AsString("retain-since-last-active-time", &retainActive),
 			cm.AsInt64("min-non-active-revisions", &c.MinNonActiveRevisions),
 			cm.AsString("max-non-active-revisions", &max),
+			cm.AsBool("dry-run", &c.DryRun),
+			cm.AsString("orphan-sweep-period", &orphanSweep),
+			cm.AsInt64("max-orphan-deletions-per-sweep", &c.MaxOrphanDeletionsPerSweep),
 		); err != nil {
 			return nil, fmt.Errorf("failed to parse data: %w", err)
 		}
 
 		// validate V2 settings
-		if err := parseDisabledOrDuration(retainCreate, &c.RetainSinceCreateTime); err != nil {
+		if err := serving.ParseDisabledOrDuration(retainCreate, &c.RetainSinceCreateTime); err != nil {
 			return nil, fmt.Errorf("failed to parse retain-since-create-time: %w", err)
 		}
-		if err := parseDisabledOrDuration(retainActive, &c.RetainSinceLastActiveTime); err != nil {
+		if err := serving.ParseDisabledOrDuration(retainActive, &c.RetainSinceLastActiveTime); err != nil {
 			return nil, fmt.Errorf("failed to parse retain-since-last-active-time: %w", err)
 		}
-		if err := parseDisabledOrInt64(max, &c.MaxNonActiveRevisions); err != nil {
+		if err := serving.ParseDisabledOrInt64(max, &c.MaxNonActiveRevisions); err != nil {
 			return nil, fmt.Errorf("failed to parse max-non-active-revisions: %w", err)
 		}
+		if err := serving.ParseDisabledOrDuration(orphanSweep, &c.OrphanSweepPeriod); err != nil {
+			return nil, fmt.Errorf("failed to parse orphan-sweep-period: %w", err)
+		}
+		if c.OrphanSweepPeriod == 0 {
+			return nil, fmt.Errorf("orphan-sweep-period must be positive or %q", disabled)
+		}
+		if c.MaxOrphanDeletionsPerSweep <= 0 {
+			return nil, fmt.Errorf("max-orphan-deletions-per-sweep must be positive, was: %d", c.MaxOrphanDeletionsPerSweep)
+		}
 		if c.MinNonActiveRevisions < 0 {
 			return nil, fmt.Errorf("min-non-active-revisions must be non-negative, was: %d", c.MinNonActiveRevisions)
 		}
@@ -99,38 +124,3 @@ func NewConfigFromConfigMapFunc(ctx context.Context) func(configMap *corev1.Conf
 		return c, nil
 	}
 }
-
-func parseDisabledOrInt64(val string, toSet *int64) error {
-	switch {
-	case val == "":
-		// keep default value
-	case strings.EqualFold(val, disabled):
-		*toSet = Disabled
-	default:
-		parsed, err := strconv.ParseUint(val, 10, 64)
-		if err != nil {
-			return err
-		}
-		*toSet = int64(parsed)
-	}
-	return nil
-}
-
-func parseDisabledOrDuration(val string, toSet *time.Duration) error {
-	switch {
-	case val == "":
-		// keep default value
-	case strings.EqualFold(val, disabled):
-		*toSet = time.Duration(Disabled)
-	default:
-		parsed, err := time.ParseDuration(val)
-		if err != nil {
-			return err
-		}
-		if parsed < 0 {
-			return fmt.Errorf("must be non-negative")
-		}
-		*toSet = parsed
-	}
-	return nil
-}
@@ -47,16 +47,20 @@ func TestOurConfig(t *testing.T) {
 	}, {
 		name: "with value overrides",
 		want: &Config{
-			RetainSinceCreateTime:     17 * time.Hour,
-			RetainSinceLastActiveTime: 16 * time.Hour,
-			MinNonActiveRevisions:     5,
-			MaxNonActiveRevisions:     500,
+			RetainSinceCreateTime:      17 * time.Hour,
+			RetainSinceLastActiveTime:  16 * time.Hour,
+			MinNonActiveRevisions:      5,
+			MaxNonActiveRevisions:      500,
+			OrphanSweepPeriod:          30 * time.Minute,
+			MaxOrphanDeletionsPerSweep: 10,
 		},
 		data: map[string]string{
-			"retain-since-create-time":      "17h",
-			"retain-since-last-active-time": "16h",
-			"min-non-active-revisions":      "5",
-			"max-non-active-revisions":      "500",
+			"retain-since-create-time":       "17h",
+			"retain-since-last-active-time":  "16h",
+			"min-non-active-revisions":       "5",
+			"max-non-active-revisions":       "500",
+			"orphan-sweep-period":            "30m",
+			"max-orphan-deletions-per-sweep": "10",
 		},
 	}, {
 		name: "Invalid negative min stale",
@@ -131,6 +135,38 @@ func TestOurConfig(t *testing.T) {
 		data: map[string]string{
 			"max-non-active-revisions": disabled,
 		},
+	}, {
+		name: "dry run",
+		want: func() *Config {
+			d := defaultConfig()
+			d.DryRun = true
+			return d
+		}(),
+		data: map[string]string{
+			"dry-run": "true",
+		},
+	}, {
+		name: "orphan sweep disabled",
+		want: func() *Config {
+			d := defaultConfig()
+			d.OrphanSweepPeriod = time.Duration(Disabled)
+			return d
+		}(),
+		data: map[string]string{
+			"orphan-sweep-period": disabled,
+		},
+	}, {
+		name: "zero orphan sweep period",
+		fail: true,
+		data: map[string]string{
+			"orphan-sweep-period": "0s",
+		},
+	}, {
+		name: "zero max orphan deletions",
+		fail: true,
+		data: map[string]string{
+			"max-orphan-deletions-per-sweep": "0",
+		},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := NewConfigFromConfigMapFunc(logtesting.TestContextWithLogger(t))(
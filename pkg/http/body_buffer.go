@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// BodyBufferDirectory is the directory request bodies spill into once
+	// they exceed the in-memory part of the buffer. It is backed by an
+	// emptyDir volume in the activator.
+	BodyBufferDirectory = "/var/run/knative/body-buffer"
+
+	// DefaultBodyBufferMemorySize is the part of a buffered request body
+	// that is kept in memory, if not configured otherwise.
+	DefaultBodyBufferMemorySize = 64 * 1024
+)
+
+// ErrBodyTooLarge is returned when a request body exceeds the configured
+// buffer size.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// BodyBufferConfig configures the buffering of request bodies.
+type BodyBufferConfig struct {
+	// MaxSize is the largest request body in bytes that is accepted.
+	MaxSize int64
+
+	// MemorySize is the number of bytes kept in memory. The remainder of the
+	// body is written to a file in Dir.
+	MemorySize int64
+
+	// Dir is the directory the files of large bodies are created in.
+	Dir string
+}
+
+// NewBodyBufferConfig parses the given quantities into a BodyBufferConfig.
+// An empty maxSize disables buffering and returns a nil config. An empty
+// memorySize defaults to DefaultBodyBufferMemorySize.
+func NewBodyBufferConfig(maxSize, memorySize, dir string) (*BodyBufferConfig, error) {
+	if maxSize == "" {
+		return nil, nil
+	}
+	max, err := resource.ParseQuantity(maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse body buffer size %q: %w", maxSize, err)
+	}
+	cfg := &BodyBufferConfig{
+		MaxSize:    max.Value(),
+		MemorySize: DefaultBodyBufferMemorySize,
+		Dir:        dir,
+	}
+	if memorySize != "" {
+		mem, err := resource.ParseQuantity(memorySize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse body buffer memory size %q: %w", memorySize, err)
+		}
+		cfg.MemorySize = mem.Value()
+	}
+	if cfg.MaxSize <= 0 {
+		return nil, fmt.Errorf("body buffer size must be positive, was %d", cfg.MaxSize)
+	}
+	if cfg.MemorySize < 0 {
+		return nil, fmt.Errorf("body buffer memory size must not be negative, was %d", cfg.MemorySize)
+	}
+	if cfg.MemorySize > cfg.MaxSize {
+		cfg.MemorySize = cfg.MaxSize
+	}
+	return cfg, nil
+}
+
+// bufferedBody holds a fully read request body. The first part of the body
+// is kept in memory, the rest in a file.
+type bufferedBody struct {
+	mem      []byte
+	file     *os.File
+	fileSize int64
+}
+
+// reader returns a new reader over the whole body. Readers of the same body
+// can be used concurrently.
+func (b *bufferedBody) reader() io.ReadCloser {
+	if b.file == nil {
+		return io.NopCloser(bytes.NewReader(b.mem))
+	}
+	return io.NopCloser(io.MultiReader(bytes.NewReader(b.mem), io.NewSectionReader(b.file, 0, b.fileSize)))
+}
+
+// release removes the file backing the body, if any.
+func (b *bufferedBody) release() {
+	if b.file != nil {
+		b.file.Close()
+		os.Remove(b.file.Name())
+	}
+}
+
+// BufferBody reads the body of the request into a buffer according to cfg
+// and replaces it with a replayable one. Request.GetBody returns a fresh
+// copy of the body on every call, which allows proxies to retry the request.
+// The returned function must be called to release the buffer once the
+// request is done. ErrBodyTooLarge is returned if the body is larger than
+// cfg.MaxSize.
+func BufferBody(r *http.Request, cfg *BodyBufferConfig) (func(), error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		r.GetBody = func() (io.ReadCloser, error) {
+			return http.NoBody, nil
+		}
+		return func() {}, nil
+	}
+	if r.ContentLength > cfg.MaxSize {
+		return nil, ErrBodyTooLarge
+	}
+	defer r.Body.Close()
+
+	// Read one byte past the limit to tell a body of exactly MaxSize apart
+	// from a larger one.
+	src := io.LimitReader(r.Body, cfg.MaxSize+1)
+	b := &bufferedBody{}
+
+	var mem bytes.Buffer
+	if r.ContentLength > 0 && r.ContentLength <= cfg.MemorySize {
+		mem.Grow(int(r.ContentLength))
+	}
+	n, err := io.CopyN(&mem, src, cfg.MemorySize)
+	b.mem = mem.Bytes()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	size := n
+
+	if err == nil {
+		// The body didn't fit into memory, spill the rest to disk.
+		f, err := os.CreateTemp(cfg.Dir, "body-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create body buffer file: %w", err)
+		}
+		b.file = f
+		b.fileSize, err = io.Copy(f, src)
+		if err != nil {
+			b.release()
+			return nil, fmt.Errorf("failed to buffer request body: %w", err)
+		}
+		size += b.fileSize
+	}
+
+	if size > cfg.MaxSize {
+		b.release()
+		return nil, ErrBodyTooLarge
+	}
+
+	r.ContentLength = size
+	r.Body = b.reader()
+	r.GetBody = func() (io.ReadCloser, error) {
+		return b.reader(), nil
+	}
+	return b.release, nil
+}
+
+// ReplayBody resets the body of a request buffered by BufferBody, so it can
+// be sent again. It returns false if the body cannot be replayed.
+func ReplayBody(r *http.Request) bool {
+	if r.GetBody == nil {
+		return false
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return false
+	}
+	r.Body = body
+	return true
+}
+
+// BodyBufferHandler buffers the request bodies before passing requests on
+// to next. configFor returns the buffer configuration for a request, or nil
+// if its body should be streamed. Requests with bodies larger than the
+// configured size are rejected with a 413.
+func BodyBufferHandler(next http.Handler, configFor func(*http.Request) *BodyBufferConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cfg := configFor(r)
+		if cfg == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		release, err := BufferBody(r, cfg)
+		if errors.Is(err, ErrBodyTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer release()
+
+		next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewBodyBufferConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    string
+		memory  string
+		want    *BodyBufferConfig
+		wantErr bool
+	}{{
+		name: "disabled",
+	}, {
+		name: "default memory",
+		size: "1Mi",
+		want: &BodyBufferConfig{MaxSize: 1 << 20, MemorySize: DefaultBodyBufferMemorySize, Dir: "/tmp"},
+	}, {
+		name:   "explicit memory",
+		size:   "1Mi",
+		memory: "1Ki",
+		want:   &BodyBufferConfig{MaxSize: 1 << 20, MemorySize: 1 << 10, Dir: "/tmp"},
+	}, {
+		name: "memory capped at size",
+		size: "1Ki",
+		want: &BodyBufferConfig{MaxSize: 1 << 10, MemorySize: 1 << 10, Dir: "/tmp"},
+	}, {
+		name:    "invalid size",
+		size:    "huge",
+		wantErr: true,
+	}, {
+		name:    "invalid memory",
+		size:    "1Mi",
+		memory:  "some",
+		wantErr: true,
+	}, {
+		name:    "zero size",
+		size:    "0",
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewBodyBufferConfig(tc.size, tc.memory, "/tmp")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewBodyBufferConfig() = %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("NewBodyBufferConfig (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestBufferBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		cfg       BodyBufferConfig
+		wantSpill bool
+		wantErr   error
+	}{{
+		name: "in memory",
+		body: "hello",
+		cfg:  BodyBufferConfig{MaxSize: 10, MemorySize: 10},
+	}, {
+		name:      "spilled",
+		body:      "hello world",
+		cfg:       BodyBufferConfig{MaxSize: 20, MemorySize: 5},
+		wantSpill: true,
+	}, {
+		name:      "exactly max size",
+		body:      "hello world",
+		cfg:       BodyBufferConfig{MaxSize: 11, MemorySize: 5},
+		wantSpill: true,
+	}, {
+		name:    "too large in memory",
+		body:    "hello world",
+		cfg:     BodyBufferConfig{MaxSize: 10, MemorySize: 10},
+		wantErr: ErrBodyTooLarge,
+	}, {
+		name:    "too large spilled",
+		body:    "hello world",
+		cfg:     BodyBufferConfig{MaxSize: 10, MemorySize: 2},
+		wantErr: ErrBodyTooLarge,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Dir = t.TempDir()
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", io.NopCloser(strings.NewReader(tc.body)))
+			// Pretend we don't know the length so the whole body has to be read.
+			r.ContentLength = -1
+
+			release, err := BufferBody(r, &tc.cfg)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("BufferBody() = %v, want: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				assertEmptyDir(t, tc.cfg.Dir)
+				return
+			}
+
+			if got, want := r.ContentLength, int64(len(tc.body)); got != want {
+				t.Errorf("ContentLength = %d, want: %d", got, want)
+			}
+			entries, _ := os.ReadDir(tc.cfg.Dir)
+			if got := len(entries) > 0; got != tc.wantSpill {
+				t.Errorf("Spilled = %v, want: %v", got, tc.wantSpill)
+			}
+
+			// The body can be read repeatedly.
+			for i := 0; i < 3; i++ {
+				b, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal("Failed to read body:", err)
+				}
+				if got := string(b); got != tc.body {
+					t.Errorf("Body = %q, want: %q", got, tc.body)
+				}
+				if !ReplayBody(r) {
+					t.Fatal("ReplayBody() = false")
+				}
+			}
+
+			release()
+			assertEmptyDir(t, tc.cfg.Dir)
+		})
+	}
+}
+
+func TestBufferBodyNoBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	release, err := BufferBody(r, &BodyBufferConfig{MaxSize: 10})
+	if err != nil {
+		t.Fatal("BufferBody() =", err)
+	}
+	defer release()
+	if !ReplayBody(r) {
+		t.Error("ReplayBody() = false, want requests without body to be replayable")
+	}
+}
+
+func TestReplayBodyUnbuffered(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("hello"))
+	r.GetBody = nil
+	if ReplayBody(r) {
+		t.Error("ReplayBody() = true for an unbuffered body")
+	}
+}
+
+func TestBodyBufferHandler(t *testing.T) {
+	cfg := &BodyBufferConfig{MaxSize: 5, MemorySize: 5, Dir: t.TempDir()}
+	var gotBody string
+	h := BodyBufferHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		if r.GetBody == nil {
+			t.Error("Want GetBody to be set for buffered requests")
+		}
+	}), func(*http.Request) *BodyBufferConfig {
+		return cfg
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("hello")))
+	if rec.Code != http.StatusOK || gotBody != "hello" {
+		t.Errorf("Got code %d and body %q, want 200 and %q", rec.Code, gotBody, "hello")
+	}
+
+	gotBody = ""
+	rec = httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("hello world")))
+	if got, want := rec.Code, http.StatusRequestEntityTooLarge; got != want {
+		t.Errorf("Code = %d, want: %d", got, want)
+	}
+	if gotBody != "" {
+		t.Error("Want oversized requests to not be passed on")
+	}
+}
+
+func assertEmptyDir(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal("Failed to read dir:", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Want no leftover files in %s, got %d", dir, len(entries))
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// GRPCStatusHeaderName is the header (or trailer) carrying the status of a gRPC call.
+	GRPCStatusHeaderName = "Grpc-Status"
+
+	// GRPCMessageHeaderName is the header (or trailer) carrying the message of a gRPC status.
+	GRPCMessageHeaderName = "Grpc-Message"
+
+	// GRPCTimeoutHeaderName is the header a gRPC client uses to propagate the call deadline.
+	GRPCTimeoutHeaderName = "Grpc-Timeout"
+
+	grpcContentType = "application/grpc"
+)
+
+// IsGRPC returns true if the request is a gRPC call.
+func IsGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType)
+}
+
+// GRPCTimeout returns the timeout requested through the grpc-timeout header
+// of a gRPC call. The boolean is false if the request doesn't carry a valid
+// timeout.
+func GRPCTimeout(r *http.Request) (time.Duration, bool) {
+	if !IsGRPC(r) {
+		return 0, false
+	}
+	d, err := parseGRPCTimeout(r.Header.Get(GRPCTimeoutHeaderName))
+	return d, err == nil
+}
+
+// parseGRPCTimeout parses the "TimeoutValue TimeoutUnit" format of the
+// grpc-timeout header, where the value has at most 8 digits.
+func parseGRPCTimeout(s string) (time.Duration, error) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, fmt.Errorf("malformed grpc-timeout %q", s)
+	}
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'H':
+		unit = time.Hour
+	case 'M':
+		unit = time.Minute
+	case 'S':
+		unit = time.Second
+	case 'm':
+		unit = time.Millisecond
+	case 'u':
+		unit = time.Microsecond
+	case 'n':
+		unit = time.Nanosecond
+	default:
+		return 0, fmt.Errorf("malformed grpc-timeout unit in %q", s)
+	}
+	v, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("malformed grpc-timeout value in %q", s)
+	}
+	if v > math.MaxInt64/int64(unit) {
+		return time.Duration(math.MaxInt64), nil
+	}
+	return time.Duration(v) * unit, nil
+}
+
+// WriteGRPCError writes a trailers-only gRPC response carrying the given
+// status. It must be called before anything else was written to w.
+func WriteGRPCError(w http.ResponseWriter, code codes.Code, msg string) {
+	h := w.Header()
+	h.Set("Content-Type", grpcContentType)
+	h.Set(GRPCStatusHeaderName, strconv.Itoa(int(code)))
+	if msg != "" {
+		h.Set(GRPCMessageHeaderName, encodeGRPCMessage(msg))
+	}
+	// gRPC calls always succeed on the HTTP level, the outcome is carried by the status.
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetGRPCErrorTrailers sets the given status as the trailers of a gRPC
+// response whose headers were already written.
+func SetGRPCErrorTrailers(w http.ResponseWriter, code codes.Code, msg string) {
+	h := w.Header()
+	h.Set(http.TrailerPrefix+GRPCStatusHeaderName, strconv.Itoa(int(code)))
+	if msg != "" {
+		h.Set(http.TrailerPrefix+GRPCMessageHeaderName, encodeGRPCMessage(msg))
+	}
+}
+
+// encodeGRPCMessage percent-encodes the message as required for the
+// grpc-message header.
+func encodeGRPCMessage(msg string) string {
+	var sb strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+// GRPCStatus returns the gRPC status reported in the headers or in the
+// trailers of a response. The trailers are looked up both as announced
+// trailers and as trailers prefixed with http.TrailerPrefix.
+func GRPCStatus(h http.Header) (codes.Code, bool) {
+	v := h.Get(GRPCStatusHeaderName)
+	if v == "" {
+		if vs := h[http.TrailerPrefix+GRPCStatusHeaderName]; len(vs) > 0 {
+			v = vs[0]
+		}
+	}
+	if v == "" {
+		return codes.Unknown, false
+	}
+	c, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return codes.Unknown, false
+	}
+	return codes.Code(c), true
+}
+
+// HTTPStatusFromGRPC maps a gRPC status code onto the closest HTTP status
+// code, following the mapping of the gRPC gateway.
+func HTTPStatusFromGRPC(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// Client Closed Request, as used by nginx.
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		// Unknown, Internal, DataLoss and codes we don't know about.
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func grpcRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "http://example.com/pkg.Service/Method", nil)
+	r.ProtoMajor, r.ProtoMinor, r.Proto = 2, 0, "HTTP/2.0"
+	r.Header.Set("Content-Type", "application/grpc+proto")
+	return r
+}
+
+func TestIsGRPC(t *testing.T) {
+	if !IsGRPC(grpcRequest()) {
+		t.Error("IsGRPC() = false for a gRPC request")
+	}
+
+	r := grpcRequest()
+	r.ProtoMajor = 1
+	if IsGRPC(r) {
+		t.Error("IsGRPC() = true for an HTTP/1 request")
+	}
+
+	r = grpcRequest()
+	r.Header.Set("Content-Type", "application/json")
+	if IsGRPC(r) {
+		t.Error("IsGRPC() = true for a JSON request")
+	}
+}
+
+func TestGRPCTimeout(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{header: "", wantOK: false},
+		{header: "1H", want: time.Hour, wantOK: true},
+		{header: "2M", want: 2 * time.Minute, wantOK: true},
+		{header: "3S", want: 3 * time.Second, wantOK: true},
+		{header: "400m", want: 400 * time.Millisecond, wantOK: true},
+		{header: "500u", want: 500 * time.Microsecond, wantOK: true},
+		{header: "600n", want: 600 * time.Nanosecond, wantOK: true},
+		{header: "99999999H", want: time.Duration(math.MaxInt64), wantOK: true},
+		{header: "123456789S", wantOK: false},
+		{header: "10s", wantOK: false},
+		{header: "-1S", wantOK: false},
+		{header: "S", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.header, func(t *testing.T) {
+			r := grpcRequest()
+			r.Header.Set(GRPCTimeoutHeaderName, tc.header)
+			got, ok := GRPCTimeout(r)
+			if ok != tc.wantOK || got != tc.want {
+				t.Errorf("GRPCTimeout() = %v, %v, want: %v, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestWriteGRPCError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteGRPCError(rec, codes.ResourceExhausted, "queue full: 100%")
+
+	if got, want := rec.Code, http.StatusOK; got != want {
+		t.Errorf("Code = %d, want: %d", got, want)
+	}
+	if got, want := rec.Header().Get("Content-Type"), "application/grpc"; got != want {
+		t.Errorf("Content-Type = %q, want: %q", got, want)
+	}
+	if got, want := rec.Header().Get(GRPCMessageHeaderName), "queue full: 100%25"; got != want {
+		t.Errorf("Message = %q, want: %q", got, want)
+	}
+	if got, ok := GRPCStatus(rec.Header()); !ok || got != codes.ResourceExhausted {
+		t.Errorf("GRPCStatus() = %v, %v, want: %v", got, ok, codes.ResourceExhausted)
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   codes.Code
+		wantOK bool
+	}{{
+		name:   "no status",
+		header: http.Header{},
+		want:   codes.Unknown,
+	}, {
+		name:   "header",
+		header: http.Header{GRPCStatusHeaderName: []string{"5"}},
+		want:   codes.NotFound,
+		wantOK: true,
+	}, {
+		name:   "prefixed trailer",
+		header: http.Header{http.TrailerPrefix + GRPCStatusHeaderName: []string{"0"}},
+		want:   codes.OK,
+		wantOK: true,
+	}, {
+		name:   "garbage",
+		header: http.Header{GRPCStatusHeaderName: []string{"ok"}},
+		want:   codes.Unknown,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, ok := GRPCStatus(tc.header); got != tc.want || ok != tc.wantOK {
+				t.Errorf("GRPCStatus() = %v, %v, want: %v, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusFromGRPC(t *testing.T) {
+	for code, want := range map[codes.Code]int{
+		codes.OK:                http.StatusOK,
+		codes.InvalidArgument:   http.StatusBadRequest,
+		codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+		codes.ResourceExhausted: http.StatusTooManyRequests,
+		codes.Unavailable:       http.StatusServiceUnavailable,
+		codes.Internal:          http.StatusInternalServerError,
+		codes.Code(42):          http.StatusInternalServerError,
+	} {
+		if got := HTTPStatusFromGRPC(code); got != want {
+			t.Errorf("HTTPStatusFromGRPC(%v) = %d, want: %d", code, got, want)
+		}
+	}
+}
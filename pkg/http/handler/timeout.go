@@ -25,8 +25,10 @@ import (
 	"sync"
 	"time"
 
+	"google.golang.org/grpc/codes"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"knative.dev/pkg/websocket"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 type timeoutHandler struct {
@@ -46,6 +48,7 @@ type timeoutHandler struct {
 // call runs for longer than its time limit, the handler responds with
 // a 504 Gateway Timeout error and the given message in its body.
 // (If msg is empty, a suitable default message will be sent.)
+// gRPC calls get a DEADLINE_EXCEEDED status carrying the message instead.
 // After such a timeout, writes by h to its ResponseWriter will return
 // ErrHandlerTimeout.
 //
@@ -91,7 +94,7 @@ func (h *timeoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// done is closed when h.handler.ServeHTTP completes and contains
 	// the panic from h.handler.ServeHTTP if h.handler.ServeHTTP panics.
 	done := make(chan interface{})
-	tw := &timeoutWriter{w: w, clock: h.clock}
+	tw := &timeoutWriter{w: w, clock: h.clock, grpc: pkghttp.IsGRPC(r)}
 
 	var maxDurationTimeout clock.Timer
 	var maxDurationTimeoutDrained bool
@@ -156,10 +159,15 @@ func (h *timeoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 type timeoutWriter struct {
 	w     http.ResponseWriter
 	clock clock.PassiveClock
+	// grpc is whether the timeout error has to be a gRPC status.
+	grpc bool
 
 	mu            sync.Mutex
 	timedOut      bool
 	lastWriteTime time.Time
+	// header is handed to the handler instead of the headers of w once the
+	// timeout response, including any trailers, has been written to w.
+	header http.Header
 }
 
 var _ http.Flusher = (*timeoutWriter)(nil)
@@ -185,7 +193,14 @@ func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return websocket.HijackIfPossible(tw.w)
 }
 
-func (tw *timeoutWriter) Header() http.Header { return tw.w.Header() }
+func (tw *timeoutWriter) Header() http.Header {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return tw.header
+	}
+	return tw.w.Header()
+}
 
 func (tw *timeoutWriter) Write(p []byte) (int, error) {
 	tw.mu.Lock()
@@ -253,10 +268,21 @@ func (tw *timeoutWriter) maxDurationTimeoutAndWriteError(msg string) bool {
 }
 
 func (tw *timeoutWriter) timeoutAndWriteError(msg string) {
-	tw.w.WriteHeader(http.StatusGatewayTimeout)
-	io.WriteString(tw.w, msg)
+	switch {
+	case tw.grpc && tw.lastWriteTime.IsZero():
+		pkghttp.WriteGRPCError(tw.w, codes.DeadlineExceeded, msg)
+	case tw.grpc:
+		// The response is already streaming, so the status can only go into the trailers.
+		pkghttp.SetGRPCErrorTrailers(tw.w, codes.DeadlineExceeded, msg)
+	default:
+		tw.w.WriteHeader(http.StatusGatewayTimeout)
+		io.WriteString(tw.w, msg)
+	}
 
 	tw.timedOut = true
+	// The handler may still be running, so it must not modify the headers
+	// the server reads to finish the timeout response.
+	tw.header = tw.w.Header().Clone()
 }
 
 var timerPool sync.Pool
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/clock"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 func TestTimeoutWriterAllowsForAdditionalWritesBeforeTimeout(t *testing.T) {
@@ -84,7 +85,9 @@ type timeoutHandlerTestScenario struct {
 	maxDurationTimeout time.Duration
 	handler            func(clock *clock.FakeClock, mux *sync.Mutex, writeErrors chan error) http.Handler
 	timeoutMessage     string
+	grpc               bool
 	wantStatus         int
+	wantGRPCStatus     string
 	wantBody           string
 	wantWriteError     bool
 	wantPanic          bool
@@ -97,6 +100,10 @@ func testTimeoutScenario(t *testing.T, scenarios []timeoutHandlerTestScenario) {
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if scenario.grpc {
+				req.ProtoMajor = 2
+				req.Header.Set("Content-Type", "application/grpc")
+			}
 
 			var reqMux sync.Mutex
 			writeErrors := make(chan error, 1)
@@ -127,6 +134,10 @@ func testTimeoutScenario(t *testing.T, scenarios []timeoutHandlerTestScenario) {
 				t.Errorf("Handler returned wrong status code: got %v want %v", status, scenario.wantStatus)
 			}
 
+			if got := rr.Header().Get(pkghttp.GRPCStatusHeaderName); got != scenario.wantGRPCStatus {
+				t.Errorf("Handler returned wrong grpc-status: got %q want %q", got, scenario.wantGRPCStatus)
+			}
+
 			if rr.Body.String() != scenario.wantBody {
 				t.Errorf("Handler returned unexpected body: got %q want %q", rr.Body.String(), scenario.wantBody)
 			}
@@ -175,6 +186,24 @@ func TestTimeToFirstByteTimeoutHandler(t *testing.T) {
 		wantStatus:     http.StatusGatewayTimeout,
 		wantBody:       "request timeout",
 		wantWriteError: true,
+	}, {
+		name:             "grpc deadline exceeded",
+		firstByteTimeout: immediateTimeout,
+		idleTimeout:      noIdleTimeout,
+		handler: func(c *clock.FakeClock, mux *sync.Mutex, writeErrors chan error) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Step(immediateTimeout)
+				mux.Lock()
+				defer mux.Unlock()
+				_, werr := w.Write([]byte("hi"))
+				writeErrors <- werr
+			})
+		},
+		timeoutMessage: "request timeout",
+		grpc:           true,
+		wantStatus:     http.StatusOK,
+		wantGRPCStatus: "4",
+		wantWriteError: true,
 	}, {
 		name:             "propagate panic",
 		firstByteTimeout: longTimeout,
@@ -332,6 +361,47 @@ func TestIdleTimeoutHandler(t *testing.T) {
 	testTimeoutScenario(t, scenarios)
 }
 
+func TestIdleTimeoutHandlerGRPCStreamingHeaders(t *testing.T) {
+	const idleTimeout = 100 * time.Millisecond
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.ProtoMajor = 2
+	req.Header.Set("Content-Type", "application/grpc")
+
+	var reqMux sync.Mutex
+	done := make(chan struct{})
+	handler := &timeoutHandler{
+		handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer close(done)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("message"))
+			fakeClock.Step(idleTimeout)
+			reqMux.Lock()
+			defer reqMux.Unlock()
+			// The handler keeps setting the trailers of its response after
+			// the timeout.
+			w.Header().Set(http.TrailerPrefix+pkghttp.GRPCStatusHeaderName, "0")
+		}),
+		body:             "request timeout",
+		firstByteTimeout: time.Minute,
+		idleTimeout:      idleTimeout,
+		clock:            fakeClock,
+	}
+
+	rr := httptest.NewRecorder()
+	reqMux.Lock()
+	handler.ServeHTTP(rr, req)
+	reqMux.Unlock()
+
+	// Reading the trailers while the handler still runs catches the handler
+	// writing to the headers of the response when run with -race.
+	got := rr.Result().Trailer.Get(pkghttp.GRPCStatusHeaderName)
+	<-done
+	if want := "4"; got != want {
+		t.Errorf("grpc-status trailer = %q, want: %q", got, want)
+	}
+}
+
 func TestMaxDurationTimeoutHandler(t *testing.T) {
 	const (
 		noTimeout        = 0 * time.Millisecond
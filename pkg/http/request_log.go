@@ -18,8 +18,11 @@ package http
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"strings"
 	"sync"
@@ -39,18 +42,19 @@ type RequestLogHandler struct {
 	// Uses an unsafe.Pointer combined with atomic operations to get the least
 	// contention possible.
 	template              atomic.Value
+	config                atomic.Value
 	enableProbeRequestLog bool
 }
 
 // RequestLogRevision provides revision related static information
 // for the template execution.
 type RequestLogRevision struct {
-	Name          string
-	Namespace     string
-	Service       string
-	Configuration string
-	PodName       string
-	PodIP         string
+	Name          string `json:"name,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	Service       string `json:"service,omitempty"`
+	Configuration string `json:"configuration,omitempty"`
+	PodName       string `json:"podName,omitempty"`
+	PodIP         string `json:"podIP,omitempty"`
 }
 
 // RequestLogResponse provided response related information for the template execution.
@@ -58,6 +62,9 @@ type RequestLogResponse struct {
 	Code    int
 	Size    int
 	Latency float64
+	// QueueWait is the time in seconds the request spent waiting for
+	// capacity before it was handed to the application.
+	QueueWait float64
 }
 
 // RequestLogTemplateInput is the wrapper struct that provides all
@@ -95,6 +102,7 @@ func NewRequestLogHandler(h http.Handler, w io.Writer, templateStr string,
 	if err := reqHandler.SetTemplate(templateStr); err != nil {
 		return nil, err
 	}
+	reqHandler.SetConfig(nil)
 	return reqHandler, nil
 }
 
@@ -121,18 +129,43 @@ func (h *RequestLogHandler) SetTemplate(templateStr string) error {
 	return nil
 }
 
+// SetConfig sets the format and sampling of the request logs. A nil config
+// restores the defaults: every request is logged using the template.
+// Setting the format to JSON writes request logs even if the template is empty.
+func (h *RequestLogHandler) SetConfig(cfg *RequestLogConfig) {
+	if cfg == nil {
+		cfg = defaultRequestLogConfig()
+	}
+	h.config.Store(cfg)
+}
+
 func (h *RequestLogHandler) getTemplate() *template.Template {
 	return h.template.Load().(*template.Template)
 }
 
+func (h *RequestLogHandler) getConfig() *RequestLogConfig {
+	return h.config.Load().(*RequestLogConfig)
+}
+
 func (h *RequestLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	t := h.getTemplate()
-	if t == nil {
+	t, cfg := h.getTemplate(), h.getConfig()
+	structured := cfg.Format == RequestLogFormatJSON
+	if t == nil && !structured {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// Head sampling is decided upfront, so requests which can't be logged
+	// anymore skip the bookkeeping entirely.
+	sampled := sample(cfg.SampleRate)
+	if !sampled && cfg.ErrorSampleRate == 0 {
 		h.handler.ServeHTTP(w, r)
 		return
 	}
 
 	rr := NewResponseRecorder(w, http.StatusOK)
+	timings := &requestTimings{}
+	r = r.WithContext(context.WithValue(r.Context(), requestTimingsKey{}, timings))
 	startTime := time.Now()
 
 	defer func() {
@@ -143,26 +176,62 @@ func (h *RequestLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		// If ServeHTTP panics, recover, record the failure and panic again.
 		err := recover()
-		latency := time.Since(startTime).Seconds()
+		resp := &RequestLogResponse{
+			Code:      rr.ResponseCode,
+			Latency:   time.Since(startTime).Seconds(),
+			Size:      rr.ResponseSize,
+			QueueWait: timings.queueWait.Load().Seconds(),
+		}
+		if err != nil {
+			resp.Code = http.StatusInternalServerError
+			resp.Size = 0
+		}
+
+		if sampled || (resp.Code >= http.StatusInternalServerError && sample(cfg.ErrorSampleRate)) {
+			if structured {
+				h.writeJSON(cfg, h.inputGetter(r, resp))
+			} else {
+				h.write(t, h.inputGetter(r, resp))
+			}
+		}
+
 		if err != nil {
-			h.write(t, h.inputGetter(r, &RequestLogResponse{
-				Code:    http.StatusInternalServerError,
-				Latency: latency,
-				Size:    0,
-			}))
 			panic(err)
-		} else {
-			h.write(t, h.inputGetter(r, &RequestLogResponse{
-				Code:    rr.ResponseCode,
-				Latency: latency,
-				Size:    rr.ResponseSize,
-			}))
 		}
 	}()
 
 	h.handler.ServeHTTP(rr, r)
 }
 
+func sample(rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	default:
+		return rand.Float64() < rate //nolint:gosec // We don't need cryptographic randomness here.
+	}
+}
+
+type requestTimingsKey struct{}
+
+// requestTimings collects timings reported by the inner handlers. The
+// fields are atomic because the timeout handler may still be running the
+// inner handlers after the request has been logged.
+type requestTimings struct {
+	queueWait atomic.Duration
+}
+
+// RecordQueueWait records the time the request spent queued before it was
+// handed to the application, to be included in the request logs. It is a
+// no-op if the request is not going to be logged.
+func RecordQueueWait(ctx context.Context, d time.Duration) {
+	if t, ok := ctx.Value(requestTimingsKey{}).(*requestTimings); ok {
+		t.queueWait.Store(d)
+	}
+}
+
 var bufPool = sync.Pool{
 	New: func() interface{} {
 		return new(bytes.Buffer)
@@ -183,3 +252,106 @@ func (h *RequestLogHandler) write(t *template.Template, in *RequestLogTemplateIn
 	}
 	h.writer.Write(w.Bytes())
 }
+
+type requestLogEntry struct {
+	Request  *requestLogEntryRequest  `json:"request,omitempty"`
+	Response *requestLogEntryResponse `json:"response,omitempty"`
+	Revision *RequestLogRevision      `json:"revision,omitempty"`
+	Latency  *requestLogEntryLatency  `json:"latency,omitempty"`
+	Trace    *requestLogEntryTrace    `json:"trace,omitempty"`
+}
+
+type requestLogEntryRequest struct {
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Host      string `json:"host,omitempty"`
+	Size      int64  `json:"size"`
+	UserAgent string `json:"userAgent,omitempty"`
+	RemoteIP  string `json:"remoteIp,omitempty"`
+	Referer   string `json:"referer,omitempty"`
+	Protocol  string `json:"protocol"`
+}
+
+type requestLogEntryResponse struct {
+	Code int `json:"code"`
+	Size int `json:"size"`
+}
+
+// requestLogEntryLatency contains the latency breakdown in seconds.
+type requestLogEntryLatency struct {
+	Total     float64 `json:"total"`
+	QueueWait float64 `json:"queueWait"`
+	App       float64 `json:"app"`
+}
+
+type requestLogEntryTrace struct {
+	TraceID string `json:"traceId,omitempty"`
+	SpanID  string `json:"spanId,omitempty"`
+}
+
+func (h *RequestLogHandler) writeJSON(cfg *RequestLogConfig, in *RequestLogTemplateInput) {
+	var entry requestLogEntry
+	if cfg.Fields.Has(RequestLogFieldRequest) {
+		entry.Request = &requestLogEntryRequest{
+			Method:    in.Request.Method,
+			URL:       in.Request.RequestURI,
+			Host:      in.Request.Host,
+			Size:      in.Request.ContentLength,
+			UserAgent: in.Request.UserAgent(),
+			RemoteIP:  in.Request.RemoteAddr,
+			Referer:   in.Request.Referer(),
+			Protocol:  in.Request.Proto,
+		}
+	}
+	if cfg.Fields.Has(RequestLogFieldResponse) {
+		entry.Response = &requestLogEntryResponse{
+			Code: in.Response.Code,
+			Size: in.Response.Size,
+		}
+	}
+	if cfg.Fields.Has(RequestLogFieldRevision) {
+		entry.Revision = in.Revision
+	}
+	if cfg.Fields.Has(RequestLogFieldLatency) {
+		entry.Latency = &requestLogEntryLatency{
+			Total:     in.Response.Latency,
+			QueueWait: in.Response.QueueWait,
+			App:       in.Response.Latency - in.Response.QueueWait,
+		}
+	}
+	if cfg.Fields.Has(RequestLogFieldTrace) {
+		if traceID, spanID := traceIDs(in.Request.Header); traceID != "" {
+			entry.Trace = &requestLogEntryTrace{
+				TraceID: traceID,
+				SpanID:  spanID,
+			}
+		}
+	}
+
+	// Same as for the templates, buffer the whole line so parallel
+	// requests don't interleave their output.
+	w := bufPool.Get().(*bytes.Buffer)
+	w.Reset()
+	defer bufPool.Put(w)
+
+	// Encode appends the trailing newline.
+	if err := json.NewEncoder(w).Encode(&entry); err != nil {
+		fmt.Fprintf(h.writer, "Failed to encode request log: method: %v, response code: %v, latency: %v, url: %v\n",
+			in.Request.Method, in.Response.Code, in.Response.Latency, in.Request.URL)
+		return
+	}
+	h.writer.Write(w.Bytes())
+}
+
+// traceIDs extracts the trace and span IDs from either the B3 or the W3C
+// trace context headers.
+func traceIDs(h http.Header) (traceID, spanID string) {
+	if traceID = h.Get("X-B3-Traceid"); traceID != "" {
+		return traceID, h.Get("X-B3-Spanid")
+	}
+	// traceparent: version-traceid-parentid-flags
+	if parts := strings.Split(h.Get("Traceparent"), "-"); len(parts) == 4 {
+		return parts[1], parts[2]
+	}
+	return "", ""
+}
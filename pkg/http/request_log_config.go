@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	cm "knative.dev/pkg/configmap"
+)
+
+// RequestLogFormat is the output format of the request logs.
+type RequestLogFormat string
+
+const (
+	// RequestLogFormatTemplate formats request logs using the Go
+	// text/template from logging.request-log-template.
+	RequestLogFormatTemplate RequestLogFormat = "template"
+
+	// RequestLogFormatJSON formats request logs as JSON lines containing
+	// the fields selected by logging.request-log-fields.
+	RequestLogFormatJSON RequestLogFormat = "json"
+)
+
+// The field groups that can be selected for structured request logs.
+const (
+	RequestLogFieldRequest  = "request"
+	RequestLogFieldResponse = "response"
+	RequestLogFieldRevision = "revision"
+	RequestLogFieldLatency  = "latency"
+	RequestLogFieldTrace    = "trace"
+)
+
+const (
+	// RequestLogFormatKey is the config-observability key selecting the request log format.
+	RequestLogFormatKey = "logging.request-log-format"
+
+	// RequestLogFieldsKey is the config-observability key selecting the comma
+	// separated field groups written by structured request logs.
+	RequestLogFieldsKey = "logging.request-log-fields"
+
+	// RequestLogSampleRateKey is the config-observability key for the fraction
+	// of requests that are logged, decided when the request arrives.
+	RequestLogSampleRateKey = "logging.request-log-sample-rate"
+
+	// RequestLogErrorSampleRateKey is the config-observability key for the
+	// fraction of requests failing with a 5xx response that are logged, in
+	// addition to the requests picked by the head sampling.
+	RequestLogErrorSampleRateKey = "logging.request-log-error-sample-rate"
+)
+
+var allRequestLogFields = sets.NewString(
+	RequestLogFieldRequest,
+	RequestLogFieldResponse,
+	RequestLogFieldRevision,
+	RequestLogFieldLatency,
+	RequestLogFieldTrace,
+)
+
+// RequestLogConfig contains the request log settings that are layered on top
+// of the template configured in config-observability.
+type RequestLogConfig struct {
+	// Format selects between the text/template and the JSON request logs.
+	Format RequestLogFormat
+
+	// Fields is the set of field groups written by JSON request logs.
+	Fields sets.String
+
+	// SampleRate is the fraction of requests that are logged, in [0, 1].
+	SampleRate float64
+
+	// ErrorSampleRate is the fraction of requests ending with a 5xx response
+	// that are logged if they were not picked by SampleRate, in [0, 1].
+	ErrorSampleRate float64
+}
+
+// DeepCopy returns a deep copy of the RequestLogConfig.
+func (c *RequestLogConfig) DeepCopy() *RequestLogConfig {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	if c.Fields != nil {
+		out.Fields = sets.NewString(c.Fields.UnsortedList()...)
+	}
+	return &out
+}
+
+func defaultRequestLogConfig() *RequestLogConfig {
+	return &RequestLogConfig{
+		Format:          RequestLogFormatTemplate,
+		Fields:          sets.NewString(allRequestLogFields.UnsortedList()...),
+		SampleRate:      1,
+		ErrorSampleRate: 1,
+	}
+}
+
+// NewRequestLogConfigFromMap creates a RequestLogConfig from the supplied map.
+func NewRequestLogConfigFromMap(data map[string]string) (*RequestLogConfig, error) {
+	rc := defaultRequestLogConfig()
+
+	var format string
+	if err := cm.Parse(data,
+		cm.AsString(RequestLogFormatKey, &format),
+		cm.AsStringSet(RequestLogFieldsKey, &rc.Fields),
+		cm.AsFloat64(RequestLogSampleRateKey, &rc.SampleRate),
+		cm.AsFloat64(RequestLogErrorSampleRateKey, &rc.ErrorSampleRate),
+	); err != nil {
+		return nil, err
+	}
+
+	switch f := RequestLogFormat(strings.ToLower(format)); f {
+	case "":
+		// Keep the default.
+	case RequestLogFormatTemplate, RequestLogFormatJSON:
+		rc.Format = f
+	default:
+		return nil, fmt.Errorf("unsupported %s value %q", RequestLogFormatKey, format)
+	}
+
+	if unknown := rc.Fields.Difference(allRequestLogFields); unknown.Len() > 0 {
+		return nil, fmt.Errorf("unsupported %s values %v, supported values are %v",
+			RequestLogFieldsKey, unknown.List(), allRequestLogFields.List())
+	}
+	if rc.SampleRate < 0 || rc.SampleRate > 1 {
+		return nil, fmt.Errorf("%s = %v, must be in [0, 1] range", RequestLogSampleRateKey, rc.SampleRate)
+	}
+	if rc.ErrorSampleRate < 0 || rc.ErrorSampleRate > 1 {
+		return nil, fmt.Errorf("%s = %v, must be in [0, 1] range", RequestLogErrorSampleRateKey, rc.ErrorSampleRate)
+	}
+	return rc, nil
+}
+
+// NewRequestLogConfigFromConfigMap creates a RequestLogConfig from the config-observability ConfigMap.
+func NewRequestLogConfigFromConfigMap(configMap *corev1.ConfigMap) (*RequestLogConfig, error) {
+	return NewRequestLogConfigFromMap(configMap.Data)
+}
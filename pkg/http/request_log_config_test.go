@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/metrics"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestRequestLogConfigFromConfigMap(t *testing.T) {
+	cm, example := ConfigMapsFromTestFile(t, metrics.ConfigMapName())
+
+	if _, err := NewRequestLogConfigFromConfigMap(cm); err != nil {
+		t.Error("NewRequestLogConfigFromConfigMap(actual) =", err)
+	}
+
+	got, err := NewRequestLogConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatal("NewRequestLogConfigFromConfigMap(example) =", err)
+	}
+	if want := defaultRequestLogConfig(); !cmp.Equal(got, want) {
+		t.Error("Example does not represent the default config: diff(-want,+got)\n", cmp.Diff(want, got))
+	}
+}
+
+func TestNewRequestLogConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    *RequestLogConfig
+		wantErr bool
+	}{{
+		name: "defaults",
+		data: map[string]string{},
+		want: defaultRequestLogConfig(),
+	}, {
+		name: "structured",
+		data: map[string]string{
+			RequestLogFormatKey:          "JSON",
+			RequestLogFieldsKey:          "response, latency",
+			RequestLogSampleRateKey:      "0.1",
+			RequestLogErrorSampleRateKey: "0.5",
+		},
+		want: &RequestLogConfig{
+			Format:          RequestLogFormatJSON,
+			Fields:          sets.NewString(RequestLogFieldResponse, RequestLogFieldLatency),
+			SampleRate:      0.1,
+			ErrorSampleRate: 0.5,
+		},
+	}, {
+		name:    "unknown format",
+		data:    map[string]string{RequestLogFormatKey: "xml"},
+		wantErr: true,
+	}, {
+		name:    "unknown field",
+		data:    map[string]string{RequestLogFieldsKey: "request,cookies"},
+		wantErr: true,
+	}, {
+		name:    "sample rate too high",
+		data:    map[string]string{RequestLogSampleRateKey: "1.5"},
+		wantErr: true,
+	}, {
+		name:    "negative error sample rate",
+		data:    map[string]string{RequestLogErrorSampleRateKey: "-0.1"},
+		wantErr: true,
+	}, {
+		name:    "malformed sample rate",
+		data:    map[string]string{RequestLogSampleRateKey: "all"},
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewRequestLogConfigFromMap(tc.data)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewRequestLogConfigFromMap() = %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("NewRequestLogConfigFromMap (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -18,12 +18,16 @@ package http
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
 	network "knative.dev/networking/pkg"
 )
 
@@ -233,6 +237,181 @@ func TestSetTemplate(t *testing.T) {
 	}
 }
 
+func TestStructuredRequestLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		header  http.Header
+		handler http.Handler
+		want    map[string]interface{}
+	}{{
+		name:   "response and revision",
+		fields: []string{RequestLogFieldResponse, RequestLogFieldRevision},
+		want: map[string]interface{}{
+			"response": map[string]interface{}{"code": 200.0, "size": 0.0},
+			"revision": map[string]interface{}{
+				"name":          "rev",
+				"namespace":     "ns",
+				"service":       "svc",
+				"configuration": "cfg",
+				"podName":       "pn",
+				"podIP":         "ip",
+			},
+		},
+	}, {
+		name:   "request",
+		fields: []string{RequestLogFieldRequest},
+		header: http.Header{"User-Agent": []string{`agent "with" quotes`}},
+		want: map[string]interface{}{
+			"request": map[string]interface{}{
+				"method":    "POST",
+				"url":       "http://example.com/testpage?q=1",
+				"host":      "example.com",
+				"size":      4.0,
+				"userAgent": `agent "with" quotes`,
+				"remoteIp":  "192.0.2.1:1234",
+				"protocol":  "HTTP/1.1",
+			},
+		},
+	}, {
+		name:   "b3 trace",
+		fields: []string{RequestLogFieldTrace},
+		header: http.Header{
+			"X-B3-Traceid": []string{"0af7651916cd43dd8448eb211c80319c"},
+			"X-B3-Spanid":  []string{"b7ad6b7169203331"},
+		},
+		want: map[string]interface{}{
+			"trace": map[string]interface{}{
+				"traceId": "0af7651916cd43dd8448eb211c80319c",
+				"spanId":  "b7ad6b7169203331",
+			},
+		},
+	}, {
+		name:   "w3c trace",
+		fields: []string{RequestLogFieldTrace},
+		header: http.Header{
+			"Traceparent": []string{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		},
+		want: map[string]interface{}{
+			"trace": map[string]interface{}{
+				"traceId": "0af7651916cd43dd8448eb211c80319c",
+				"spanId":  "b7ad6b7169203331",
+			},
+		},
+	}, {
+		name:   "no trace",
+		fields: []string{RequestLogFieldTrace},
+		want:   map[string]interface{}{},
+	}, {
+		name:   "queue wait",
+		fields: []string{RequestLogFieldLatency},
+		handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			RecordQueueWait(r.Context(), 2*time.Second)
+		}),
+		want: map[string]interface{}{
+			"latency": map[string]interface{}{"queueWait": 2.0},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.NewBufferString("")
+			h := test.handler
+			if h == nil {
+				h = baseHandler
+			}
+			handler, err := NewRequestLogHandler(h, buf, "", defaultInputGetter, false)
+			if err != nil {
+				t.Fatal("NewRequestLogHandler() =", err)
+			}
+			handler.SetConfig(&RequestLogConfig{
+				Format:          RequestLogFormatJSON,
+				Fields:          sets.NewString(test.fields...),
+				SampleRate:      1,
+				ErrorSampleRate: 1,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "http://example.com/testpage?q=1", bytes.NewBufferString("test"))
+			for k, v := range test.header {
+				req.Header[k] = v
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !strings.HasSuffix(buf.String(), "\n") || strings.Count(buf.String(), "\n") != 1 {
+				t.Errorf("Want a single line, got %q", buf.String())
+			}
+			var got map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to parse %q: %v", buf.String(), err)
+			}
+			// Latencies depend on the wall clock, so only the queue wait is stable.
+			if l, ok := got["latency"].(map[string]interface{}); ok {
+				delete(l, "total")
+				delete(l, "app")
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Error("Request log (-want, +got):", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
+
+func TestRequestLogSampling(t *testing.T) {
+	errorHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	tests := []struct {
+		name            string
+		handler         http.Handler
+		sampleRate      float64
+		errorSampleRate float64
+		wantLogged      bool
+	}{{
+		name:       "everything sampled",
+		handler:    baseHandler,
+		sampleRate: 1,
+		wantLogged: true,
+	}, {
+		name:            "nothing sampled",
+		handler:         errorHandler,
+		sampleRate:      0,
+		errorSampleRate: 0,
+	}, {
+		name:            "successes are not error sampled",
+		handler:         baseHandler,
+		sampleRate:      0,
+		errorSampleRate: 1,
+	}, {
+		name:            "errors are error sampled",
+		handler:         errorHandler,
+		sampleRate:      0,
+		errorSampleRate: 1,
+		wantLogged:      true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.NewBufferString("")
+			handler, err := NewRequestLogHandler(test.handler, buf, "{{.Response.Code}}", defaultInputGetter, false)
+			if err != nil {
+				t.Fatal("NewRequestLogHandler() =", err)
+			}
+			handler.SetConfig(&RequestLogConfig{
+				Format:          RequestLogFormatTemplate,
+				SampleRate:      test.sampleRate,
+				ErrorSampleRate: test.errorSampleRate,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got := buf.Len() > 0; got != test.wantLogged {
+				t.Errorf("Logged = %v (%q), want: %v", got, buf.String(), test.wantLogged)
+			}
+		})
+	}
+}
+
 func BenchmarkRequestLogHandlerNoTemplate(b *testing.B) {
 	handler, err := NewRequestLogHandler(baseHandler, io.Discard, "", defaultInputGetter, false)
 	if err != nil {
@@ -21,8 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"go.uber.org/atomic"
+
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 var (
@@ -39,6 +42,22 @@ type BreakerParams struct {
 	QueueDepth      int
 	MaxConcurrency  int
 	InitialCapacity int
+
+	// Reporters are notified about the requests passing through the
+	// queue of the breaker. Optional.
+	Reporters []BreakerReporter
+}
+
+// BreakerReporter receives the outcome of the requests queued by a Breaker.
+type BreakerReporter interface {
+	// ReportQueueWait reports how long an admitted request waited for capacity.
+	ReportQueueWait(time.Duration)
+	// ReportQueueOverflow reports a request that was rejected because the
+	// queue was full.
+	ReportQueueOverflow()
+	// ReportQueueCancelled reports a request whose context was done while
+	// it was waiting in the queue.
+	ReportQueueCancelled()
 }
 
 // Breaker is a component that enforces a concurrency limit on the
@@ -49,6 +68,7 @@ type Breaker struct {
 	inFlight   atomic.Int64
 	totalSlots int64
 	sem        *semaphore
+	reporters  []BreakerReporter
 
 	// release is the callback function returned to callers by Reserve to
 	// allow the reservation made by Reserve to be released.
@@ -71,6 +91,7 @@ func NewBreaker(params BreakerParams) *Breaker {
 	b := &Breaker{
 		totalSlots: int64(params.QueueDepth + params.MaxConcurrency),
 		sem:        newSemaphore(params.MaxConcurrency, params.InitialCapacity),
+		reporters:  params.Reporters,
 	}
 
 	// Allocating the closure returned by Reserve here avoids an allocation in Reserve.
@@ -136,15 +157,29 @@ func (b *Breaker) Reserve(ctx context.Context) (func(), bool) {
 // the thunk was executed, Maybe returns nil, else error.
 func (b *Breaker) Maybe(ctx context.Context, thunk func()) error {
 	if !b.tryAcquirePending() {
+		for _, r := range b.reporters {
+			r.ReportQueueOverflow()
+		}
 		return ErrRequestQueueFull
 	}
 
 	defer b.releasePending()
 
+	queuedAt := time.Now()
+
 	// Wait for capacity in the active queue.
 	if err := b.sem.acquire(ctx); err != nil {
+		for _, r := range b.reporters {
+			r.ReportQueueCancelled()
+		}
 		return err
 	}
+	// The wait is measured once, so the metrics and the request logs agree.
+	wait := time.Since(queuedAt)
+	for _, r := range b.reporters {
+		r.ReportQueueWait(wait)
+	}
+	pkghttp.RecordQueueWait(ctx, wait)
 	// Defer releasing capacity in the active.
 	// It's safe to ignore the error returned by release since we
 	// make sure the semaphore is only manipulated here and acquire
@@ -17,10 +17,17 @@ limitations under the License.
 package queue
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 const (
@@ -223,6 +230,54 @@ func TestBreakerUpdateConcurrency(t *testing.T) {
 
 }
 
+type waitReporter struct {
+	waits []time.Duration
+}
+
+func (r *waitReporter) ReportQueueWait(d time.Duration) { r.waits = append(r.waits, d) }
+func (r *waitReporter) ReportQueueOverflow()            {}
+func (r *waitReporter) ReportQueueCancelled()           {}
+
+func TestBreakerQueueWaitMatchesRequestLog(t *testing.T) {
+	reporter := &waitReporter{}
+	b := NewBreaker(BreakerParams{QueueDepth: 1, MaxConcurrency: 1, InitialCapacity: 1,
+		Reporters: []BreakerReporter{reporter}})
+
+	buf := &bytes.Buffer{}
+	handler, err := pkghttp.NewRequestLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := b.Maybe(r.Context(), func() {}); err != nil {
+			t.Error("Maybe() =", err)
+		}
+	}), buf, "", func(req *http.Request, resp *pkghttp.RequestLogResponse) *pkghttp.RequestLogTemplateInput {
+		return &pkghttp.RequestLogTemplateInput{Request: req, Response: resp}
+	}, false)
+	if err != nil {
+		t.Fatal("NewRequestLogHandler() =", err)
+	}
+	handler.SetConfig(&pkghttp.RequestLogConfig{
+		Format:          pkghttp.RequestLogFormatJSON,
+		Fields:          sets.NewString(pkghttp.RequestLogFieldLatency),
+		SampleRate:      1,
+		ErrorSampleRate: 1,
+	})
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com", nil))
+
+	var entry struct {
+		Latency struct {
+			QueueWait float64 `json:"queueWait"`
+		} `json:"latency"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Unmarshal(%q) = %v", buf.String(), err)
+	}
+	if len(reporter.waits) != 1 {
+		t.Fatalf("ReportQueueWait calls = %d, want: 1", len(reporter.waits))
+	}
+	if got, want := entry.Latency.QueueWait, reporter.waits[0].Seconds(); got != want {
+		t.Errorf("Logged queue wait = %v, want: %v", got, want)
+	}
+}
+
 // Test empty semaphore, token cannot be acquired
 func TestSemaphoreAcquireHasNoCapacity(t *testing.T) {
 	gotChan := make(chan struct{}, 1)
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"go.uber.org/zap"
+)
+
+// regexCacheSize bounds the number of compiled regular expressions of the
+// faults kept by the queue proxy. The faults of a revision are few, but the
+// clients reaching the queue proxy directly may send any fault header.
+const regexCacheSize = 64
+
+// FaultHeaderName is the header key for the fault the queue proxy injects
+// into a share of the requests of a Route. Its value is the JSON encoding
+// of Fault.
+const FaultHeaderName = "Knative-Serving-Fault"
+
+// Fault is a fault injected into a share of the requests.
+type Fault struct {
+	// Percent of the requests the fault is injected into.
+	Percent int `json:"percent"`
+	// Delay delays the requests before they are served.
+	Delay time.Duration `json:"delay,omitempty"`
+	// AbortStatus aborts the requests with the HTTP status code.
+	AbortStatus int `json:"abortStatus,omitempty"`
+	// Headers restricts the fault to the requests with matching headers.
+	Headers []FaultHeaderMatch `json:"headers,omitempty"`
+}
+
+// FaultHeaderMatch matches the value of a request header with one of
+// Exact, Prefix and Regex.
+type FaultHeaderMatch struct {
+	Name   string `json:"name"`
+	Exact  string `json:"exact,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Regex  string `json:"regex,omitempty"`
+}
+
+// matches returns whether the request has a header matching hm. The
+// regular expressions are compiled once, and kept in regexps.
+func (hm *FaultHeaderMatch) matches(r *http.Request, regexps *lru.Cache) (bool, error) {
+	value, ok := r.Header[http.CanonicalHeaderKey(hm.Name)]
+	if !ok {
+		return false, nil
+	}
+	switch {
+	case hm.Regex != "":
+		re, err := compileRegex(regexps, hm.Regex)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(value[0]), nil
+	case hm.Prefix != "":
+		return strings.HasPrefix(value[0], hm.Prefix), nil
+	default:
+		return value[0] == hm.Exact, nil
+	}
+}
+
+// compileRegex returns the compiled regular expression, from the cache if
+// it was compiled before.
+func compileRegex(cache *lru.Cache, expr string) (*regexp.Regexp, error) {
+	if re, ok := cache.Get(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	cache.Add(expr, re)
+	return re, nil
+}
+
+// FaultHandler injects the fault of the fault header into the request,
+// and removes the header.
+func FaultHandler(logger *zap.SugaredLogger, next http.Handler) http.HandlerFunc {
+	// The only possible error is when the cache size is not positive.
+	regexps, _ := lru.New(regexCacheSize)
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(FaultHeaderName)
+		// The Ingress clears the header of the requests without a fault.
+		r.Header.Del(FaultHeaderName)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		f := &Fault{}
+		if err := json.Unmarshal([]byte(header), f); err != nil {
+			logger.Warnw("Ignoring malformed fault", zap.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+		for i := range f.Headers {
+			ok, err := f.Headers[i].matches(r, regexps)
+			if err != nil {
+				logger.Warnw("Ignoring malformed fault", zap.Error(err))
+			}
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		if rand.Intn(100) >= f.Percent { //nolint:gosec // We don't need cryptographic randomness here.
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if f.AbortStatus != 0 {
+			http.Error(w, http.StatusText(f.AbortStatus), f.AbortStatus)
+			return
+		}
+		t := time.NewTimer(f.Delay)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+			// The client or the request timeout cancelled the request.
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	ktesting "knative.dev/pkg/logging/testing"
+)
+
+func TestFaultHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		fault      string
+		headers    map[string]string
+		wantStatus int
+		wantServed bool
+		wantDelay  time.Duration
+	}{{
+		name:       "no fault",
+		wantStatus: http.StatusOK,
+		wantServed: true,
+	}, {
+		name:       "abort",
+		fault:      `{"percent":100,"abortStatus":503}`,
+		wantStatus: http.StatusServiceUnavailable,
+	}, {
+		name:       "delay",
+		fault:      `{"percent":100,"delay":50000000}`,
+		wantStatus: http.StatusOK,
+		wantServed: true,
+		wantDelay:  50 * time.Millisecond,
+	}, {
+		name:       "never injected",
+		fault:      `{"percent":0,"abortStatus":503}`,
+		wantStatus: http.StatusOK,
+		wantServed: true,
+	}, {
+		name:       "matching headers",
+		fault:      `{"percent":100,"abortStatus":500,"headers":[{"name":"user","exact":"tester"},{"name":"user-agent","prefix":"curl/"},{"name":"test","regex":"^chaos-[0-9]+$"}]}`,
+		headers:    map[string]string{"User": "tester", "User-Agent": "curl/7.79.1", "Test": "chaos-42"},
+		wantStatus: http.StatusInternalServerError,
+	}, {
+		name:       "mismatching header",
+		fault:      `{"percent":100,"abortStatus":500,"headers":[{"name":"user","exact":"tester"},{"name":"test","regex":"^chaos-[0-9]+$"}]}`,
+		headers:    map[string]string{"User": "tester", "Test": "chaos"},
+		wantStatus: http.StatusOK,
+		wantServed: true,
+	}, {
+		name:       "missing header",
+		fault:      `{"percent":100,"abortStatus":500,"headers":[{"name":"user","exact":"tester"}]}`,
+		wantStatus: http.StatusOK,
+		wantServed: true,
+	}, {
+		name:       "malformed",
+		fault:      `{"percent":`,
+		wantStatus: http.StatusOK,
+		wantServed: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			served := false
+			h := FaultHandler(ktesting.TestLogger(t), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+				if got, ok := r.Header[FaultHeaderName]; ok {
+					t.Errorf("Fault header = %q, want it removed", got)
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			// The Ingress clears the header of the requests without a fault.
+			req.Header.Set(FaultHeaderName, test.fault)
+			resp := httptest.NewRecorder()
+			start := time.Now()
+			h.ServeHTTP(resp, req)
+
+			if got := resp.Code; got != test.wantStatus {
+				t.Errorf("Status = %d, want: %d", got, test.wantStatus)
+			}
+			if served != test.wantServed {
+				t.Errorf("Served = %t, want: %t", served, test.wantServed)
+			}
+			if got := time.Since(start); got < test.wantDelay {
+				t.Errorf("Request took %v, want it delayed by %v", got, test.wantDelay)
+			}
+		})
+	}
+}
+
+func TestFaultHandlerCancelledDelay(t *testing.T) {
+	served := false
+	h := FaultHandler(ktesting.TestLogger(t), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil).WithContext(ctx)
+	req.Header.Set(FaultHeaderName, `{"percent":100,"delay":3600000000000}`)
+	cancel()
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if served {
+		t.Error("The cancelled request was served")
+	}
+}
+
+func TestCompileRegex(t *testing.T) {
+	cache, _ := lru.New(regexCacheSize)
+	re, err := compileRegex(cache, "^chaos-[0-9]+$")
+	if err != nil {
+		t.Fatal("compileRegex() =", err)
+	}
+	if again, _ := compileRegex(cache, "^chaos-[0-9]+$"); again != re {
+		t.Error("The regular expression was compiled again")
+	}
+	if _, err := compileRegex(cache, "chaos-[0-9"); err == nil {
+		t.Error("compileRegex() = nil, want an error for a malformed expression")
+	}
+
+	// The cache doesn't grow past its size.
+	for i := 0; i < 2*regexCacheSize; i++ {
+		if _, err := compileRegex(cache, "^chaos-"+strconv.Itoa(i)+"$"); err != nil {
+			t.Fatal("compileRegex() =", err)
+		}
+	}
+	if got := cache.Len(); got != regexCacheSize {
+		t.Errorf("Cache size = %d, want: %d", got, regexCacheSize)
+	}
+}
@@ -23,8 +23,11 @@ import (
 	"time"
 
 	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
 	network "knative.dev/networking/pkg"
 	"knative.dev/serving/pkg/activator"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 // ProxyHandler sends requests to the `next` handler at a rate controlled by
@@ -64,7 +67,9 @@ func ProxyHandler(breaker *Breaker, stats *network.RequestStats, tracingEnabled
 				next.ServeHTTP(w, r)
 			}); err != nil {
 				waitSpan.End()
-				if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrRequestQueueFull) {
+				if pkghttp.IsGRPC(r) {
+					pkghttp.WriteGRPCError(w, grpcCodeForBreakerError(err), err.Error())
+				} else if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrRequestQueueFull) {
 					http.Error(w, err.Error(), http.StatusServiceUnavailable)
 				} else {
 					// This line is most likely untestable :-).
@@ -76,3 +81,44 @@ func ProxyHandler(breaker *Breaker, stats *network.RequestStats, tracingEnabled
 		}
 	}
 }
+
+func grpcCodeForBreakerError(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrRequestQueueFull):
+		return codes.ResourceExhausted
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unavailable
+	}
+}
+
+// GRPCTimeoutHandler applies the deadline a gRPC client requested through
+// the grpc-timeout header to the request's context.
+func GRPCTimeoutHandler(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if timeout, ok := pkghttp.GRPCTimeout(r); ok {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// GRPCErrorHandler wraps the ErrorHandler of a reverse proxy to respond
+// to gRPC calls with a gRPC status rather than a plain 502.
+func GRPCErrorHandler(logger *zap.SugaredLogger, next func(http.ResponseWriter, *http.Request, error)) func(http.ResponseWriter, *http.Request, error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		if !pkghttp.IsGRPC(r) {
+			next(w, r, err)
+			return
+		}
+		logger.Errorw("error reverse proxying gRPC request", zap.Error(err))
+		code := codes.Unavailable
+		if errors.Is(err, context.DeadlineExceeded) {
+			code = codes.DeadlineExceeded
+		}
+		pkghttp.WriteGRPCError(w, code, err.Error())
+	}
+}
@@ -18,6 +18,7 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -28,8 +29,12 @@ import (
 	"time"
 
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/util/wait"
 	network "knative.dev/networking/pkg"
 	"knative.dev/serving/pkg/activator"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 const (
@@ -120,6 +125,90 @@ func TestHandlerBreakerTimeout(t *testing.T) {
 	}
 }
 
+func TestHandlerBreakerGRPC(t *testing.T) {
+	resp := make(chan struct{})
+	defer close(resp)
+	seen := make(chan struct{}, 1)
+	blockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen <- struct{}{}
+		<-resp
+	})
+	breaker := NewBreaker(BreakerParams{
+		QueueDepth: 1, MaxConcurrency: 1, InitialCapacity: 1,
+	})
+	stats := network.NewRequestStats(time.Now())
+	h := GRPCTimeoutHandler(ProxyHandler(breaker, stats, false /*tracingEnabled*/, blockHandler))
+
+	grpcRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "http://localhost:8081/pkg.Service/Method", nil)
+		r.ProtoMajor, r.ProtoMinor, r.Proto = 2, 0, "HTTP/2.0"
+		r.Header.Set("Content-Type", "application/grpc")
+		return r
+	}
+
+	// Occupy the only slot.
+	go h(httptest.NewRecorder(), grpcRequest())
+	<-seen
+
+	// The next request has to wait in the queue and exceeds its deadline.
+	req := grpcRequest()
+	req.Header.Set(pkghttp.GRPCTimeoutHeaderName, "10m")
+	queued := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		queued <- rec
+	}()
+	if err := wait.PollImmediate(time.Millisecond, 5*time.Second, func() (bool, error) {
+		return breaker.InFlight() == 2, nil
+	}); err != nil {
+		t.Fatal("Request never got queued:", err)
+	}
+
+	// The queue is full, so the third one is rejected right away.
+	rec := httptest.NewRecorder()
+	h(rec, grpcRequest())
+	if got, ok := pkghttp.GRPCStatus(rec.Header()); !ok || got != codes.ResourceExhausted {
+		t.Errorf("Status = %v, want: %v", got, codes.ResourceExhausted)
+	}
+	if got, want := rec.Code, http.StatusOK; got != want {
+		t.Errorf("Code = %d, want: %d", got, want)
+	}
+
+	rec = <-queued
+	if got, ok := pkghttp.GRPCStatus(rec.Header()); !ok || got != codes.DeadlineExceeded {
+		t.Errorf("Status = %v, want: %v", got, codes.DeadlineExceeded)
+	}
+}
+
+func TestGRPCErrorHandler(t *testing.T) {
+	var fallbackCalled bool
+	h := GRPCErrorHandler(zap.NewNop().Sugar(), func(w http.ResponseWriter, r *http.Request, err error) {
+		fallbackCalled = true
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "http://localhost:8081/", nil), errors.New("connection refused"))
+	if !fallbackCalled || rec.Code != http.StatusBadGateway {
+		t.Errorf("Want plain requests to use the fallback, got code %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:8081/pkg.Service/Method", nil)
+	req.ProtoMajor = 2
+	req.Header.Set("Content-Type", "application/grpc")
+	for err, want := range map[error]codes.Code{
+		errors.New("connection refused"): codes.Unavailable,
+		context.DeadlineExceeded:         codes.DeadlineExceeded,
+	} {
+		rec := httptest.NewRecorder()
+		h(rec, req, err)
+		if got, ok := pkghttp.GRPCStatus(rec.Header()); !ok || got != want {
+			t.Errorf("Status for %v = %v, want: %v", err, got, want)
+		}
+	}
+}
+
 func TestHandlerReqEvent(t *testing.T) {
 	params := BreakerParams{QueueDepth: 10, MaxConcurrency: 10, InitialCapacity: 10}
 	breaker := NewBreaker(params)
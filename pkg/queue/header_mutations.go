@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"knative.dev/pkg/websocket"
+)
+
+// HeaderMutationsHeaderName is the header key for the mutations of the
+// headers of a request and of its response which the Ingress cannot
+// apply. Its value is the JSON encoding of HeaderMutations.
+const HeaderMutationsHeaderName = "Knative-Serving-Header-Mutations"
+
+// HeaderMutations are the mutations of the headers of a request and of its
+// response applied by the queue proxy. The Ingress sets the headers of the
+// request itself.
+type HeaderMutations struct {
+	// RequestAdd are the values added to the headers of the request.
+	RequestAdd map[string]string `json:"requestAdd,omitempty"`
+	// RequestRemove are the headers removed from the request.
+	RequestRemove []string `json:"requestRemove,omitempty"`
+	// ResponseSet are the values the headers of the response are set to.
+	ResponseSet map[string]string `json:"responseSet,omitempty"`
+	// ResponseAdd are the values added to the headers of the response.
+	ResponseAdd map[string]string `json:"responseAdd,omitempty"`
+	// ResponseRemove are the headers removed from the response.
+	ResponseRemove []string `json:"responseRemove,omitempty"`
+}
+
+// HeaderMutationsHandler applies the mutations of the header mutations
+// header to the request and to its response, and removes the header.
+func HeaderMutationsHandler(logger *zap.SugaredLogger, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(HeaderMutationsHeaderName)
+		// The Ingress clears the header of the targets without mutations.
+		r.Header.Del(HeaderMutationsHeaderName)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		m := &HeaderMutations{}
+		if err := json.Unmarshal([]byte(header), m); err != nil {
+			logger.Warnw("Ignoring malformed header mutations", zap.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+		for _, name := range m.RequestRemove {
+			r.Header.Del(name)
+		}
+		for name, value := range m.RequestAdd {
+			r.Header.Add(name, value)
+		}
+		if len(m.ResponseSet)+len(m.ResponseAdd)+len(m.ResponseRemove) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&headerMutatingWriter{writer: w, mutations: m}, r)
+	}
+}
+
+var (
+	_ http.Flusher  = (*headerMutatingWriter)(nil)
+	_ http.Hijacker = (*headerMutatingWriter)(nil)
+)
+
+// headerMutatingWriter mutates the headers of the response before they
+// are written.
+type headerMutatingWriter struct {
+	writer    http.ResponseWriter
+	mutations *HeaderMutations
+	mutated   bool
+}
+
+func (w *headerMutatingWriter) mutate() {
+	if w.mutated {
+		return
+	}
+	w.mutated = true
+	h := w.writer.Header()
+	for _, name := range w.mutations.ResponseRemove {
+		h.Del(name)
+	}
+	for name, value := range w.mutations.ResponseSet {
+		h.Set(name, value)
+	}
+	for name, value := range w.mutations.ResponseAdd {
+		h.Add(name, value)
+	}
+}
+
+// Header returns the header map that will be sent by WriteHeader.
+func (w *headerMutatingWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+// Write writes the data to the connection as part of an HTTP reply.
+func (w *headerMutatingWriter) Write(p []byte) (int, error) {
+	w.mutate()
+	return w.writer.Write(p)
+}
+
+// WriteHeader sends an HTTP response header with the provided status code.
+func (w *headerMutatingWriter) WriteHeader(code int) {
+	w.mutate()
+	w.writer.WriteHeader(code)
+}
+
+// Flush flushes the buffer to the client.
+func (w *headerMutatingWriter) Flush() {
+	w.mutate()
+	w.writer.(http.Flusher).Flush()
+}
+
+// Hijack calls Hijack() on the wrapped http.ResponseWriter if it implements
+// http.Hijacker interface, which is required for net/http/httputil/reverseproxy
+// to handle connection upgrade/switching protocol.  Otherwise returns an error.
+func (w *headerMutatingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return websocket.HijackIfPossible(w.writer)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	ktesting "knative.dev/pkg/logging/testing"
+)
+
+func TestHeaderMutationsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		mutations    string
+		wantRequest  http.Header
+		wantResponse http.Header
+	}{{
+		name: "no mutations",
+		wantRequest: http.Header{
+			"Canary": {"false"},
+			"Secret": {"s3cr3t"},
+		},
+		wantResponse: http.Header{
+			"Internal": {"true"},
+			"Server":   {"user-container"},
+			"Vary":     {"Accept"},
+		},
+	}, {
+		name:      "request and response mutations",
+		mutations: `{"requestAdd":{"Canary":"true"},"requestRemove":["Secret"],"responseSet":{"Server":"knative"},"responseAdd":{"Vary":"Cookie"},"responseRemove":["Internal"]}`,
+		wantRequest: http.Header{
+			"Canary": {"false", "true"},
+		},
+		wantResponse: http.Header{
+			"Server": {"knative"},
+			"Vary":   {"Accept", "Cookie"},
+		},
+	}, {
+		name:      "malformed",
+		mutations: `{"requestRemove":`,
+		wantRequest: http.Header{
+			"Canary": {"false"},
+			"Secret": {"s3cr3t"},
+		},
+		wantResponse: http.Header{
+			"Internal": {"true"},
+			"Server":   {"user-container"},
+			"Vary":     {"Accept"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotRequest http.Header
+			h := HeaderMutationsHandler(ktesting.TestLogger(t), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRequest = r.Header.Clone()
+				w.Header().Set("Server", "user-container")
+				w.Header().Set("Vary", "Accept")
+				w.Header().Set("Internal", "true")
+				w.WriteHeader(http.StatusOK)
+				// Mutations after the headers were written are ignored.
+				w.Header().Set("Late", "true")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			req.Header.Set("Canary", "false")
+			req.Header.Set("Secret", "s3cr3t")
+			// The Ingress clears the header of the targets without mutations.
+			req.Header.Set(HeaderMutationsHeaderName, test.mutations)
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			if !cmp.Equal(gotRequest, test.wantRequest) {
+				t.Errorf("Request headers (-want, +got) = %s", cmp.Diff(test.wantRequest, gotRequest))
+			}
+			if gotResponse := resp.Result().Header; !cmp.Equal(gotResponse, test.wantResponse) {
+				t.Errorf("Response headers (-want, +got) = %s", cmp.Diff(test.wantResponse, gotResponse))
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package health
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -24,9 +27,12 @@ import (
 	"net/url"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protowire"
 	corev1 "k8s.io/api/core/v1"
 	network "knative.dev/networking/pkg"
 	pkgnet "knative.dev/pkg/network"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 // HTTPProbeConfigOptions holds the HTTP probe config options
@@ -44,6 +50,15 @@ type TCPProbeConfigOptions struct {
 	Address       string
 }
 
+// GRPCProbeConfigOptions holds the gRPC probe config options
+type GRPCProbeConfigOptions struct {
+	Timeout   time.Duration
+	Address   string
+	Service   string
+	KubeMajor string
+	KubeMinor string
+}
+
 // TCPProbe checks that a TCP socket to the address can be opened.
 // Did not reuse k8s.io/kubernetes/pkg/probe/tcp to not create a dependency
 // on klog.
@@ -99,6 +114,12 @@ func getURL(config HTTPProbeConfigOptions) url.URL {
 	}
 }
 
+// kubeProbeUserAgent returns the User-Agent of the probes for the given
+// Kubernetes version, which identifies them as kubelet probes.
+func kubeProbeUserAgent(major, minor string) string {
+	return network.KubeProbeUAPrefix + major + "/" + minor
+}
+
 // http2UpgradeProbe checks that an HTTP with HTTP2 upgrade request
 // connection can be understood by the address.
 // Returns: the highest known proto version supported (0 if not ready or error)
@@ -119,7 +140,7 @@ func http2UpgradeProbe(config HTTPProbeConfigOptions) (int, error) {
 	req.Header.Add("Upgrade", "h2c")
 	req.Header.Add("HTTP2-Settings", "")
 
-	req.Header.Add(network.UserAgentKey, network.KubeProbeUAPrefix+config.KubeMajor+"/"+config.KubeMinor)
+	req.Header.Add(network.UserAgentKey, kubeProbeUserAgent(config.KubeMajor, config.KubeMinor))
 
 	res, err := httpClient.Do(req)
 	if err != nil {
@@ -167,7 +188,7 @@ func HTTPProbe(config HTTPProbeConfigOptions) error {
 		return fmt.Errorf("error constructing probe request %w", err)
 	}
 
-	req.Header.Add(network.UserAgentKey, network.KubeProbeUAPrefix+config.KubeMajor+"/"+config.KubeMinor)
+	req.Header.Add(network.UserAgentKey, kubeProbeUserAgent(config.KubeMajor, config.KubeMinor))
 
 	for _, header := range config.HTTPHeaders {
 		req.Header.Add(header.Name, header.Value)
@@ -202,3 +223,156 @@ func isHTTPProbeReady(res *http.Response) bool {
 	// response status code between 200-399 indicates success
 	return res.StatusCode >= 200 && res.StatusCode < 400
 }
+
+const (
+	// grpcHealthCheckPath is the path of the grpc.health.v1.Health/Check method.
+	grpcHealthCheckPath = "/grpc.health.v1.Health/Check"
+
+	// maxGRPCHealthResponseSize bounds how much of the health check response we read.
+	// A HealthCheckResponse is a single enum field, so this is plenty.
+	maxGRPCHealthResponseSize = 1024
+)
+
+// healthServingStatus mirrors grpc.health.v1.HealthCheckResponse.ServingStatus.
+type healthServingStatus uint64
+
+const (
+	healthUnknown healthServingStatus = iota
+	healthServing
+	healthNotServing
+	healthServiceUnknown
+)
+
+func (s healthServingStatus) String() string {
+	switch s {
+	case healthUnknown:
+		return "UNKNOWN"
+	case healthServing:
+		return "SERVING"
+	case healthNotServing:
+		return "NOT_SERVING"
+	case healthServiceUnknown:
+		return "SERVICE_UNKNOWN"
+	default:
+		return fmt.Sprintf("ServingStatus(%d)", uint64(s))
+	}
+}
+
+// gRPC always runs over HTTP/2, so the probes always use h2c.
+var grpcTransport = pkgnet.NewH2CTransport()
+
+// GRPCProbe checks that the address responds SERVING to a call of the standard
+// gRPC health checking protocol (grpc.health.v1.Health/Check).
+// Did not use the generated gRPC client to keep the queue-proxy lean.
+func GRPCProbe(config GRPCProbeConfigOptions) error {
+	httpClient := &http.Client{
+		Transport: grpcTransport,
+		Timeout:   config.Timeout,
+	}
+	url := url.URL{
+		Scheme: "http",
+		Host:   config.Address,
+		Path:   grpcHealthCheckPath,
+	}
+	req, err := http.NewRequest(http.MethodPost, url.String(), bytes.NewReader(encodeHealthCheckRequest(config.Service)))
+	if err != nil {
+		return fmt.Errorf("error constructing probe request %w", err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("Te", "trailers")
+	req.Header.Add(network.UserAgentKey, kubeProbeUserAgent(config.KubeMajor, config.KubeMinor))
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("gRPC probe did not respond Ready, got status code: %d", res.StatusCode)
+	}
+
+	// The trailers are only populated once the body was read completely.
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxGRPCHealthResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read gRPC probe response: %w", err)
+	}
+
+	code, ok := pkghttp.GRPCStatus(res.Trailer)
+	if !ok {
+		// A trailers-only response carries the status in the headers.
+		code, ok = pkghttp.GRPCStatus(res.Header)
+	}
+	if !ok {
+		return errors.New("gRPC probe response did not contain a grpc-status")
+	}
+	if code != codes.OK {
+		return fmt.Errorf("gRPC probe failed with status %s", code)
+	}
+
+	status, err := decodeHealthCheckResponse(body)
+	if err != nil {
+		return fmt.Errorf("failed to decode gRPC probe response: %w", err)
+	}
+	if status != healthServing {
+		return fmt.Errorf("gRPC probe did not respond SERVING, got status: %s", status)
+	}
+	return nil
+}
+
+// encodeHealthCheckRequest returns the length-prefixed gRPC message for a
+// grpc.health.v1.HealthCheckRequest for the given service.
+func encodeHealthCheckRequest(service string) []byte {
+	var msg []byte
+	if service != "" {
+		msg = protowire.AppendTag(msg, 1, protowire.BytesType)
+		msg = protowire.AppendString(msg, service)
+	}
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+// decodeHealthCheckResponse returns the status of the length-prefixed
+// grpc.health.v1.HealthCheckResponse message in b.
+func decodeHealthCheckResponse(b []byte) (healthServingStatus, error) {
+	if len(b) < 5 {
+		return healthUnknown, fmt.Errorf("response too short: %d bytes", len(b))
+	}
+	if b[0] != 0 {
+		return healthUnknown, errors.New("compressed responses are not supported")
+	}
+	size := binary.BigEndian.Uint32(b[1:5])
+	b = b[5:]
+	if uint32(len(b)) < size {
+		return healthUnknown, fmt.Errorf("response truncated, want %d bytes, got %d", size, len(b))
+	}
+	b = b[:size]
+
+	status := healthUnknown
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return healthUnknown, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return healthUnknown, protowire.ParseError(n)
+			}
+			status = healthServingStatus(v)
+			b = b[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return healthUnknown, protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return status, nil
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package health
 
 import (
+	"encoding/binary"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -28,6 +30,7 @@ import (
 	"go.uber.org/atomic"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/encoding/protowire"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	network "knative.dev/networking/pkg"
@@ -258,6 +261,131 @@ func TestHTTPProbeResponseErrorFailure(t *testing.T) {
 	}
 }
 
+func TestGRPCProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		handler http.HandlerFunc
+		wantErr bool
+	}{{
+		name:    "serving",
+		handler: grpcHealthHandler(t, "", healthServing),
+	}, {
+		name:    "serving with service name",
+		service: "foo.Bar",
+		handler: grpcHealthHandler(t, "foo.Bar", healthServing),
+	}, {
+		name:    "not serving",
+		handler: grpcHealthHandler(t, "", healthNotServing),
+		wantErr: true,
+	}, {
+		name: "error status",
+		handler: func(w http.ResponseWriter, r *http.Request) {
+			// Trailers-only response.
+			w.Header().Set("Content-Type", "application/grpc")
+			w.Header().Set("Grpc-Status", "12") // UNIMPLEMENTED
+			w.WriteHeader(http.StatusOK)
+		},
+		wantErr: true,
+	}, {
+		name: "not grpc",
+		handler: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newH2cTestServer(t, tc.handler)
+			config := GRPCProbeConfigOptions{
+				Timeout: time.Second,
+				Address: server.Listener.Addr().String(),
+				Service: tc.service,
+			}
+			if err := GRPCProbe(config); (err != nil) != tc.wantErr {
+				t.Errorf("GRPCProbe() = %v, wantErr = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGRPCProbeResponseErrorFailure(t *testing.T) {
+	config := GRPCProbeConfigOptions{
+		Timeout: time.Second,
+		Address: "localhost:0",
+	}
+	if err := GRPCProbe(config); err == nil {
+		t.Error("Expected probe to fail but it succeeded")
+	}
+}
+
+func TestDecodeHealthCheckResponse(t *testing.T) {
+	msg := protowire.AppendTag(nil, 2, protowire.BytesType)
+	msg = protowire.AppendString(msg, "unknown field")
+	msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(healthNotServing))
+
+	got, err := decodeHealthCheckResponse(grpcFrame(msg))
+	if err != nil {
+		t.Fatal("decodeHealthCheckResponse() =", err)
+	}
+	if got != healthNotServing {
+		t.Errorf("Status = %v, want: %v", got, healthNotServing)
+	}
+
+	// The default value of the status is omitted on the wire.
+	if got, err := decodeHealthCheckResponse(grpcFrame(nil)); err != nil || got != healthUnknown {
+		t.Errorf("decodeHealthCheckResponse() = %v, %v, want: %v", got, err, healthUnknown)
+	}
+
+	for _, b := range [][]byte{nil, {0, 0, 0}, {1, 0, 0, 0, 0}, {0, 0, 0, 0, 4, 8}} {
+		if _, err := decodeHealthCheckResponse(b); err == nil {
+			t.Errorf("decodeHealthCheckResponse(%v) succeeded, want an error", b)
+		}
+	}
+}
+
+func grpcFrame(msg []byte) []byte {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+// grpcHealthHandler answers grpc.health.v1.Health/Check calls for the given
+// service with the given status.
+func grpcHealthHandler(t *testing.T, wantService string, status healthServingStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != grpcHealthCheckPath {
+			t.Errorf("Path = %q, want: %q", r.URL.Path, grpcHealthCheckPath)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/grpc" {
+			t.Errorf("Content-Type = %q, want: application/grpc", got)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Failed to read request:", err)
+		}
+		var service string
+		if msg := body[5:]; len(msg) > 0 {
+			_, _, n := protowire.ConsumeTag(msg)
+			service, _ = protowire.ConsumeString(msg[n:])
+		}
+		if service != wantService {
+			t.Errorf("Service = %q, want: %q", service, wantService)
+		}
+
+		msg := protowire.AppendTag(nil, 1, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(status))
+
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		w.Write(grpcFrame(msg))
+		w.Header().Set("Grpc-Status", "0")
+	}
+}
+
 func newH2cTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
 	h2s := &http2.Server{}
 	t.Helper()
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// PathRewriteHeaderName is the header key for the rewriting of the prefix
+// of the request path which the Ingress cannot apply. Its value is the JSON
+// encoding of PathRewrite.
+const PathRewriteHeaderName = "Knative-Serving-Path-Rewrite"
+
+// PathRewrite replaces the prefix of the request path.
+type PathRewrite struct {
+	// Prefix is the prefix of the request path which is replaced.
+	Prefix string `json:"prefix"`
+	// Replacement is the prefix the request path is rewritten to.
+	Replacement string `json:"replacement"`
+}
+
+// rewrite returns the path with its prefix replaced, without doubling the
+// slash between the replacement and the rest of the path.
+func (pr *PathRewrite) rewrite(path string) string {
+	rest := strings.TrimPrefix(path, pr.Prefix)
+	if strings.HasSuffix(pr.Replacement, "/") && strings.HasPrefix(rest, "/") {
+		rest = rest[1:]
+	}
+	if path = pr.Replacement + rest; !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// PathRewriteHandler rewrites the prefix of the request path as the path
+// rewrite header asks, and removes the header.
+func PathRewriteHandler(logger *zap.SugaredLogger, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(PathRewriteHeaderName)
+		// The Ingress clears the header when there is no rewriting, so it is
+		// removed even when empty.
+		r.Header.Del(PathRewriteHeaderName)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		pr := &PathRewrite{}
+		if err := json.Unmarshal([]byte(header), pr); err != nil {
+			logger.Warnw("Ignoring malformed path rewrite", zap.Error(err))
+			next.ServeHTTP(w, r)
+			return
+		}
+		if pr.Prefix == "" || !strings.HasPrefix(r.URL.Path, pr.Prefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r.URL.Path = pr.rewrite(r.URL.Path)
+		// The escaped path no longer matches the path.
+		r.URL.RawPath = ""
+		next.ServeHTTP(w, r)
+	}
+}
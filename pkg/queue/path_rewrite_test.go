@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ktesting "knative.dev/pkg/logging/testing"
+)
+
+func TestPathRewriteHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		rewrite string
+		path    string
+		want    string
+	}{{
+		name: "no rewrite",
+		path: "/billing/invoices",
+		want: "/billing/invoices",
+	}, {
+		name:    "to the root",
+		rewrite: `{"prefix":"/billing","replacement":"/"}`,
+		path:    "/billing/invoices",
+		want:    "/invoices",
+	}, {
+		name:    "the prefix to the root",
+		rewrite: `{"prefix":"/billing","replacement":"/"}`,
+		path:    "/billing",
+		want:    "/",
+	}, {
+		name:    "to another prefix",
+		rewrite: `{"prefix":"/billing/","replacement":"/v2/"}`,
+		path:    "/billing/invoices?page=2",
+		want:    "/v2/invoices",
+	}, {
+		name:    "to an empty prefix",
+		rewrite: `{"prefix":"/billing","replacement":""}`,
+		path:    "/billing/invoices",
+		want:    "/invoices",
+	}, {
+		name:    "other prefix",
+		rewrite: `{"prefix":"/billing","replacement":"/"}`,
+		path:    "/users/me",
+		want:    "/users/me",
+	}, {
+		name:    "malformed",
+		rewrite: `{"prefix":`,
+		path:    "/billing/invoices",
+		want:    "/billing/invoices",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got string
+			h := PathRewriteHandler(ktesting.TestLogger(t), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Path
+				if h, ok := r.Header[PathRewriteHeaderName]; ok {
+					t.Errorf("Path rewrite header = %q, want it removed", h)
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com"+test.path, nil)
+			// The Ingress always sets the header, empty when there is no rewriting.
+			req.Header.Set(PathRewriteHeaderName, test.rewrite)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != test.want {
+				t.Errorf("Path = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
@@ -58,6 +58,21 @@ var (
 	processUptimeGV = newGV(
 		"process_uptime",
 		"The number of seconds that the process has been up")
+
+	queueWaitHV = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "queue_wait_seconds",
+			Help:    "The number of seconds requests waited in the queue before being proxied",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		},
+		metricLabelNames,
+	)
+	queueOverflowCV = newCV(
+		"queue_overflow_requests_total",
+		"Number of requests rejected because the queue was full")
+	queueCancelledCV = newCV(
+		"queue_cancelled_requests_total",
+		"Number of requests cancelled while waiting in the queue")
 )
 
 func newGV(n, h string) *prometheus.GaugeVec {
@@ -67,6 +82,15 @@ func newGV(n, h string) *prometheus.GaugeVec {
 	)
 }
 
+func newCV(n, h string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: n, Help: h},
+		metricLabelNames,
+	)
+}
+
+var _ BreakerReporter = (*PrometheusStatsReporter)(nil)
+
 // PrometheusStatsReporter structure represents a prometheus stats reporter.
 type PrometheusStatsReporter struct {
 	handler   http.Handler
@@ -81,6 +105,10 @@ type PrometheusStatsReporter struct {
 	averageConcurrentRequests        prometheus.Gauge
 	averageProxiedConcurrentRequests prometheus.Gauge
 	processUptime                    prometheus.Gauge
+
+	queueWait      prometheus.Observer
+	queueOverflow  prometheus.Counter
+	queueCancelled prometheus.Counter
 }
 
 // NewPrometheusStatsReporter creates a reporter that collects and reports queue metrics.
@@ -99,11 +127,11 @@ func NewPrometheusStatsReporter(namespace, config, revision, pod string, reporti
 	}
 
 	registry := prometheus.NewRegistry()
-	for _, gv := range []*prometheus.GaugeVec{
+	for _, c := range []prometheus.Collector{
 		requestsPerSecondGV, proxiedRequestsPerSecondGV,
 		averageConcurrentRequestsGV, averageProxiedConcurrentRequestsGV,
-		processUptimeGV} {
-		if err := registry.Register(gv); err != nil {
+		processUptimeGV, queueWaitHV, queueOverflowCV, queueCancelledCV} {
+		if err := registry.Register(c); err != nil {
 			return nil, fmt.Errorf("register metric failed: %w", err)
 		}
 	}
@@ -126,6 +154,10 @@ func NewPrometheusStatsReporter(namespace, config, revision, pod string, reporti
 		averageConcurrentRequests:        averageConcurrentRequestsGV.With(labels),
 		averageProxiedConcurrentRequests: averageProxiedConcurrentRequestsGV.With(labels),
 		processUptime:                    processUptimeGV.With(labels),
+
+		queueWait:      queueWaitHV.With(labels),
+		queueOverflow:  queueOverflowCV.With(labels),
+		queueCancelled: queueCancelledCV.With(labels),
 	}, nil
 }
 
@@ -139,6 +171,21 @@ func (r *PrometheusStatsReporter) Report(stats network.RequestStatsReport) {
 	r.processUptime.Set(time.Since(r.startTime).Seconds())
 }
 
+// ReportQueueWait implements BreakerReporter.
+func (r *PrometheusStatsReporter) ReportQueueWait(d time.Duration) {
+	r.queueWait.Observe(d.Seconds())
+}
+
+// ReportQueueOverflow implements BreakerReporter.
+func (r *PrometheusStatsReporter) ReportQueueOverflow() {
+	r.queueOverflow.Inc()
+}
+
+// ReportQueueCancelled implements BreakerReporter.
+func (r *PrometheusStatsReporter) ReportQueueCancelled() {
+	r.queueCancelled.Inc()
+}
+
 // ServeHTTP serves the stats in prometheus format over HTTP.
 func (r *PrometheusStatsReporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r.handler.ServeHTTP(w, req)
@@ -197,3 +197,69 @@ func getData(t *testing.T, gv *prometheus.GaugeVec) float64 {
 	}
 	return m.Gauge.GetValue()
 }
+
+func TestPrometheusStatsReporterBreaker(t *testing.T) {
+	reporter, err := NewPrometheusStatsReporter(namespace, config, revision, pod, time.Second)
+	if err != nil {
+		t.Fatal("NewPrometheusStatsReporter() =", err)
+	}
+
+	// The metric vectors are shared between reporters, so only compare the deltas.
+	overflow, cancelled := getCounter(t, queueOverflowCV), getCounter(t, queueCancelledCV)
+	waits, waitSum := getHistogram(t)
+
+	reporter.ReportQueueOverflow()
+	reporter.ReportQueueOverflow()
+	reporter.ReportQueueCancelled()
+	reporter.ReportQueueWait(1500 * time.Millisecond)
+
+	if got, want := getCounter(t, queueOverflowCV)-overflow, 2.; got != want {
+		t.Errorf("Overflow count = %v, want: %v", got, want)
+	}
+	if got, want := getCounter(t, queueCancelledCV)-cancelled, 1.; got != want {
+		t.Errorf("Cancelled count = %v, want: %v", got, want)
+	}
+	gotWaits, gotSum := getHistogram(t)
+	if got, want := gotWaits-waits, uint64(1); got != want {
+		t.Errorf("Queue wait samples = %v, want: %v", got, want)
+	}
+	if got, want := gotSum-waitSum, 1.5; got != want {
+		t.Errorf("Queue wait sum = %v, want: %v", got, want)
+	}
+}
+
+func getCounter(t *testing.T, cv *prometheus.CounterVec) float64 {
+	t.Helper()
+	c, err := cv.GetMetricWith(prometheus.Labels{
+		destinationNsLabel:     namespace,
+		destinationConfigLabel: config,
+		destinationRevLabel:    revision,
+		destinationPodLabel:    pod,
+	})
+	if err != nil {
+		t.Fatal("CounterVec.GetMetricWith() error =", err)
+	}
+	m := dto.Metric{}
+	if err := c.Write(&m); err != nil {
+		t.Fatal("Counter.Write() error =", err)
+	}
+	return m.Counter.GetValue()
+}
+
+func getHistogram(t *testing.T) (uint64, float64) {
+	t.Helper()
+	o, err := queueWaitHV.GetMetricWith(prometheus.Labels{
+		destinationNsLabel:     namespace,
+		destinationConfigLabel: config,
+		destinationRevLabel:    revision,
+		destinationPodLabel:    pod,
+	})
+	if err != nil {
+		t.Fatal("HistogramVec.GetMetricWith() error =", err)
+	}
+	m := dto.Metric{}
+	if err := o.(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatal("Histogram.Write() error =", err)
+	}
+	return m.Histogram.GetSampleCount(), m.Histogram.GetSampleSum()
+}
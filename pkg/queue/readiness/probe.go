@@ -19,7 +19,9 @@ package readiness
 import (
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -34,6 +36,10 @@ const (
 	// advantage of the full window
 	PollTimeout   = 10 * time.Second
 	retryInterval = 50 * time.Millisecond
+
+	// grpcProbeHost is the host gRPC probes are sent to, as corev1.GRPCAction
+	// has no host and the user-container shares the network namespace.
+	grpcProbeHost = "127.0.0.1"
 )
 
 // Probe wraps a corev1.Probe along with a count of consecutive, successful probes
@@ -134,6 +140,8 @@ func (p *Probe) probeContainerImpl() bool {
 		err = p.httpProbe()
 	case p.TCPSocket != nil:
 		err = p.tcpProbe()
+	case p.GRPC != nil:
+		err = p.grpcProbe()
 	case p.Exec != nil:
 		// Should never be reachable. Exec probes to be translated to
 		// TCP probes when container is built.
@@ -220,3 +228,20 @@ func (p *Probe) httpProbe() error {
 		return health.HTTPProbe(config)
 	})
 }
+
+// grpcProbe function executes gRPC health check probe once if its standard probe
+// otherwise gRPC probe polls condition function which returns true
+// if the probe count is greater than success threshold and false if gRPC probe fails
+func (p *Probe) grpcProbe() error {
+	config := health.GRPCProbeConfigOptions{
+		Address: net.JoinHostPort(grpcProbeHost, strconv.Itoa(int(p.GRPC.Port))),
+	}
+	if p.GRPC.Service != nil {
+		config.Service = *p.GRPC.Service
+	}
+
+	return p.doProbe(func(to time.Duration) error {
+		config.Timeout = to
+		return health.GRPCProbe(config)
+	})
+}
@@ -51,6 +51,33 @@ func TestParseProbeSuccess(t *testing.T) {
 	}
 }
 
+func TestParseGRPCProbeSuccess(t *testing.T) {
+	service := "foo.Bar"
+	expectedProbe := &corev1.Probe{
+		PeriodSeconds:    1,
+		TimeoutSeconds:   2,
+		SuccessThreshold: 1,
+		FailureThreshold: 1,
+		ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Port:    8080,
+				Service: &service,
+			},
+		},
+	}
+	probeBytes, err := json.Marshal(expectedProbe)
+	if err != nil {
+		t.Fatalf("Failed to decode probe %#v", err)
+	}
+	gotProbe, err := DecodeProbe(string(probeBytes))
+	if err != nil {
+		t.Fatalf("Failed DecodeProbe() %#v", err)
+	}
+	if d := cmp.Diff(gotProbe, expectedProbe); d != "" {
+		t.Errorf("Probe diff %s; got %v, want %v", d, gotProbe, expectedProbe)
+	}
+}
+
 func TestParseProbeFailure(t *testing.T) {
 	probeBytes, err := json.Marshal("wrongProbeObject")
 	if err != nil {
@@ -24,16 +24,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"go.uber.org/atomic"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	network "knative.dev/networking/pkg"
 )
 
 func TestNewProbe(t *testing.T) {
@@ -197,6 +201,75 @@ func TestTCPSuccess(t *testing.T) {
 	}
 }
 
+func TestGRPCSuccess(t *testing.T) {
+	var gotPath, gotUserAgent string
+	server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUserAgent = r.UserAgent()
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		// A HealthCheckResponse with status SERVING.
+		w.Write([]byte{0, 0, 0, 0, 2, 0x08, 0x01})
+		w.Header().Set("Grpc-Status", "0")
+	}), &http2.Server{}))
+	t.Cleanup(server.Close)
+	port, _ := strconv.Atoi(server.URL[strings.LastIndex(server.URL, ":")+1:])
+
+	pb := NewProbe(&corev1.Probe{
+		PeriodSeconds:    1,
+		TimeoutSeconds:   2,
+		SuccessThreshold: 1,
+		FailureThreshold: 1,
+		ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Port: int32(port),
+			},
+		},
+	})
+
+	if !pb.ProbeContainer() {
+		t.Error("Probe report failure. Expected success.")
+	}
+	if want := "/grpc.health.v1.Health/Check"; gotPath != want {
+		t.Errorf("Path = %q, want: %q", gotPath, want)
+	}
+	if !strings.HasPrefix(gotUserAgent, network.KubeProbeUAPrefix) {
+		t.Errorf("User-Agent = %q, want prefix: %q", gotUserAgent, network.KubeProbeUAPrefix)
+	}
+}
+
+func TestGRPCFailureToConnect(t *testing.T) {
+	// Grab a free port and release it so nothing is listening on it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Error setting up tcp listener:", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	var b bytes.Buffer
+	pb := NewProbe(&corev1.Probe{
+		PeriodSeconds:    1,
+		TimeoutSeconds:   1,
+		SuccessThreshold: 1,
+		FailureThreshold: 1,
+		ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Port: int32(port),
+			},
+		},
+	})
+	pb.out = &b
+
+	if pb.ProbeContainer() {
+		t.Error("Reported success when no server was available for connection")
+	}
+	if b.Len() == 0 {
+		t.Error("Want the probe error to be logged")
+	}
+}
+
 func TestHTTPFailureToConnect(t *testing.T) {
 	pb := NewProbe(&corev1.Probe{
 		PeriodSeconds:    1,
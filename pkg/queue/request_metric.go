@@ -59,6 +59,18 @@ var (
 		"queue_depth",
 		"The current number of items in the serving and waiting queue, or not reported if unlimited concurrency.",
 		stats.UnitDimensionless)
+	queueWaitInMsecM = stats.Float64(
+		"queue_wait_latencies",
+		"The time in millisecond requests waited in the queue before being routed to user-container",
+		stats.UnitMilliseconds)
+	queueOverflowCountM = stats.Int64(
+		"queue_overflow_count",
+		"The number of requests rejected because the queue was full",
+		stats.UnitDimensionless)
+	queueCancelledCountM = stats.Int64(
+		"queue_cancelled_count",
+		"The number of requests cancelled while waiting in the queue",
+		stats.UnitDimensionless)
 )
 
 type requestMetricsHandler struct {
@@ -126,7 +138,7 @@ func (h *requestMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			panic(err)
 		}
 		ctx := metrics.AugmentWithResponseAndRouteTag(h.statsCtx,
-			rr.ResponseCode, routeTag)
+			responseCode(r, rr), routeTag)
 		pkgmetrics.RecordBatch(ctx, requestCountM.M(1),
 			responseTimeInMsecM.M(float64(latency.Milliseconds())))
 	}()
@@ -192,13 +204,78 @@ func (h *appRequestMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 			panic(err)
 		}
 
-		ctx := metrics.AugmentWithResponse(h.statsCtx, rr.ResponseCode)
+		ctx := metrics.AugmentWithResponse(h.statsCtx, responseCode(r, rr))
 		pkgmetrics.RecordBatch(ctx, appRequestCountM.M(1),
 			appResponseTimeInMsecM.M(float64(latency.Milliseconds())))
 	}()
 	h.next.ServeHTTP(rr, r)
 }
 
+type breakerMetricsReporter struct {
+	statsCtx context.Context
+}
+
+var _ BreakerReporter = (*breakerMetricsReporter)(nil)
+
+// NewBreakerMetricsReporter creates a BreakerReporter that emits queue metrics.
+func NewBreakerMetricsReporter(ns, service, config, rev, pod string) (BreakerReporter, error) {
+	keys := []tag.Key{metrics.PodKey, metrics.ContainerKey}
+	if err := pkgmetrics.RegisterResourceView(&view.View{
+		Description: "The time in millisecond requests waited in the queue",
+		Measure:     queueWaitInMsecM,
+		Aggregation: defaultLatencyDistribution,
+		TagKeys:     keys,
+	}, &view.View{
+		Description: "The number of requests rejected because the queue was full",
+		Measure:     queueOverflowCountM,
+		Aggregation: view.Count(),
+		TagKeys:     keys,
+	}, &view.View{
+		Description: "The number of requests cancelled while waiting in the queue",
+		Measure:     queueCancelledCountM,
+		Aggregation: view.Count(),
+		TagKeys:     keys,
+	}); err != nil {
+		return nil, err
+	}
+
+	ctx, err := metrics.PodRevisionContext(pod, "queue-proxy", ns, service, config, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return &breakerMetricsReporter{
+		statsCtx: ctx,
+	}, nil
+}
+
+// ReportQueueWait implements BreakerReporter.
+func (r *breakerMetricsReporter) ReportQueueWait(d time.Duration) {
+	pkgmetrics.Record(r.statsCtx, queueWaitInMsecM.M(float64(d)/float64(time.Millisecond)))
+}
+
+// ReportQueueOverflow implements BreakerReporter.
+func (r *breakerMetricsReporter) ReportQueueOverflow() {
+	pkgmetrics.Record(r.statsCtx, queueOverflowCountM.M(1))
+}
+
+// ReportQueueCancelled implements BreakerReporter.
+func (r *breakerMetricsReporter) ReportQueueCancelled() {
+	pkgmetrics.Record(r.statsCtx, queueCancelledCountM.M(1))
+}
+
+// responseCode returns the response code to record for the request. gRPC
+// calls practically always return a 200, so their status is mapped onto the
+// equivalent HTTP status code instead.
+func responseCode(r *http.Request, rr *pkghttp.ResponseRecorder) int {
+	if pkghttp.IsGRPC(r) {
+		if code, ok := pkghttp.GRPCStatus(rr.Header()); ok {
+			return pkghttp.HTTPStatusFromGRPC(code)
+		}
+	}
+	return rr.ResponseCode
+}
+
 const (
 	defaultTagName   = "DEFAULT"
 	undefinedTagName = "UNDEFINED"
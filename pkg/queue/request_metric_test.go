@@ -18,14 +18,19 @@ package queue
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"go.opencensus.io/resource"
+	"k8s.io/apimachinery/pkg/util/wait"
 	network "knative.dev/networking/pkg"
 	"knative.dev/pkg/metrics/metricstest"
 	_ "knative.dev/pkg/metrics/testing"
+	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/metrics"
 )
 
@@ -140,7 +145,70 @@ func reset() {
 	metricstest.Unregister(
 		requestCountM.Name(), appRequestCountM.Name(),
 		responseTimeInMsecM.Name(), appResponseTimeInMsecM.Name(),
-		queueDepthM.Name())
+		queueDepthM.Name(), queueWaitInMsecM.Name(),
+		queueOverflowCountM.Name(), queueCancelledCountM.Name())
+}
+
+func TestBreakerMetricsReporter(t *testing.T) {
+	defer reset()
+	reporter, err := NewBreakerMetricsReporter("ns", "svc", "cfg", "rev", "pod")
+	if err != nil {
+		t.Fatal("Failed to create reporter:", err)
+	}
+
+	breaker := NewBreaker(BreakerParams{
+		QueueDepth:      1,
+		MaxConcurrency:  1,
+		InitialCapacity: 1,
+		Reporters:       []BreakerReporter{reporter},
+	})
+
+	// Occupy the only slot, so the next request queues up and the one
+	// after that overflows.
+	admitted, release := make(chan struct{}), make(chan struct{})
+	go breaker.Maybe(context.Background(), func() {
+		close(admitted)
+		<-release
+	})
+	<-admitted
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error)
+	go func() {
+		queued <- breaker.Maybe(ctx, func() {})
+	}()
+	if err := wait.PollImmediate(time.Millisecond, 5*time.Second, func() (bool, error) {
+		return breaker.InFlight() == 2, nil
+	}); err != nil {
+		t.Fatal("Request never got queued:", err)
+	}
+
+	if err := breaker.Maybe(context.Background(), func() {}); !errors.Is(err, ErrRequestQueueFull) {
+		t.Errorf("Maybe() = %v, want: %v", err, ErrRequestQueueFull)
+	}
+	cancel()
+	if err := <-queued; !errors.Is(err, context.Canceled) {
+		t.Errorf("Maybe() = %v, want: %v", err, context.Canceled)
+	}
+	close(release)
+
+	wantTags := map[string]string{
+		metrics.LabelPodName:       "pod",
+		metrics.LabelContainerName: "queue-proxy",
+	}
+	wantResource := &resource.Resource{
+		Type: "knative_revision",
+		Labels: map[string]string{
+			metrics.LabelNamespaceName:     "ns",
+			metrics.LabelRevisionName:      "rev",
+			metrics.LabelServiceName:       "svc",
+			metrics.LabelConfigurationName: "cfg",
+		},
+	}
+	metricstest.AssertMetric(t,
+		metricstest.DistributionCountOnlyMetric("queue_wait_latencies", 1, wantTags).WithResource(wantResource),
+		metricstest.IntMetric("queue_overflow_count", 1, wantTags).WithResource(wantResource),
+		metricstest.IntMetric("queue_cancelled_count", 1, wantTags).WithResource(wantResource))
 }
 
 func TestRequestMetricsHandlerPanickingHandler(t *testing.T) {
@@ -289,6 +357,45 @@ func TestAppRequestMetricsHandler(t *testing.T) {
 	metricstest.AssertMetric(t, metricstest.DistributionCountOnlyMetric("app_request_latencies", 1, wantTags).WithResource(wantResource))
 }
 
+func TestAppRequestMetricsHandlerGRPC(t *testing.T) {
+	defer reset()
+	baseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", pkghttp.GRPCStatusHeaderName)
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set(pkghttp.GRPCStatusHeaderName, "14")
+	})
+	breaker := NewBreaker(BreakerParams{QueueDepth: 10, MaxConcurrency: 10, InitialCapacity: 10})
+	handler, err := NewAppRequestMetricsHandler(baseHandler, breaker,
+		"ns", "svc", "cfg", "rev", "pod")
+	if err != nil {
+		t.Fatal("Failed to create handler:", err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, targetURI, bytes.NewBufferString("test"))
+	req.ProtoMajor = 2
+	req.Header.Set("Content-Type", "application/grpc")
+	handler.ServeHTTP(resp, req)
+
+	// The UNAVAILABLE status is reported as a 503 even though the HTTP status is 200.
+	wantTags := map[string]string{
+		metrics.LabelPodName:           "pod",
+		metrics.LabelContainerName:     "queue-proxy",
+		metrics.LabelResponseCode:      "503",
+		metrics.LabelResponseCodeClass: "5xx",
+	}
+	wantResource := &resource.Resource{
+		Type: "knative_revision",
+		Labels: map[string]string{
+			metrics.LabelNamespaceName:     "ns",
+			metrics.LabelRevisionName:      "rev",
+			metrics.LabelServiceName:       "svc",
+			metrics.LabelConfigurationName: "cfg",
+		},
+	}
+	metricstest.AssertMetric(t, metricstest.IntMetric("app_request_count", 1, wantTags).WithResource(wantResource))
+}
+
 func BenchmarkRequestMetricsHandler(b *testing.B) {
 	baseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
 	handler, _ := NewRequestMetricsHandler(baseHandler, "ns", "svc", "cfg", "rev", "pod")
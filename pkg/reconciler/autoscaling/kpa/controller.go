@@ -19,11 +19,15 @@ package kpa
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
 	networkingclient "knative.dev/networking/pkg/client/injection/client"
 	sksinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/serverlessservice"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
+	resourcequotainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/resourcequota"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
 	"knative.dev/serving/pkg/client/injection/ducks/autoscaling/v1alpha1/podscalable"
 	metricinformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/metric"
@@ -57,6 +61,7 @@ func NewController(
 	podsInformer := filteredpodinformer.Get(ctx, serving.RevisionUID)
 	metricInformer := metricinformer.Get(ctx)
 	psInformerFactory := podscalable.Get(ctx)
+	quotaInformer := resourcequotainformer.Get(ctx)
 
 	onlyKPAClass := pkgreconciler.AnnotationFilterFunc(
 		autoscaling.ClassAnnotationKey, autoscaling.KPA, false /*allowUnset*/)
@@ -68,8 +73,9 @@ func NewController(
 			SKSLister:        sksInformer.Lister(),
 			MetricLister:     metricInformer.Lister(),
 		},
-		podsLister: podsInformer.Lister(),
-		deciders:   deciders,
+		podsLister:  podsInformer.Lister(),
+		quotaLister: quotaInformer.Lister(),
+		deciders:    deciders,
 	}
 	impl := pareconciler.NewImpl(ctx, c, autoscaling.KPA, func(impl *controller.Impl) controller.Options {
 		logger.Info("Setting up ConfigMap receivers")
@@ -107,6 +113,34 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueLabelOfNamespaceScopedResource("", serving.RevisionLabelKey)),
 	})
 
+	// The quotas may limit the scale of the PAs in their namespace. Their
+	// usage changes with every pod, which enqueues its PA already, so only
+	// the changes of their limits resync the PAs.
+	resyncNamespace := func(obj interface{}) {
+		if quota, ok := obj.(metav1.Object); ok {
+			impl.FilteredGlobalResync(pkgreconciler.ChainFilterFuncs(onlyKPAClass,
+				pkgreconciler.NamespaceFilterFunc(quota.GetNamespace())), paInformer.Informer())
+		}
+	}
+	quotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: resyncNamespace,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			old, ok := oldObj.(*corev1.ResourceQuota)
+			if !ok {
+				return
+			}
+			quota, ok := newObj.(*corev1.ResourceQuota)
+			if !ok {
+				return
+			}
+			if !equality.Semantic.DeepEqual(old.Spec, quota.Spec) ||
+				!equality.Semantic.DeepEqual(old.Status.Hard, quota.Status.Hard) {
+				resyncNamespace(quota)
+			}
+		},
+		DeleteFunc: resyncNamespace,
+	})
+
 	// Have the Deciders enqueue the PAs whose decisions have changed.
 	deciders.Watch(impl.EnqueueKey)
 
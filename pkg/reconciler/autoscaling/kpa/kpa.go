@@ -43,6 +43,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -68,9 +69,10 @@ type podCounts struct {
 type Reconciler struct {
 	*areconciler.Base
 
-	podsLister corev1listers.PodLister
-	deciders   resources.Deciders
-	scaler     *scaler
+	podsLister  corev1listers.PodLister
+	quotaLister corev1listers.ResourceQuotaLister
+	deciders    resources.Deciders
+	scaler      *scaler
 }
 
 // Check that our Reconciler implements the necessary interfaces.
@@ -178,6 +180,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pa *autoscalingv1alpha1.
 	logger.Infof("PA scale got=%d, want=%d, desiredPods=%d ebc=%d", ready, want,
 		decider.Status.DesiredScale, decider.Status.ExcessBurstCapacity)
 
+	if err := c.reconcileQuota(pa, want, ready+notReady); err != nil {
+		return fmt.Errorf("error checking the resource quotas: %w", err)
+	}
+
 	pc := podCounts{
 		want:        int(want),
 		ready:       ready,
@@ -219,6 +225,37 @@ func (c *Reconciler) reconcileDecider(ctx context.Context, pa *autoscalingv1alph
 	return decider, nil
 }
 
+// reconcileQuota marks the PA limited by quota when the headroom of the
+// ResourceQuotas of its namespace admits fewer pods than it scales up to.
+func (c *Reconciler) reconcileQuota(pa *autoscalingv1alpha1.PodAutoscaler, want int32, existing int) error {
+	if int(want) <= existing {
+		pa.Status.ClearScaleTargetLimitedByQuota()
+		return nil
+	}
+
+	quotas, err := c.quotaLister.ResourceQuotas(pa.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	if len(quotas) == 0 {
+		pa.Status.ClearScaleTargetLimitedByQuota()
+		return nil
+	}
+	ps, err := resourceutil.GetScaleResource(pa.Namespace, pa.Spec.ScaleTargetRef, c.scaler.listerFactory)
+	if err != nil {
+		return fmt.Errorf("failed to get scale target %v: %w", pa.Spec.ScaleTargetRef, err)
+	}
+
+	// The usage of the quotas includes the existing pods.
+	pods, quota := resourceutil.PodsWithinQuotas(quotas, &ps.Spec.Template.Spec)
+	if admitted := int64(existing) + pods; pods >= 0 && admitted < int64(want) {
+		pa.Status.MarkScaleTargetLimitedByQuota(quota, int32(admitted), want)
+	} else {
+		pa.Status.ClearScaleTargetLimitedByQuota()
+	}
+	return nil
+}
+
 func computeStatus(ctx context.Context, pa *autoscalingv1alpha1.PodAutoscaler, pc podCounts, logger *zap.SugaredLogger) {
 	pa.Status.DesiredScale, pa.Status.ActualScale = ptr.Int32(int32(pc.want)), ptr.Int32(int32(pc.ready))
 
@@ -128,6 +128,29 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, config *v1.Configuration
 	if err = c.findAndSetLatestReadyRevision(ctx, config); err != nil {
 		return fmt.Errorf("failed to find and set latest ready revision: %w", err)
 	}
+	if err = c.setGCCandidates(config); err != nil {
+		return fmt.Errorf("failed to list the garbage collection candidates: %w", err)
+	}
+	return nil
+}
+
+// setGCCandidates reports the Revisions which the garbage collector, in
+// dry-run mode, annotated as the ones it would delete.
+func (c *Reconciler) setGCCandidates(config *v1.Configuration) error {
+	revs, err := c.revisionLister.Revisions(config.Namespace).List(labels.SelectorFromSet(labels.Set{
+		serving.ConfigurationLabelKey: config.Name,
+	}))
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, rev := range revs {
+		if _, ok := rev.Annotations[serving.RevisionGCCandidateAnnotationKey]; ok {
+			names = append(names, rev.Name)
+		}
+	}
+	sort.Strings(names)
+	config.Status.GCCandidates = names
 	return nil
 }
 
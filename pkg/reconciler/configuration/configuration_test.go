@@ -37,6 +37,7 @@ import (
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	configreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/configuration"
@@ -287,6 +288,24 @@ func TestReconcile(t *testing.T) {
 				WithCreationTimestamp(now), MarkRevisionReady, WithRevName("matching-revision")),
 		},
 		Key: "foo/matching-revision-done-idempotent",
+	}, {
+		Name: "reconcile the revisions annotated as gc candidates",
+		Ctx:  config.ToContext(context.Background(), config.FromContext(testCtx)),
+		Objects: []runtime.Object{
+			cfg("gc-candidates", "foo", 5566,
+				WithConfigObservedGen, WithLatestCreated("gc-candidates-00002"), WithLatestReady("gc-candidates-00002")),
+			rev("gc-candidates", "foo", 5565,
+				WithCreationTimestamp(now), MarkRevisionReady, WithRevName("gc-candidates-00001"),
+				WithRevisionAnn(serving.RevisionGCCandidateAnnotationKey, "it exceeds max-non-active-revisions=0")),
+			rev("gc-candidates", "foo", 5566,
+				WithCreationTimestamp(now), MarkRevisionReady, WithRevName("gc-candidates-00002")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: cfg("gc-candidates", "foo", 5566,
+				WithConfigObservedGen, WithLatestCreated("gc-candidates-00002"), WithLatestReady("gc-candidates-00002"),
+				WithGCCandidates("gc-candidates-00001")),
+		}},
+		Key: "foo/gc-candidates",
 	}, {
 		Name: "reconcile revision matching generation (ready: false)",
 		Ctx:  config.ToContext(context.Background(), config.FromContext(testCtx)),
@@ -22,13 +22,15 @@ import (
 	network "knative.dev/networking/pkg"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
+	apicfg "knative.dev/serving/pkg/apis/config"
 )
 
 type cfgKey struct{}
 
 // Config holds the collection of configurations that we attach to contexts.
 type Config struct {
-	Network *network.Config
+	Network  *network.Config
+	Features *apicfg.Features
 }
 
 // FromContext extracts a Config from the provided context.
@@ -55,7 +57,8 @@ func (s *Store) ToContext(ctx context.Context) context.Context {
 // Load creates a Config from the current config state of the Store.
 func (s *Store) Load() *Config {
 	return &Config{
-		Network: s.UntypedLoad(network.ConfigName).(*network.Config).DeepCopy(),
+		Network:  s.UntypedLoad(network.ConfigName).(*network.Config).DeepCopy(),
+		Features: s.UntypedLoad(apicfg.FeaturesConfigName).(*apicfg.Features).DeepCopy(),
 	}
 }
 
@@ -66,7 +69,8 @@ func NewStore(ctx context.Context, onAfterStore ...func(name string, value inter
 			"domainmapping",
 			logging.FromContext(ctx),
 			configmap.Constructors{
-				network.ConfigName: network.NewConfigFromConfigMap,
+				network.ConfigName:        network.NewConfigFromConfigMap,
+				apicfg.FeaturesConfigName: apicfg.NewFeaturesConfigFromConfigMap,
 			},
 			onAfterStore...,
 		),
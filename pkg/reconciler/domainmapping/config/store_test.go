@@ -24,6 +24,7 @@ import (
 
 	network "knative.dev/networking/pkg"
 	logtesting "knative.dev/pkg/logging/testing"
+	apicfg "knative.dev/serving/pkg/apis/config"
 
 	. "knative.dev/pkg/configmap/testing"
 )
@@ -33,7 +34,9 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store := NewStore(ctx)
 
 	networkConfig := ConfigMapFromTestFile(t, network.ConfigName)
+	featuresConfig := ConfigMapFromTestFile(t, apicfg.FeaturesConfigName)
 	store.OnConfigChanged(networkConfig)
+	store.OnConfigChanged(featuresConfig)
 
 	config := FromContext(store.ToContext(context.Background()))
 
@@ -43,4 +46,11 @@ func TestStoreLoadWithContext(t *testing.T) {
 			t.Errorf("Unexpected network config (-want, +got):\n%v", diff)
 		}
 	})
+
+	t.Run("features", func(t *testing.T) {
+		expected, _ := apicfg.NewFeaturesConfigFromConfigMap(featuresConfig)
+		if diff := cmp.Diff(expected, config.Features); diff != "" {
+			t.Errorf("Unexpected features config (-want, +got):\n%v", diff)
+		}
+	})
 }
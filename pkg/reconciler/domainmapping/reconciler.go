@@ -18,6 +18,7 @@ package domainmapping
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -31,6 +32,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 
 	networkingpkg "knative.dev/networking/pkg"
 	"knative.dev/networking/pkg/apis/networking"
@@ -44,9 +49,13 @@ import (
 	"knative.dev/pkg/network"
 	"knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+	apicfg "knative.dev/serving/pkg/apis/config"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/apis/serving/v1alpha1"
 	domainmappingreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1alpha1/domainmapping"
+	servinglisters "knative.dev/serving/pkg/client/listers/serving/v1alpha1"
 	servingnetworking "knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/reconciler/domainmapping/config"
 	"knative.dev/serving/pkg/reconciler/domainmapping/resources"
@@ -58,8 +67,12 @@ type Reconciler struct {
 	certificateLister networkinglisters.CertificateLister
 	ingressLister     networkinglisters.IngressLister
 	domainClaimLister networkinglisters.ClusterDomainClaimLister
+	grantLister       servinglisters.DomainMappingGrantLister
+	serviceLister     corev1listers.ServiceLister
 	netclient         netclientset.Interface
+	kubeclient        kubernetes.Interface
 	resolver          *resolver.URIResolver
+	tracker           tracker.Interface
 }
 
 // Check that our Reconciler implements Interface
@@ -106,8 +119,8 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1alpha1.DomainMappi
 	}
 
 	// To prevent Ingress hostname collision, require that we can create, or
-	// already own, a cluster-wide domain claim.
-	if err := r.reconcileDomainClaim(ctx, dm); err != nil {
+	// already own, a cluster-wide domain claim for each host.
+	if err := r.reconcileDomainClaims(ctx, dm); err != nil {
 		return err
 	}
 
@@ -117,11 +130,28 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1alpha1.DomainMappi
 	}
 
 	// Resolve the spec.Ref to a URI following the Addressable contract.
-	targetHost, targetBackendSvc, err := r.resolveRef(ctx, dm)
+	target, err := r.resolveRef(ctx, dm)
 	if err != nil {
 		return err
 	}
 
+	// Resolve the Ref of each path, the requests for their prefixes are
+	// routed to them instead.
+	paths, err := r.resolvePaths(ctx, dm)
+	if err != nil {
+		return err
+	}
+
+	// The KIngress reaches the targets of other namespaces, and outside the
+	// cluster, through ExternalName Services.
+	targets := []resources.Target{target}
+	for _, path := range paths {
+		targets = append(targets, path.Target)
+	}
+	if err := r.reconcileExternalServices(ctx, dm, targets); err != nil {
+		return err
+	}
+
 	// HTTPOption can be set via annotations or in the config map.
 	httpOption, err := servingnetworking.GetHTTPOption(ctx, config.FromContext(ctx).Network, dm.GetAnnotations())
 	if err != nil {
@@ -129,8 +159,8 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1alpha1.DomainMappi
 	}
 
 	// Reconcile the Ingress resource corresponding to the requested Mapping.
-	logger.Debugf("Mapping %s to ref %s/%s (host: %q, svc: %q)", url, dm.Spec.Ref.Namespace, dm.Spec.Ref.Name, targetHost, targetBackendSvc)
-	desired := resources.MakeIngress(dm, targetBackendSvc, targetHost, ingressClass, httpOption, tls, acmeChallenges...)
+	logger.Debugf("Mapping %s to ref %s/%s (host: %q, svc: %q)", url, dm.Spec.Ref.Namespace, dm.Spec.Ref.Name, target.HostName, target.BackendServiceName)
+	desired := resources.MakeIngress(dm, target, paths, ingressClass, httpOption, tls, acmeChallenges...)
 	ingress, err := r.reconcileIngress(ctx, dm, desired)
 	if err != nil {
 		return err
@@ -146,14 +176,23 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1alpha1.DomainMappi
 	return err
 }
 
-// FinalizeKind cleans up the ClusterDomainClaim created by the DomainMapping.
+// FinalizeKind cleans up the ClusterDomainClaims created by the DomainMapping.
 func (r *Reconciler) FinalizeKind(ctx context.Context, dm *v1alpha1.DomainMapping) reconciler.Event {
 	if !config.FromContext(ctx).Network.AutocreateClusterDomainClaims {
 		// If we're not responsible for creating domain claims, we're not responsible for cleaning them up.
 		return nil
 	}
 
-	dc, err := r.domainClaimLister.Get(dm.Name)
+	for _, host := range resources.Hosts(dm) {
+		if err := r.deleteDomainClaim(ctx, dm, resources.DomainClaimName(host)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteDomainClaim(ctx context.Context, dm *v1alpha1.DomainMapping, name string) error {
+	dc, err := r.domainClaimLister.Get(name)
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			// Nothing to do since the domain was never claimed.
@@ -169,7 +208,11 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, dm *v1alpha1.DomainMappin
 		return nil
 	}
 
-	return r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Delete(ctx, dm.Name, metav1.DeleteOptions{})
+	err = r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	return err
 }
 
 func autoTLSEnabled(ctx context.Context, dm *v1alpha1.DomainMapping) bool {
@@ -198,7 +241,7 @@ func (r *Reconciler) tls(ctx context.Context, dm *v1alpha1.DomainMapping) ([]net
 		dm.Status.MarkCertificateNotRequired(v1alpha1.TLSCertificateProvidedExternally)
 		dm.Status.URL.Scheme = "https"
 		return []netv1alpha1.IngressTLS{{
-			Hosts:           []string{dm.Name},
+			Hosts:           resources.Hosts(dm),
 			SecretName:      dm.Spec.TLS.SecretName,
 			SecretNamespace: dm.Namespace,
 		}}, nil, nil
@@ -209,6 +252,16 @@ func (r *Reconciler) tls(ctx context.Context, dm *v1alpha1.DomainMapping) ([]net
 		return nil, nil, nil
 	}
 
+	// HTTP-01 challenges cannot validate wildcard hosts.
+	if f := config.FromContext(ctx).Features; f == nil || f.WildcardCertificates != apicfg.Enabled {
+		for _, host := range dm.Spec.Hosts {
+			if strings.HasPrefix(host, "*.") {
+				dm.Status.MarkWildcardCertificateNotSupported(host)
+				return nil, nil, fmt.Errorf("wildcard host %q requires the wildcard-certificates feature", host)
+			}
+		}
+	}
+
 	acmeChallenges := []netv1alpha1.HTTP01Challenge{}
 	desiredCert := resources.MakeCertificate(dm, certClass(ctx))
 	cert, err := networkaccessor.ReconcileCertificate(ctx, dm, desiredCert, r)
@@ -278,72 +331,360 @@ func (r *Reconciler) reconcileIngress(ctx context.Context, dm *v1alpha1.DomainMa
 	return ingress, err
 }
 
-func (r *Reconciler) resolveRef(ctx context.Context, dm *v1alpha1.DomainMapping) (host, backendSvc string, err error) {
-	resolved, err := r.resolver.URIFromKReference(ctx, &dm.Spec.Ref, dm)
+// resolveRef resolves the target of the DomainMapping, its Ref or its URL.
+func (r *Reconciler) resolveRef(ctx context.Context, dm *v1alpha1.DomainMapping) (resources.Target, error) {
+	if dm.Spec.URL != nil {
+		if err := r.checkClusterURL(dm, dm.Spec.URL); err != nil {
+			return resources.Target{}, err
+		}
+		dm.Status.MarkReferenceResolved()
+		return externalTarget(dm, "", dm.Spec.URL), nil
+	}
+
+	_, target, err := r.resolveTarget(ctx, dm, "", &dm.Spec.Ref, dm.Status.MarkReferenceNotResolved)
+	if err != nil {
+		return resources.Target{}, err
+	}
+
+	dm.Status.MarkReferenceResolved()
+	return target, nil
+}
+
+// resolvePaths resolves the Ref of each path of the DomainMapping, and reports
+// the resolution of each in the status.
+func (r *Reconciler) resolvePaths(ctx context.Context, dm *v1alpha1.DomainMapping) ([]resources.PathTarget, error) {
+	dm.Status.Paths = nil
+	targets := make([]resources.PathTarget, 0, len(dm.Spec.Paths))
+	var failed []string
+	for _, path := range dm.Spec.Paths {
+		status := v1alpha1.DomainMappingPathStatus{Prefix: path.Prefix}
+		resolved, target, err := r.resolveTarget(ctx, dm, path.Prefix, &path.Ref, func(reason string) {
+			status.Message = reason
+		})
+		if err != nil {
+			failed = append(failed, path.Prefix)
+		} else {
+			status.URL = resolved
+			status.ReferenceResolved = true
+			targets = append(targets, resources.PathTarget{
+				Target:        target,
+				Prefix:        path.Prefix,
+				RewritePrefix: path.RewritePrefix,
+			})
+		}
+		dm.Status.Paths = append(dm.Status.Paths, status)
+	}
+
+	if len(failed) > 0 {
+		reason := fmt.Sprintf("failed to resolve the references of the paths %s", strings.Join(failed, ", "))
+		dm.Status.MarkReferenceNotResolved(reason)
+		return nil, errors.New(reason)
+	}
+	return targets, nil
+}
+
+// resolveTarget resolves the ref of the path with the prefix to a URI
+// following the Addressable contract, and returns the URI and the target the
+// Ingress routes the requests to. It reports why it failed to markNotResolved.
+func (r *Reconciler) resolveTarget(ctx context.Context, dm *v1alpha1.DomainMapping, prefix string, ref *duckv1.KReference, markNotResolved func(string)) (*apis.URL, resources.Target, error) {
+	// The objects of other namespaces may only be referenced, or even
+	// resolved, when a DomainMappingGrant of their namespace allows it.
+	if ref.Namespace != dm.Namespace {
+		allowed, err := r.isGranted(dm, ref)
+		if err != nil {
+			return nil, resources.Target{}, err
+		}
+		if !allowed {
+			reason := fmt.Sprintf("no DomainMappingGrant of namespace %q allows the reference to %s %q", ref.Namespace, ref.Kind, ref.Name)
+			markNotResolved(reason)
+			return nil, resources.Target{}, errors.New(reason)
+		}
+	}
+
+	resolved, err := r.resolver.URIFromKReference(ctx, ref, dm)
 	if err != nil {
-		dm.Status.MarkReferenceNotResolved(err.Error())
-		return "", "", fmt.Errorf("resolving reference: %w", err)
+		markNotResolved(err.Error())
+		return nil, resources.Target{}, fmt.Errorf("resolving reference: %w", err)
 	}
 
-	// Since we do not support path-based routing in domain mappings, we cannot
-	// support target references that contain a path.
+	// Since the Ingress cannot route the requests to a path of the target, we
+	// cannot support target references that contain a path.
 	if strings.TrimSuffix(resolved.Path, "/") != "" {
-		dm.Status.MarkReferenceNotResolved(fmt.Sprintf("resolved URI %q contains a path", resolved))
-		return "", "", fmt.Errorf("resolved URI %q contains a path", resolved)
+		markNotResolved(fmt.Sprintf("resolved URI %q contains a path", resolved))
+		return nil, resources.Target{}, fmt.Errorf("resolved URI %q contains a path", resolved)
 	}
 
-	// The resolved hostname must be of the form {name}.{namespace}.svc.{suffix},
-	// which is the standard DNS address given by kubernetes to services. We will
-	// use `name` from this resolved hostname to determine the backend service
-	// name for the KIngress.
-	// TODO(julz) in the future we may support addressables that are not created
-	// from Services, in which case we would need to dynamically create the
-	// an ExternalName Service for the KIngress to use.
+	// When the resolved hostname is of the form {name}.{namespace}.svc.{suffix},
+	// which is the standard DNS address given by kubernetes to services, and
+	// the namespace is the namespace of the DomainMapping, we use `name` as the
+	// backend service name for the KIngress.
 	requiredSuffix := ".svc." + network.GetClusterDomainName()
 	parts := strings.Split(strings.TrimSuffix(resolved.Host, requiredSuffix), ".")
-	if !strings.HasSuffix(resolved.Host, requiredSuffix) || len(parts) != 2 {
-		dm.Status.MarkReferenceNotResolved(fmt.Sprintf("resolved URI %q must be of the form {name}.{namespace}%s", resolved, requiredSuffix))
-		return "", "", fmt.Errorf("resolved URI %q must be of the form {name}.{namespace}%s", resolved, requiredSuffix)
+	if strings.HasSuffix(resolved.Host, requiredSuffix) && len(parts) == 2 && parts[1] == dm.Namespace {
+		return resolved, resources.Target{
+			BackendServiceName: parts[0],
+			HostName:           resolved.Host,
+		}, nil
 	}
 
-	// If the namespace part of the target isn't the same as the DomainMapping
-	// we'd need to create a cross-namespace KIngress, which isn't supported.
-	if parts[1] != dm.Namespace {
-		dm.Status.MarkReferenceNotResolved(fmt.Sprintf("resolved URI %q must be in same namespace as DomainMapping", resolved))
-		return "", "", fmt.Errorf("resolved URI %q must be in same namespace as DomainMapping", resolved)
+	// Otherwise, as KIngress does not support cross-namespace backends, the
+	// KIngress reaches the target through an ExternalName Service.
+	return resolved, externalTarget(dm, prefix, resolved), nil
+}
+
+// externalTarget returns the target reached through the ExternalName Service
+// of the path with the prefix, which points to the host of the URL.
+func externalTarget(dm *v1alpha1.DomainMapping, prefix string, url *apis.URL) resources.Target {
+	port := 80
+	if p, err := strconv.Atoi(url.URL().Port()); err == nil {
+		port = p
 	}
+	return resources.Target{
+		BackendServiceName: resources.ExternalServiceName(dm, prefix),
+		ServicePort:        port,
+		HostName:           url.Host,
+		ExternalName:       url.URL().Hostname(),
+	}
+}
 
-	dm.Status.MarkReferenceResolved()
-	return resolved.Host, parts[0], nil
+// checkClusterURL makes sure that the URL, when it names a Service of another
+// namespace, is allowed by a DomainMappingGrant of the namespace, as the
+// reference to the Service would be.
+func (r *Reconciler) checkClusterURL(dm *v1alpha1.DomainMapping, url *apis.URL) error {
+	ref, err := r.clusterService(url.URL().Hostname())
+	if err != nil || ref == nil || ref.Namespace == dm.Namespace {
+		return err
+	}
+	allowed, err := r.isGranted(dm, ref)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		reason := fmt.Sprintf("no DomainMappingGrant of namespace %q allows the URL %q of Service %q", ref.Namespace, url, ref.Name)
+		dm.Status.MarkReferenceNotResolved(reason)
+		return errors.New(reason)
+	}
+	return nil
 }
 
-func (r *Reconciler) reconcileDomainClaim(ctx context.Context, dm *v1alpha1.DomainMapping) error {
-	dc, err := r.domainClaimLister.Get(dm.Name)
+// clusterService returns the reference to the Service of the cluster the host
+// names, if any: {name}.{namespace}.svc, possibly followed by the cluster
+// domain, or {name}.{namespace} when the Service exists, since the search
+// domains of the cluster resolve it before the external domain.
+func (r *Reconciler) clusterService(host string) (*duckv1.KReference, error) {
+	svc := false
+	for _, suffix := range []string{".svc." + network.GetClusterDomainName(), ".svc"} {
+		if strings.HasSuffix(host, suffix) {
+			host, svc = strings.TrimSuffix(host, suffix), true
+			break
+		}
+	}
+	parts := strings.Split(host, ".")
+	if len(parts) != 2 {
+		return nil, nil
+	}
+	ref := &duckv1.KReference{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Namespace:  parts[1],
+		Name:       parts[0],
+	}
+	if !svc {
+		if _, err := r.serviceLister.Services(ref.Namespace).Get(ref.Name); apierrs.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return ref, nil
+}
+
+// isGranted returns whether a DomainMappingGrant of the namespace of the ref
+// allows the DomainMapping to reference it. The DomainMapping is reconciled
+// again when the grants of the namespace change.
+func (r *Reconciler) isGranted(dm *v1alpha1.DomainMapping, ref *duckv1.KReference) (bool, error) {
+	if err := r.tracker.TrackReference(tracker.Reference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "DomainMappingGrant",
+		Namespace:  ref.Namespace,
+		Selector:   &metav1.LabelSelector{},
+	}, dm); err != nil {
+		return false, err
+	}
+
+	grants, err := r.grantLister.DomainMappingGrants(ref.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list DomainMappingGrants: %w", err)
+	}
+	for _, grant := range grants {
+		if grant.Allows(dm.Namespace, ref) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reconcileExternalServices reconciles the ExternalName Services of the
+// targets the Ingress cannot reach directly, and deletes the others.
+func (r *Reconciler) reconcileExternalServices(ctx context.Context, dm *v1alpha1.DomainMapping, targets []resources.Target) error {
+	desired := make(map[string]*corev1.Service, len(targets))
+	for _, target := range targets {
+		if target.ExternalName != "" {
+			desired[target.BackendServiceName] = resources.MakeExternalService(dm, target.BackendServiceName, target.ExternalName, target.ServicePort)
+		}
+	}
+
+	for _, svc := range desired {
+		if err := r.reconcileExternalService(ctx, dm, svc); err != nil {
+			return err
+		}
+	}
+
+	existing, err := r.serviceLister.Services(dm.Namespace).List(labels.SelectorFromSet(labels.Set{
+		serving.DomainMappingUIDLabelKey: string(dm.UID),
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to list Services: %w", err)
+	}
+	for _, svc := range existing {
+		if _, ok := desired[svc.Name]; ok || !metav1.IsControlledBy(svc, dm) {
+			continue
+		}
+		if err := r.kubeclient.CoreV1().Services(svc.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Service: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileExternalService(ctx context.Context, dm *v1alpha1.DomainMapping, desired *corev1.Service) error {
+	recorder := controller.GetEventRecorder(ctx)
+	svc, err := r.serviceLister.Services(desired.Namespace).Get(desired.Name)
+	if apierrs.IsNotFound(err) {
+		if _, err := r.kubeclient.CoreV1().Services(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{}); err != nil {
+			recorder.Eventf(dm, corev1.EventTypeWarning, "CreationFailed", "Failed to create Service: %v", err)
+			return fmt.Errorf("failed to create Service: %w", err)
+		}
+		recorder.Eventf(dm, corev1.EventTypeNormal, "Created", "Created Service %q", desired.Name)
+		return nil
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(svc, dm) {
+		dm.Status.MarkReferenceNotResolved(fmt.Sprintf("There is an existing Service %s that we don't own.", desired.Name))
+		return fmt.Errorf("domain mapping: %q does not own Service: %q", dm.Name, desired.Name)
+	} else if !equality.Semantic.DeepEqual(svc.Spec, desired.Spec) {
+		// Don't modify the informers copy
+		origin := svc.DeepCopy()
+		origin.Spec = desired.Spec
+		if _, err := r.kubeclient.CoreV1().Services(origin.Namespace).Update(ctx, origin, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update Service: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileDomainClaims(ctx context.Context, dm *v1alpha1.DomainMapping) error {
+	for _, host := range resources.Hosts(dm) {
+		if err := r.reconcileDomainClaim(ctx, dm, host); err != nil {
+			return err
+		}
+	}
+	if err := r.releaseDomainClaims(ctx, dm); err != nil {
+		return err
+	}
+
+	dm.Status.MarkDomainClaimed()
+	return nil
+}
+
+func (r *Reconciler) reconcileDomainClaim(ctx context.Context, dm *v1alpha1.DomainMapping, host string) error {
+	if err := r.checkClaimOverlap(dm, host); err != nil {
+		return err
+	}
+
+	dc, err := r.domainClaimLister.Get(resources.DomainClaimName(host))
 	if err != nil && !apierrs.IsNotFound(err) {
 		return fmt.Errorf("failed to get ClusterDomainClaim: %w", err)
 	} else if apierrs.IsNotFound(err) {
-		if err := r.createDomainClaim(ctx, dm); err != nil {
+		if err := r.createDomainClaim(ctx, dm, host); err != nil {
 			return err
 		}
 	} else if dm.Namespace != dc.Spec.Namespace {
 		dm.Status.MarkDomainClaimNotOwned()
-		return fmt.Errorf("namespace %q does not own ClusterDomainClaim for %q", dm.Namespace, dm.Name)
+		return fmt.Errorf("namespace %q does not own ClusterDomainClaim for %q", dm.Namespace, host)
 	}
+	return nil
+}
 
-	dm.Status.MarkDomainClaimed()
+// checkClaimOverlap checks that the host does not overlap the wildcard hosts
+// claimed by other namespaces and, if it is a wildcard host itself, the
+// hosts claimed by other namespaces.
+func (r *Reconciler) checkClaimOverlap(dm *v1alpha1.DomainMapping, host string) error {
+	domain, wildcard := resources.WildcardDomain(resources.DomainClaimName(host))
+	dcs, err := r.domainClaimLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterDomainClaims: %w", err)
+	}
+	for _, dc := range dcs {
+		if dc.Spec.Namespace == dm.Namespace {
+			continue
+		}
+		other, otherWildcard := resources.WildcardDomain(dc.Name)
+		var overlaps bool
+		switch {
+		case wildcard && otherWildcard:
+			overlaps = other == domain || strings.HasSuffix(domain, "."+other) || strings.HasSuffix(other, "."+domain)
+		case wildcard:
+			overlaps = strings.HasSuffix(dc.Name, "."+domain)
+		case otherWildcard:
+			overlaps = strings.HasSuffix(host, "."+other)
+		}
+		if overlaps {
+			dm.Status.MarkDomainClaimNotOwned()
+			return fmt.Errorf("host %q overlaps the ClusterDomainClaim %q of namespace %q", host, dc.Name, dc.Spec.Namespace)
+		}
+	}
 	return nil
 }
 
-func (r *Reconciler) createDomainClaim(ctx context.Context, dm *v1alpha1.DomainMapping) error {
+func (r *Reconciler) createDomainClaim(ctx context.Context, dm *v1alpha1.DomainMapping, host string) error {
 	if !config.FromContext(ctx).Network.AutocreateClusterDomainClaims {
 		dm.Status.MarkDomainClaimNotOwned()
-		return fmt.Errorf("no ClusterDomainClaim found for domain %q (and autocreate-cluster-domain-claims property is not true)", dm.Name)
+		return fmt.Errorf("no ClusterDomainClaim found for domain %q (and autocreate-cluster-domain-claims property is not true)", host)
 	}
 
-	_, err := r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Create(ctx, resources.MakeDomainClaim(dm), metav1.CreateOptions{})
+	_, err := r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Create(ctx, resources.MakeHostDomainClaim(dm, host), metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to create ClusterDomainClaim: %w", err)
 	}
 
 	return nil
 }
+
+// releaseDomainClaims deletes the ClusterDomainClaims created for the hosts
+// the DomainMapping no longer maps.
+func (r *Reconciler) releaseDomainClaims(ctx context.Context, dm *v1alpha1.DomainMapping) error {
+	if !config.FromContext(ctx).Network.AutocreateClusterDomainClaims {
+		return nil
+	}
+
+	dcs, err := r.domainClaimLister.List(labels.SelectorFromSet(labels.Set{
+		serving.DomainMappingUIDLabelKey: string(dm.UID),
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterDomainClaims: %w", err)
+	}
+	claimed := sets.NewString()
+	for _, host := range resources.Hosts(dm) {
+		claimed.Insert(resources.DomainClaimName(host))
+	}
+	for _, dc := range dcs {
+		if claimed.Has(dc.Name) {
+			continue
+		}
+		if err := r.deleteDomainClaim(ctx, dm, dc.Name); err != nil {
+			return fmt.Errorf("failed to delete ClusterDomainClaim: %w", err)
+		}
+	}
+	return nil
+}
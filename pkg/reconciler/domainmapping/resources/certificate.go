@@ -31,6 +31,9 @@ import (
 // from the caller.
 func MakeCertificate(dm *v1alpha1.DomainMapping, certClass string) *networkingv1alpha1.Certificate {
 	certName := kmeta.ChildName(dm.GetName(), "")
-	return routeresources.MakeCertificate(
+	cert := routeresources.MakeCertificate(
 		dm, serving.DomainMappingUIDLabelKey, dm.Name, certName, certClass)
+	// The certificate covers all the hosts, wildcards included.
+	cert.Spec.DNSNames = Hosts(dm)
+	return cert
 }
@@ -105,6 +105,38 @@ func TestMakeCertificate(t *testing.T) {
 				SecretName: "mapping.com",
 			},
 		},
+	}, {
+		name: "hosts",
+		dm: v1alpha1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+			},
+			Spec: v1alpha1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+				Hosts: []string{"*.mapping.com"},
+			},
+		},
+		want: networkingv1alpha1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mapping.com",
+				Namespace:   "the-namespace",
+				Annotations: map[string]string{networking.CertificateClassAnnotationKey: certClass},
+				Labels: map[string]string{
+					serving.DomainMappingUIDLabelKey: "mapping.com",
+				},
+			},
+			Spec: networkingv1alpha1.CertificateSpec{
+				DNSNames: []string{
+					"mapping.com",
+					"*.mapping.com",
+				},
+				SecretName: "mapping.com",
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.want.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(&tc.dm)}
@@ -17,17 +17,61 @@ limitations under the License.
 package resources
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/apis/serving/v1alpha1"
 )
 
+// WildcardClaimPrefix replaces the `*.` of a wildcard host in the name of its
+// ClusterDomainClaim, as object names may not contain `*`. Its label has
+// hyphens in its third and fourth positions, which IDNA reserves and the
+// hosts of DomainMappings may not have, so the claim of a wildcard host
+// never collides with the claim of an exact host.
+const WildcardClaimPrefix = "wc--wildcard."
+
+// Hosts returns the hosts mapped by the DomainMapping: its name, followed by
+// its additional hosts.
+func Hosts(dm *v1alpha1.DomainMapping) []string {
+	return append([]string{dm.Name}, dm.Spec.Hosts...)
+}
+
+// DomainClaimName returns the name of the ClusterDomainClaim of the host.
+func DomainClaimName(host string) string {
+	if strings.HasPrefix(host, "*.") {
+		return WildcardClaimPrefix + strings.TrimPrefix(host, "*.")
+	}
+	return host
+}
+
+// WildcardDomain returns the domain whose subdomains are claimed by the
+// ClusterDomainClaim with the given name, if it claims a wildcard host.
+func WildcardDomain(claimName string) (string, bool) {
+	if !strings.HasPrefix(claimName, WildcardClaimPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(claimName, WildcardClaimPrefix), true
+}
+
 // MakeDomainClaim creates a ClusterDomainClaim named after the given DomainMapping
 // and giving ownership of the domain name to the DomainMapping's namespace.
 func MakeDomainClaim(dm *v1alpha1.DomainMapping) *netv1alpha1.ClusterDomainClaim {
+	return MakeHostDomainClaim(dm, dm.Name)
+}
+
+// MakeHostDomainClaim creates the ClusterDomainClaim of one of the hosts of
+// the given DomainMapping, giving ownership of the host to the
+// DomainMapping's namespace. The claim is labeled with the DomainMapping, so
+// that it is released once the DomainMapping no longer maps the host.
+func MakeHostDomainClaim(dm *v1alpha1.DomainMapping, host string) *netv1alpha1.ClusterDomainClaim {
 	return &netv1alpha1.ClusterDomainClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: dm.Name,
+			Name: DomainClaimName(host),
+			Labels: map[string]string{
+				serving.DomainMappingUIDLabelKey: string(dm.UID),
+			},
 		},
 		Spec: netv1alpha1.ClusterDomainClaimSpec{
 			Namespace: dm.Namespace,
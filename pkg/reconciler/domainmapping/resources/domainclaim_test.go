@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/apis/serving/v1alpha1"
 )
 
@@ -30,12 +31,16 @@ func TestMakeDomainClaim(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "mapping.com",
 			Namespace: "the-namespace",
+			UID:       "the-uid",
 		},
 	})
 
 	want := &netv1alpha1.ClusterDomainClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "mapping.com",
+			Labels: map[string]string{
+				serving.DomainMappingUIDLabelKey: "the-uid",
+			},
 		},
 		Spec: netv1alpha1.ClusterDomainClaimSpec{
 			Namespace: "the-namespace",
@@ -46,3 +51,35 @@ func TestMakeDomainClaim(t *testing.T) {
 		t.Errorf("Unexpected DomainClaim (-want, +got):\n%s", cmp.Diff(want, got))
 	}
 }
+
+func TestMakeHostDomainClaim(t *testing.T) {
+	got := MakeHostDomainClaim(&v1alpha1.DomainMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "customer.example.com",
+			Namespace: "the-namespace",
+			UID:       "the-uid",
+		},
+	}, "*.customer.example.com")
+
+	want := &netv1alpha1.ClusterDomainClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "wc--wildcard.customer.example.com",
+			Labels: map[string]string{
+				serving.DomainMappingUIDLabelKey: "the-uid",
+			},
+		},
+		Spec: netv1alpha1.ClusterDomainClaimSpec{
+			Namespace: "the-namespace",
+		},
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Errorf("Unexpected DomainClaim (-want, +got):\n%s", cmp.Diff(want, got))
+	}
+	if domain, ok := WildcardDomain(got.Name); !ok || domain != "customer.example.com" {
+		t.Errorf("WildcardDomain(%q) = %q, %t, want: customer.example.com, true", got.Name, domain, ok)
+	}
+	if _, ok := WildcardDomain("www.customer.example.com"); ok {
+		t.Error("WildcardDomain(www.customer.example.com) = true, want false")
+	}
+}
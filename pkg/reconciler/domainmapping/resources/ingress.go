@@ -17,6 +17,10 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
+	"sort"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -26,15 +30,42 @@ import (
 	"knative.dev/pkg/kmeta"
 	"knative.dev/serving/pkg/apis/serving"
 	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
+	"knative.dev/serving/pkg/queue"
 	routeresources "knative.dev/serving/pkg/reconciler/route/resources"
 )
 
+// Target is the resolved target of a DomainMapping or of one of its paths.
+type Target struct {
+	// BackendServiceName is the name of the Service of the target.
+	BackendServiceName string
+	// ServicePort is the port of the Service, 80 when unset.
+	ServicePort int
+	// HostName is the host the requests are rewritten to.
+	HostName string
+	// ExternalName is the host the ExternalName Service of the target points
+	// to, when the Ingress cannot reach the target through a Service of the
+	// namespace of the DomainMapping.
+	ExternalName string
+}
+
+// PathTarget is the resolved target of a path of a DomainMapping.
+type PathTarget struct {
+	Target
+	// Prefix is the prefix of the request paths routed to the target.
+	Prefix string
+	// RewritePrefix replaces the prefix of the request paths, if set.
+	RewritePrefix *string
+}
+
 // MakeIngress creates an Ingress object for a DomainMapping.  The Ingress is
 // always created in the same namespace as the DomainMapping, and the ingress
 // backend is always in the same namespace also (as this is required by
 // KIngress).  The created ingress will contain a RewriteHost rule to cause the
-// given hostName to be used as the host.
-func MakeIngress(dm *servingv1alpha1.DomainMapping, backendServiceName, hostName, ingressClass string, httpOption netv1alpha1.HTTPOption, tls []netv1alpha1.IngressTLS, acmeChallenges ...netv1alpha1.HTTP01Challenge) *netv1alpha1.Ingress {
+// host name of the target to be used as the host, for each host of the
+// DomainMapping.
+// The requests for the prefixes of the paths are routed to their targets
+// instead.
+func MakeIngress(dm *servingv1alpha1.DomainMapping, target Target, paths []PathTarget, ingressClass string, httpOption netv1alpha1.HTTPOption, tls []netv1alpha1.IngressTLS, acmeChallenges ...netv1alpha1.HTTP01Challenge) *netv1alpha1.Ingress {
 	return &netv1alpha1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      kmeta.ChildName(dm.GetName(), ""),
@@ -51,28 +82,79 @@ func MakeIngress(dm *servingv1alpha1.DomainMapping, backendServiceName, hostName
 		Spec: netv1alpha1.IngressSpec{
 			HTTPOption: httpOption,
 			TLS:        tls,
-			Rules: []netv1alpha1.IngressRule{{
-				Hosts:      []string{dm.Name},
-				Visibility: netv1alpha1.IngressVisibilityExternalIP,
-				HTTP: &netv1alpha1.HTTPIngressRuleValue{
-					// The order of the paths is sensitive, always put tls challenge first
-					Paths: append(routeresources.MakeACMEIngressPaths(acmeChallenges, dm.GetName()),
-						[]netv1alpha1.HTTPIngressPath{{
-							RewriteHost: hostName,
-							Splits: []netv1alpha1.IngressBackendSplit{{
-								Percent: 100,
-								AppendHeaders: map[string]string{
-									network.OriginalHostHeader: dm.Name,
-								},
-								IngressBackend: netv1alpha1.IngressBackend{
-									ServiceNamespace: dm.Namespace,
-									ServiceName:      backendServiceName,
-									ServicePort:      intstr.FromInt(80),
-								},
-							}},
-						}}...),
-				},
-			}},
+			Rules:      makeRules(dm, target, paths, acmeChallenges),
 		},
 	}
 }
+
+// makeRules makes a rule for each host of the DomainMapping. The requests of
+// an exact host carry it as their original host, the requests of a wildcard
+// host cannot.
+func makeRules(dm *servingv1alpha1.DomainMapping, target Target, paths []PathTarget, acmeChallenges []netv1alpha1.HTTP01Challenge) []netv1alpha1.IngressRule {
+	// The longest matching prefix wins, so longer prefixes come first.
+	paths = append([]PathTarget(nil), paths...)
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i].Prefix) != len(paths[j].Prefix) {
+			return len(paths[i].Prefix) > len(paths[j].Prefix)
+		}
+		return paths[i].Prefix < paths[j].Prefix
+	})
+
+	hosts := Hosts(dm)
+	rules := make([]netv1alpha1.IngressRule, 0, len(hosts))
+	for _, host := range hosts {
+		originalHost := host
+		if strings.HasPrefix(host, "*.") {
+			originalHost = ""
+		}
+		// The order of the paths is sensitive, always put tls challenge first
+		httpPaths := routeresources.MakeACMEIngressPaths(acmeChallenges, host)
+		for _, path := range paths {
+			httpPaths = append(httpPaths, makeIngressPath(dm.Namespace, path, originalHost))
+		}
+		rules = append(rules, netv1alpha1.IngressRule{
+			Hosts:      []string{host},
+			Visibility: netv1alpha1.IngressVisibilityExternalIP,
+			HTTP: &netv1alpha1.HTTPIngressRuleValue{
+				Paths: append(httpPaths, makeIngressPath(dm.Namespace, PathTarget{Target: target}, originalHost)),
+			},
+		})
+	}
+	return rules
+}
+
+// makeIngressPath makes a path routing the requests to the target, with the
+// headers carrying the original host, if any, and the rewriting of the
+// prefix, if any. The target without a prefix catches all the requests.
+func makeIngressPath(ns string, target PathTarget, originalHost string) netv1alpha1.HTTPIngressPath {
+	// The path rewrite header is always set, and cleared when there is no
+	// rewriting, so the clients cannot rewrite the paths themselves.
+	headers := map[string]string{
+		queue.PathRewriteHeaderName: "",
+	}
+	if originalHost != "" {
+		headers[network.OriginalHostHeader] = originalHost
+	}
+	if target.RewritePrefix != nil {
+		// Marshaling strings cannot fail.
+		b, _ := json.Marshal(queue.PathRewrite{Prefix: target.Prefix, Replacement: *target.RewritePrefix})
+		headers[queue.PathRewriteHeaderName] = string(b)
+	}
+	port := target.ServicePort
+	if port == 0 {
+		port = 80
+	}
+	return netv1alpha1.HTTPIngressPath{
+		Path:        target.Prefix,
+		RewriteHost: target.HostName,
+		Splits: []netv1alpha1.IngressBackendSplit{{
+			Percent:       100,
+			AppendHeaders: headers,
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: ns,
+				ServiceName:      target.BackendServiceName,
+				ServicePort:      intstr.FromInt(port),
+			},
+		}},
+	}
+}
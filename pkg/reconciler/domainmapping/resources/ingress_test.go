@@ -30,8 +30,10 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
 	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/apis/serving/v1alpha1"
+	"knative.dev/serving/pkg/queue"
 )
 
 func TestMakeIngress(t *testing.T) {
@@ -41,6 +43,7 @@ func TestMakeIngress(t *testing.T) {
 		want           netv1alpha1.Ingress
 		tls            []netv1alpha1.IngressTLS
 		acmeChallenges []netv1alpha1.HTTP01Challenge
+		paths          []PathTarget
 	}{{
 		name: "basic",
 		dm: v1alpha1.DomainMapping{
@@ -80,7 +83,8 @@ func TestMakeIngress(t *testing.T) {
 							Splits: []netv1alpha1.IngressBackendSplit{{
 								Percent: 100,
 								AppendHeaders: map[string]string{
-									network.OriginalHostHeader: "mapping.com",
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: "",
 								},
 								IngressBackend: netv1alpha1.IngressBackend{
 									ServiceName:      "the-target-svc",
@@ -136,7 +140,8 @@ func TestMakeIngress(t *testing.T) {
 							Splits: []netv1alpha1.IngressBackendSplit{{
 								Percent: 100,
 								AppendHeaders: map[string]string{
-									network.OriginalHostHeader: "mapping.com",
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: "",
 								},
 								IngressBackend: netv1alpha1.IngressBackend{
 									ServiceName:      "the-target-svc",
@@ -212,7 +217,190 @@ func TestMakeIngress(t *testing.T) {
 							Splits: []netv1alpha1.IngressBackendSplit{{
 								Percent: 100,
 								AppendHeaders: map[string]string{
-									network.OriginalHostHeader: "mapping.com",
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: "",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "paths",
+		dm: v1alpha1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				UID:       types.UID("the-uid"),
+			},
+			Spec: v1alpha1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+			},
+		},
+		paths: []PathTarget{{
+			Target: Target{
+				BackendServiceName: "billing-svc",
+				HostName:           "billing.the-namespace.svc.cluster.local",
+			},
+			Prefix:        "/billing",
+			RewritePrefix: ptr.String("/"),
+		}, {
+			Target: Target{
+				BackendServiceName: "admin-svc",
+				HostName:           "admin.the-namespace.svc.cluster.local",
+			},
+			Prefix: "/billing/admin",
+		}},
+		want: netv1alpha1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				Annotations: map[string]string{
+					networking.IngressClassAnnotationKey: "the-ingress-class",
+				},
+			},
+			Spec: netv1alpha1.IngressSpec{
+				HTTPOption: netv1alpha1.HTTPOptionEnabled,
+				Rules: []netv1alpha1.IngressRule{{
+					Hosts:      []string{"mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						// The longest prefix comes first.
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							Path:        "/billing/admin",
+							RewriteHost: "admin.the-namespace.svc.cluster.local",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: "",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "admin-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}, {
+							Path:        "/billing",
+							RewriteHost: "billing.the-namespace.svc.cluster.local",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: `{"prefix":"/billing","replacement":"/"}`,
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "billing-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}, {
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: "",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "hosts",
+		dm: v1alpha1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				UID:       types.UID("the-uid"),
+			},
+			Spec: v1alpha1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+				Hosts: []string{"www.mapping.com", "*.customers.mapping.com"},
+			},
+		},
+		want: netv1alpha1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				Annotations: map[string]string{
+					networking.IngressClassAnnotationKey: "the-ingress-class",
+				},
+			},
+			Spec: netv1alpha1.IngressSpec{
+				HTTPOption: netv1alpha1.HTTPOptionEnabled,
+				Rules: []netv1alpha1.IngressRule{{
+					Hosts:      []string{"mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									network.OriginalHostHeader:  "mapping.com",
+									queue.PathRewriteHeaderName: "",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}, {
+					Hosts:      []string{"www.mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									network.OriginalHostHeader:  "www.mapping.com",
+									queue.PathRewriteHeaderName: "",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}, {
+					// The original host of the wildcard is unknown.
+					Hosts:      []string{"*.customers.mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									queue.PathRewriteHeaderName: "",
 								},
 								IngressBackend: netv1alpha1.IngressBackend{
 									ServiceName:      "the-target-svc",
@@ -233,7 +421,7 @@ func TestMakeIngress(t *testing.T) {
 			})
 			tc.want.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(&tc.dm)}
 			got := *MakeIngress(&tc.dm,
-				"the-target-svc", "the-rewrite-host", "the-ingress-class",
+				Target{BackendServiceName: "the-target-svc", HostName: "the-rewrite-host"}, tc.paths, "the-ingress-class",
 				netv1alpha1.HTTPOptionEnabled,
 				tc.tls, tc.acmeChallenges...)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/serving/pkg/apis/serving"
+	"knative.dev/serving/pkg/apis/serving/v1alpha1"
+)
+
+// ExternalServiceName returns the name of the ExternalName Service of the
+// target of the DomainMapping, which is the Ref for an empty prefix and the
+// Ref of the path with the prefix otherwise. Service names are DNS-1035
+// labels, which the names of DomainMappings are not.
+func ExternalServiceName(dm *v1alpha1.DomainMapping, prefix string) string {
+	base := "dm-" + strings.ReplaceAll(dm.Name, ".", "-")
+	for i, path := range dm.Spec.Paths {
+		if prefix != "" && path.Prefix == prefix {
+			return kmeta.ChildName(base, "-path-"+strconv.Itoa(i))
+		}
+	}
+	return kmeta.ChildName(base, "")
+}
+
+// MakeExternalService creates an ExternalName Service in the namespace of
+// the DomainMapping through which the Ingress reaches a target of another
+// namespace, or outside the cluster, on the port of the target.
+func MakeExternalService(dm *v1alpha1.DomainMapping, name, externalName string, port int) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: dm.Namespace,
+			Labels: map[string]string{
+				serving.DomainMappingUIDLabelKey:       string(dm.UID),
+				serving.DomainMappingNamespaceLabelKey: dm.Namespace,
+			},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(dm)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:            corev1.ServiceTypeExternalName,
+			ExternalName:    externalName,
+			SessionAffinity: corev1.ServiceAffinityNone,
+			Ports: []corev1.ServicePort{{
+				Name:       networking.ServicePortNameHTTP1,
+				Port:       int32(port),
+				TargetPort: intstr.FromInt(port),
+			}},
+		},
+	}
+}
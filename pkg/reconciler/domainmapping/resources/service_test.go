@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/serving/pkg/apis/serving"
+	"knative.dev/serving/pkg/apis/serving/v1alpha1"
+)
+
+func TestExternalServiceName(t *testing.T) {
+	dm := &v1alpha1.DomainMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mapping.com",
+			Namespace: "the-namespace",
+		},
+		Spec: v1alpha1.DomainMappingSpec{
+			Paths: []v1alpha1.DomainMappingPath{{
+				Prefix: "/billing",
+			}, {
+				Prefix: "/users",
+			}},
+		},
+	}
+
+	for prefix, want := range map[string]string{
+		"":         "dm-mapping-com",
+		"/billing": "dm-mapping-com-path-0",
+		"/users":   "dm-mapping-com-path-1",
+	} {
+		if got := ExternalServiceName(dm, prefix); got != want {
+			t.Errorf("ExternalServiceName(%q) = %q, want: %q", prefix, got, want)
+		}
+	}
+}
+
+func TestMakeExternalService(t *testing.T) {
+	dm := &v1alpha1.DomainMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mapping.com",
+			Namespace: "the-namespace",
+			UID:       "the-uid",
+		},
+	}
+	got := MakeExternalService(dm, "dm-mapping-com", "example.com", 8080)
+
+	want := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dm-mapping-com",
+			Namespace: "the-namespace",
+			Labels: map[string]string{
+				serving.DomainMappingUIDLabelKey:       "the-uid",
+				serving.DomainMappingNamespaceLabelKey: "the-namespace",
+			},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(dm)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:            corev1.ServiceTypeExternalName,
+			ExternalName:    "example.com",
+			SessionAffinity: corev1.ServiceAffinityNone,
+			Ports: []corev1.ServicePort{{
+				Name:       networking.ServicePortNameHTTP1,
+				Port:       8080,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Errorf("Unexpected Service (-want, +got):\n%s", cmp.Diff(want, got))
+	}
+}
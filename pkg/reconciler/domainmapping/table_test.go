@@ -34,11 +34,13 @@ import (
 	networkingclient "knative.dev/networking/pkg/client/injection/client/fake"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	pkgnetwork "knative.dev/pkg/network"
+	"knative.dev/pkg/ptr"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/tracker"
@@ -95,7 +97,7 @@ func TestReconcile(t *testing.T) {
 		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
-			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -195,7 +197,7 @@ func TestReconcile(t *testing.T) {
 			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target", withAPIVersionKind("v1", "Service")))),
 			resources.MakeIngress(
 				domainMapping("default", "first-reconcile.com", withRef("default", "target", withAPIVersionKind("v1", "Service"))),
-				"target", "target.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+				resources.Target{BackendServiceName: "target", HostName: "target.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -233,6 +235,111 @@ func TestReconcile(t *testing.T) {
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
 			Eventf(corev1.EventTypeWarning, "InternalError", `resolving reference: failed to get object default/target: services.serving.knative.dev "target" not found`),
 		},
+	}, {
+		Name: "first reconcile with paths",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			ksvc("default", "billing", "billing.default.svc.cluster.local", ""),
+			ksvc("default", "users", "users.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withPath("/billing", "default", "billing", ptr.String("/")),
+				withPath("/users", "default", "users", nil)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withPath("/billing", "default", "billing", ptr.String("/")),
+				withPath("/users", "default", "users", nil),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+				withPathStatus(v1alpha1.DomainMappingPathStatus{
+					Prefix:            "/billing",
+					URL:               &apis.URL{Scheme: "http", Host: "billing.default.svc.cluster.local"},
+					ReferenceResolved: true,
+				}),
+				withPathStatus(v1alpha1.DomainMappingPathStatus{
+					Prefix:            "/users",
+					URL:               &apis.URL{Scheme: "http", Host: "users.default.svc.cluster.local"},
+					ReferenceResolved: true,
+				}),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com")),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")),
+				resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, []resources.PathTarget{{
+					Target: resources.Target{
+						BackendServiceName: "billing",
+						HostName:           "billing.default.svc.cluster.local",
+					},
+					Prefix:        "/billing",
+					RewritePrefix: ptr.String("/"),
+				}, {
+					Target: resources.Target{
+						BackendServiceName: "users",
+						HostName:           "users.default.svc.cluster.local",
+					},
+					Prefix: "/users",
+				}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "first reconcile, path ref does not exist",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			ksvc("default", "users", "users.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withPath("/billing", "default", "billing", nil),
+				withPath("/users", "default", "users", nil)),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withPath("/billing", "default", "billing", nil),
+				withPath("/users", "default", "users", nil),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withReferenceNotResolved("failed to resolve the references of the paths /billing"),
+				withPathStatus(v1alpha1.DomainMappingPathStatus{
+					Prefix:  "/billing",
+					Message: `failed to get object default/billing: services.serving.knative.dev "billing" not found`,
+				}),
+				withPathStatus(v1alpha1.DomainMappingPathStatus{
+					Prefix:            "/users",
+					URL:               &apis.URL{Scheme: "http", Host: "users.default.svc.cluster.local"},
+					ReferenceResolved: true,
+				}),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com")),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", "failed to resolve the references of the paths /billing"),
+		},
 	}, {
 		Name: "first reconcile, ref has a path",
 		Key:  "default/first-reconcile.com",
@@ -270,37 +377,267 @@ func TestReconcile(t *testing.T) {
 			ksvc("default", "target", "notasvc.cluster.local", ""),
 			domainMapping("default", "first-reconcile.com", withRef("default", "target")),
 		},
-		WantErr: true,
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-			Object: domainMapping("default", "first-reconcile.com",
-				withRef("default", "target"),
+			Object: domainMapping("default", "first-reconcile.com", withRef("default", "target"),
 				withURL("http", "first-reconcile.com"),
 				withAddress("http", "first-reconcile.com"),
 				withInitDomainMappingConditions,
 				withTLSNotEnabled,
 				withDomainClaimed,
-				withReferenceNotResolved(`resolved URI "http://notasvc.cluster.local" must be of the form {name}.{namespace}.svc.cluster.local`),
+				withIngressNotConfigured,
+				withReferenceResolved,
 			),
 		}},
 		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), resources.Target{BackendServiceName: "dm-first-reconcile-com", ServicePort: 80, HostName: "notasvc.cluster.local", ExternalName: "notasvc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeExternalService(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "dm-first-reconcile-com", "notasvc.cluster.local", 80),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
 		},
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
-			Eventf(corev1.EventTypeWarning, "InternalError", `resolved URI "http://notasvc.cluster.local" must be of the form {name}.{namespace}.svc.cluster.local`),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Service %q", "dm-first-reconcile-com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
 		},
 	}, {
-		Name: "first reconcile, resolved URL in wrong namespace",
+		Name: "first reconcile, resolved URL in another namespace",
 		Key:  "default/first-reconcile.com",
 		Objects: []runtime.Object{
 			ksvc("default", "target", "name.anothernamespace.svc.cluster.local", ""),
 			domainMapping("default", "first-reconcile.com", withRef("default", "target")),
 		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), resources.Target{BackendServiceName: "dm-first-reconcile-com", ServicePort: 80, HostName: "name.anothernamespace.svc.cluster.local", ExternalName: "name.anothernamespace.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeExternalService(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "dm-first-reconcile-com", "name.anothernamespace.svc.cluster.local", 80),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Service %q", "dm-first-reconcile-com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "first reconcile, ref in another namespace without a grant",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("other", "target", "target.other.svc.cluster.local", ""),
+			grant("other", "not-default"),
+			domainMapping("default", "first-reconcile.com", withRef("other", "target")),
+		},
 		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("other", "target"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withReferenceNotResolved(`no DomainMappingGrant of namespace "other" allows the reference to Service "target"`),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("other", "target"))),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `no DomainMappingGrant of namespace "other" allows the reference to Service "target"`),
+		},
+	}, {
+		Name: "first reconcile, ref in another namespace with a grant",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("other", "target", "target.other.svc.cluster.local", ""),
+			grant("other", "default"),
+			domainMapping("default", "first-reconcile.com", withRef("other", "target")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com", withRef("other", "target"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("other", "target"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("other", "target")), resources.Target{BackendServiceName: "dm-first-reconcile-com", ServicePort: 80, HostName: "target.other.svc.cluster.local", ExternalName: "target.other.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeExternalService(domainMapping("default", "first-reconcile.com", withRef("other", "target")), "dm-first-reconcile-com", "target.other.svc.cluster.local", 80),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Service %q", "dm-first-reconcile-com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "first reconcile with url",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			domainMapping("default", "first-reconcile.com", withTargetURL("http://example.com:8080")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com", withTargetURL("http://example.com:8080"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withTargetURL("http://example.com:8080"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withTargetURL("http://example.com:8080")), resources.Target{BackendServiceName: "dm-first-reconcile-com", ServicePort: 8080, HostName: "example.com:8080", ExternalName: "example.com"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeExternalService(domainMapping("default", "first-reconcile.com", withTargetURL("http://example.com:8080")), "dm-first-reconcile-com", "example.com", 8080),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Service %q", "dm-first-reconcile-com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "url of a Service in another namespace without a grant",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			// The grant allows the Knative Services alone.
+			grant("other", "default"),
+			domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc")),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withReferenceNotResolved(`no DomainMappingGrant of namespace "other" allows the URL "http://users.other.svc" of Service "users"`),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc"))),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `no DomainMappingGrant of namespace "other" allows the URL "http://users.other.svc" of Service "users"`),
+		},
+	}, {
+		Name: "url of an existing Service in another namespace",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			// The search domains resolve the host to the Service.
+			service("other", "users"),
+			domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other")),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withReferenceNotResolved(`no DomainMappingGrant of namespace "other" allows the URL "http://users.other" of Service "users"`),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other"))),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `no DomainMappingGrant of namespace "other" allows the URL "http://users.other" of Service "users"`),
+		},
+	}, {
+		Name: "url of a Service in another namespace with a grant",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			&v1alpha1.DomainMappingGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "grant",
+					Namespace: "other",
+				},
+				Spec: v1alpha1.DomainMappingGrantSpec{
+					From: []v1alpha1.DomainMappingGrantFrom{{Namespace: "default"}},
+					To:   []v1alpha1.DomainMappingGrantTo{{Kind: "Service", Name: "users"}},
+				},
+			},
+			domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc")), resources.Target{BackendServiceName: "dm-first-reconcile-com", ServicePort: 80, HostName: "users.other.svc", ExternalName: "users.other.svc"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeExternalService(domainMapping("default", "first-reconcile.com", withTargetURL("http://users.other.svc")), "dm-first-reconcile-com", "users.other.svc", 80),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Service %q", "dm-first-reconcile-com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "reconcile deletes the ExternalName Service of a former target",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target")),
+			resources.MakeExternalService(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "dm-first-reconcile-com", "example.com", 80),
+		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: domainMapping("default", "first-reconcile.com",
 				withRef("default", "target"),
@@ -309,19 +646,29 @@ func TestReconcile(t *testing.T) {
 				withInitDomainMappingConditions,
 				withTLSNotEnabled,
 				withDomainClaimed,
-				withReferenceNotResolved(`resolved URI "http://name.anothernamespace.svc.cluster.local" must be in same namespace as DomainMapping`),
+				withIngressNotConfigured,
+				withReferenceResolved,
 			),
 		}},
 		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "default",
+				Verb:      "delete",
+				Resource:  corev1.SchemeGroupVersion.WithResource("services"),
+			},
+			Name: "dm-first-reconcile-com",
+		}},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
 		},
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
-			Eventf(corev1.EventTypeWarning, "InternalError", `resolved URI "http://name.anothernamespace.svc.cluster.local" must be in same namespace as DomainMapping`),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
 		},
 	}, {
 		Name: "first reconcile, pre-owned domain claim",
@@ -344,7 +691,7 @@ func TestReconcile(t *testing.T) {
 			),
 		}},
 		WantCreates: []runtime.Object{
-			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -411,6 +758,167 @@ func TestReconcile(t *testing.T) {
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
 			Eventf(corev1.EventTypeWarning, "InternalError", `namespace "default" does not own ClusterDomainClaim for "first-reconcile.com"`),
 		},
+	}, {
+		Name: "first reconcile with hosts",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withHosts("www.first-reconcile.com", "*.customers.first-reconcile.com")),
+			// Wildcards of other namespaces may be siblings.
+			resources.MakeHostDomainClaim(domainMapping("other-namespace", "first-reconcile.com"), "*.partners.first-reconcile.com"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withHosts("www.first-reconcile.com", "*.customers.first-reconcile.com"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com")),
+			resources.MakeHostDomainClaim(domainMapping("default", "first-reconcile.com"), "www.first-reconcile.com"),
+			resources.MakeHostDomainClaim(domainMapping("default", "first-reconcile.com"), "*.customers.first-reconcile.com"),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withHosts("www.first-reconcile.com", "*.customers.first-reconcile.com")),
+				resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "wildcard host overlaps the wildcard of another namespace",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withHosts("*.customers.first-reconcile.com")),
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com")),
+			resources.MakeHostDomainClaim(domainMapping("other-namespace", "first-reconcile.com"), "*.first-reconcile.com"),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withHosts("*.customers.first-reconcile.com"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withDomainClaimNotOwned,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `host "*.customers.first-reconcile.com" overlaps the ClusterDomainClaim "wc--wildcard.first-reconcile.com" of namespace "other-namespace"`),
+		},
+	}, {
+		Name: "exact host under the wildcard of another namespace",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withHosts("www.partners.first-reconcile.com")),
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com")),
+			resources.MakeHostDomainClaim(domainMapping("other-namespace", "first-reconcile.com"), "*.partners.first-reconcile.com"),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withHosts("www.partners.first-reconcile.com"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withDomainClaimNotOwned,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `host "www.partners.first-reconcile.com" overlaps the ClusterDomainClaim "wc--wildcard.partners.first-reconcile.com" of namespace "other-namespace"`),
+		},
+	}, {
+		Name: "wildcard host over the host of another namespace",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"),
+				withHosts("*.customers.first-reconcile.com")),
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com")),
+			resources.MakeHostDomainClaim(domainMapping("other-namespace", "first-reconcile.com"), "shop.customers.first-reconcile.com"),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withHosts("*.customers.first-reconcile.com"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withDomainClaimNotOwned,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `host "*.customers.first-reconcile.com" overlaps the ClusterDomainClaim "shop.customers.first-reconcile.com" of namespace "other-namespace"`),
+		},
+	}, {
+		Name: "reconcile releases the claims of removed hosts",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"), withUID("the-uid")),
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withUID("the-uid"))),
+			resources.MakeHostDomainClaim(domainMapping("default", "first-reconcile.com", withUID("the-uid")), "www.first-reconcile.com"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withUID("the-uid"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow deletion of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target"), withUID("the-uid")),
+				resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Verb:     "delete",
+				Resource: v1alpha1.SchemeGroupVersion.WithResource("clusterdomainclaims"),
+			},
+			Name: "www.first-reconcile.com",
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
 	}, {
 		Name: "reconcile with ingressClass annotation",
 		Key:  "default/ingressclass.first-reconcile.com",
@@ -441,7 +949,7 @@ func TestReconcile(t *testing.T) {
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "ingressclass.first-reconcile.com", withRef("default", "target"))),
 			resources.MakeIngress(domainMapping("default", "ingressclass.first-reconcile.com", withRef("default", "target")),
-				"the-target-svc", "the-target-svc.default.svc.cluster.local", "overridden-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+				resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "overridden-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "ingressclass.first-reconcile.com"),
@@ -456,7 +964,7 @@ func TestReconcile(t *testing.T) {
 		Objects: []runtime.Object{
 			ksvc("default", "changed", "changed.default.svc.cluster.local", ""),
 			domainMapping("default", "ingress-exists.org", withRef("default", "changed")),
-			resources.MakeIngress(domainMapping("default", "ingress-exists.org", withRef("default", "changed")), "previous", "previous.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "ingress-exists.org", withRef("default", "changed")), resources.Target{BackendServiceName: "previous", HostName: "previous.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 			resources.MakeDomainClaim(domainMapping("default", "ingress-exists.org", withRef("default", "changed"))),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
@@ -710,6 +1218,10 @@ func TestReconcile(t *testing.T) {
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
 			domainClaimLister: listers.GetDomainClaimLister(),
+			grantLister:       listers.GetDomainMappingGrantLister(),
+			serviceLister:     listers.GetK8sServiceLister(),
+			kubeclient:        fakekubeclient.Get(ctx),
+			tracker:           &NullTracker{},
 		}
 
 		cfg := &config.Config{
@@ -783,7 +1295,7 @@ func TestReconcileAutocreateClaimsDisabled(t *testing.T) {
 		}},
 		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
 		WantCreates: []runtime.Object{
-			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), resources.Target{BackendServiceName: "the-target-svc", HostName: "the-target-svc.default.svc.cluster.local"}, nil /* paths */, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -843,6 +1355,10 @@ func TestReconcileAutocreateClaimsDisabled(t *testing.T) {
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
 			domainClaimLister: listers.GetDomainClaimLister(),
+			grantLister:       listers.GetDomainMappingGrantLister(),
+			serviceLister:     listers.GetK8sServiceLister(),
+			kubeclient:        fakekubeclient.Get(ctx),
+			tracker:           &NullTracker{},
 		}
 
 		return domainmappingreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -1190,6 +1706,37 @@ func TestReconcileTLSEnabled(t *testing.T) {
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "certificateless.com"),
 			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "certificateless.com"),
 		},
+	}, {
+		Name: "wildcard host without wildcard certificates",
+		Key:  "default/wildcard.reconcile.io",
+		Objects: []runtime.Object{
+			ksvc("default", "ready", "ready.default.svc.cluster.local", ""),
+			domainMapping("default", "wildcard.reconcile.io",
+				withRef("default", "ready"),
+				withHosts("*.customers.wildcard.reconcile.io"),
+			),
+			resources.MakeDomainClaim(domainMapping("default", "wildcard.reconcile.io")),
+			resources.MakeHostDomainClaim(domainMapping("default", "wildcard.reconcile.io"), "*.customers.wildcard.reconcile.io"),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "wildcard.reconcile.io",
+				withRef("default", "ready"),
+				withHosts("*.customers.wildcard.reconcile.io"),
+				withURL("http", "wildcard.reconcile.io"),
+				withAddress("http", "wildcard.reconcile.io"),
+				withInitDomainMappingConditions,
+				withDomainClaimed,
+				withWildcardCertificateNotSupported("*.customers.wildcard.reconcile.io"),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "wildcard.reconcile.io"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "wildcard.reconcile.io"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `wildcard host "*.customers.wildcard.reconcile.io" requires the wildcard-certificates feature`),
+		},
 	}}
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
@@ -1200,6 +1747,10 @@ func TestReconcileTLSEnabled(t *testing.T) {
 			domainClaimLister: listers.GetDomainClaimLister(),
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			grantLister:       listers.GetDomainMappingGrantLister(),
+			serviceLister:     listers.GetK8sServiceLister(),
+			kubeclient:        fakekubeclient.Get(ctx),
+			tracker:           &NullTracker{},
 		}
 
 		return domainmappingreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -1267,6 +1818,10 @@ func TestReconcileTLSEnabledButDowngraded(t *testing.T) {
 			ingressLister:     listers.GetIngressLister(),
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			grantLister:       listers.GetDomainMappingGrantLister(),
+			serviceLister:     listers.GetK8sServiceLister(),
+			kubeclient:        fakekubeclient.Get(ctx),
+			tracker:           &NullTracker{},
 		}
 
 		return domainmappingreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -1313,6 +1868,12 @@ func withUID(uid types.UID) domainMappingOption {
 	}
 }
 
+func withHosts(hosts ...string) domainMappingOption {
+	return func(dm *v1alpha1.DomainMapping) {
+		dm.Spec.Hosts = hosts
+	}
+}
+
 type refOption func(*duckv1.KReference)
 
 func withRef(namespace, name string, opt ...refOption) domainMappingOption {
@@ -1328,6 +1889,34 @@ func withRef(namespace, name string, opt ...refOption) domainMappingOption {
 	}
 }
 
+func withTargetURL(url string) domainMappingOption {
+	return func(dm *v1alpha1.DomainMapping) {
+		u, _ := apis.ParseURL(url)
+		dm.Spec.URL = u
+	}
+}
+
+func withPath(prefix, namespace, name string, rewritePrefix *string) domainMappingOption {
+	return func(dm *v1alpha1.DomainMapping) {
+		dm.Spec.Paths = append(dm.Spec.Paths, v1alpha1.DomainMappingPath{
+			Prefix: prefix,
+			Ref: duckv1.KReference{
+				Namespace:  namespace,
+				Name:       name,
+				APIVersion: "serving.knative.dev/v1",
+				Kind:       "Service",
+			},
+			RewritePrefix: rewritePrefix,
+		})
+	}
+}
+
+func withPathStatus(status v1alpha1.DomainMappingPathStatus) domainMappingOption {
+	return func(dm *v1alpha1.DomainMapping) {
+		dm.Status.Paths = append(dm.Status.Paths, status)
+	}
+}
+
 func withAPIVersionKind(apiVersion, kind string) refOption {
 	return func(ref *duckv1.KReference) {
 		ref.APIVersion = apiVersion
@@ -1400,6 +1989,12 @@ func withCertificateNotOwned(dm *v1alpha1.DomainMapping) {
 	dm.Status.MarkCertificateNotOwned(dm.Name)
 }
 
+func withWildcardCertificateNotSupported(host string) domainMappingOption {
+	return func(dm *v1alpha1.DomainMapping) {
+		dm.Status.MarkWildcardCertificateNotSupported(host)
+	}
+}
+
 func withDomainClaimNotOwned(dm *v1alpha1.DomainMapping) {
 	dm.Status.MarkDomainClaimNotOwned()
 }
@@ -1443,7 +2038,10 @@ func ingress(dm *v1alpha1.DomainMapping, ingressClass string, opt ...IngressOpti
 }
 
 func ingressWithChallenges(dm *v1alpha1.DomainMapping, ingressClass string, challenges []netv1alpha1.HTTP01Challenge, opt ...IngressOption) *netv1alpha1.Ingress {
-	ing := resources.MakeIngress(dm, dm.Spec.Ref.Name, dm.Spec.Ref.Name+"."+dm.Spec.Ref.Namespace+".svc.cluster.local", ingressClass, netv1alpha1.HTTPOptionEnabled, nil /* tls */, challenges...)
+	ing := resources.MakeIngress(dm, resources.Target{
+		BackendServiceName: dm.Spec.Ref.Name,
+		HostName:           dm.Spec.Ref.Name + "." + dm.Spec.Ref.Namespace + ".svc.cluster.local",
+	}, nil /* paths */, ingressClass, netv1alpha1.HTTPOptionEnabled, nil /* tls */, challenges...)
 	for _, o := range opt {
 		o(ing)
 	}
@@ -1493,6 +2091,22 @@ func ksvc(ns, name, host, path string) *servingv1.Service {
 	}
 }
 
+func grant(ns, from string) *v1alpha1.DomainMappingGrant {
+	return &v1alpha1.DomainMappingGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grant",
+			Namespace: ns,
+		},
+		Spec: v1alpha1.DomainMappingGrantSpec{
+			From: []v1alpha1.DomainMappingGrantFrom{{Namespace: from}},
+			To: []v1alpha1.DomainMappingGrantTo{{
+				Group: serving.GroupName,
+				Kind:  "Service",
+			}},
+		},
+	}
+}
+
 func readyCertStatus() netv1alpha1.CertificateStatus {
 	certStatus := &netv1alpha1.CertificateStatus{}
 	certStatus.MarkReady()
@@ -19,12 +19,23 @@ package gc
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+	cachingclient "knative.dev/caching/pkg/client/injection/client"
+	imageinformer "knative.dev/caching/pkg/client/injection/informers/caching/v1alpha1/image"
+	netclient "knative.dev/networking/pkg/client/injection/client"
+	sksinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/serverlessservice"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	namespacereconciler "knative.dev/pkg/client/injection/kube/reconciler/core/v1/namespace"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
+	metricinformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/metric"
+	painformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/podautoscaler"
 	configurationinformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration"
 	revisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision"
 	configreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/configuration"
@@ -32,7 +43,10 @@ import (
 	configns "knative.dev/serving/pkg/reconciler/gc/config"
 )
 
-const controllerAgentName = "revision-gc-controller"
+const (
+	controllerAgentName = "revision-gc-controller"
+	sweeperAgentName    = "orphan-sweeper-controller"
+)
 
 // NewController creates a new Garbage Collection controller
 func NewController(
@@ -42,10 +56,12 @@ func NewController(
 	logger := logging.FromContext(ctx)
 	configurationInformer := configurationinformer.Get(ctx)
 	revisionInformer := revisioninformer.Get(ctx)
+	namespaceInformer := namespaceinformer.Get(ctx)
 
 	c := &reconciler{
-		client:         servingclient.Get(ctx),
-		revisionLister: revisionInformer.Lister(),
+		client:          servingclient.Get(ctx),
+		revisionLister:  revisionInformer.Lister(),
+		namespaceLister: namespaceInformer.Lister(),
 	}
 	return configreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 		// Since the gc controller came from the configuration controller, having event handlers
@@ -59,6 +75,17 @@ func NewController(
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		// The annotations of a Namespace may override the GC policy of the
+		// Configurations of the Namespace.
+		namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: controller.PassNew(func(obj interface{}) {
+				namespace := obj.(*corev1.Namespace).Name
+				impl.FilteredGlobalResync(func(obj interface{}) bool {
+					return obj.(*v1.Configuration).Namespace == namespace
+				}, configurationInformer.Informer())
+			}),
+		})
+
 		configsToResync := []interface{}{
 			&gcconfig.Config{},
 		}
@@ -78,3 +105,59 @@ func NewController(
 		}
 	})
 }
+
+// NewSweeperController creates a new controller sweeping the child resources
+// of Revisions which outlived their Revision, Namespace by Namespace.
+func NewSweeperController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+	namespaceInformer := namespaceinformer.Get(ctx)
+	configurationInformer := configurationinformer.Get(ctx)
+	revisionInformer := revisioninformer.Get(ctx)
+	paInformer := painformer.Get(ctx)
+
+	s := newSweeper(
+		servingclient.Get(ctx),
+		netclient.Get(ctx),
+		cachingclient.Get(ctx),
+		configurationInformer.Lister(),
+		revisionInformer.Lister(),
+		paInformer.Lister(),
+		metricinformer.Get(ctx).Lister(),
+		sksinformer.Get(ctx).Lister(),
+		imageinformer.Get(ctx).Lister(),
+	)
+	return namespacereconciler.NewImpl(ctx, s, func(impl *controller.Impl) controller.Options {
+		namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: impl.Enqueue,
+		})
+
+		// The child resources are orphaned when their owners are deleted
+		// and the Kubernetes garbage collector misses them, so the Namespace
+		// is swept once the garbage collector had the time to delete them.
+		sweepAfterDelete := cache.ResourceEventHandlerFuncs{
+			DeleteFunc: func(obj interface{}) {
+				if acc, err := kmeta.DeletionHandlingAccessor(obj); err == nil {
+					impl.EnqueueKeyAfter(types.NamespacedName{Name: acc.GetNamespace()}, orphanGracePeriod)
+				}
+			},
+		}
+		configurationInformer.Informer().AddEventHandler(sweepAfterDelete)
+		revisionInformer.Informer().AddEventHandler(sweepAfterDelete)
+		paInformer.Informer().AddEventHandler(sweepAfterDelete)
+
+		resync := configmap.TypeFilter(&gcconfig.Config{})(func(string, interface{}) {
+			impl.GlobalResync(namespaceInformer.Informer())
+		})
+		configStore := configns.NewStore(logging.WithLogger(ctx, logger.Named("config-store")), resync)
+		configStore.WatchConfigs(cmw)
+
+		return controller.Options{
+			ConfigStore:       configStore,
+			AgentName:         sweeperAgentName,
+			SkipStatusUpdates: true,
+		}
+	})
+}
@@ -18,13 +18,21 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/serving/pkg/apis/serving"
@@ -35,34 +43,93 @@ import (
 	configns "knative.dev/serving/pkg/reconciler/gc/config"
 )
 
-// collect deletes stale revisions if they are sufficiently old
+// collect deletes stale revisions if they are sufficiently old. In dry-run
+// mode, it reports them instead, as Events and with an annotation, which the
+// Configuration reconciler reports in the status of the Configuration.
 func collect(
 	ctx context.Context,
 	client clientset.Interface,
 	revisionLister listers.RevisionLister,
+	namespaceLister corev1listers.NamespaceLister,
 	config *v1.Configuration) pkgreconciler.Event {
-	cfg := configns.FromContext(ctx).RevisionGC
 	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
 
+	// The Namespace and the Configuration may override config-gc.
+	var nsAnnotations map[string]string
+	if ns, err := namespaceLister.Get(config.Namespace); err == nil {
+		nsAnnotations = ns.Annotations
+	} else if !apierrs.IsNotFound(err) {
+		return err
+	}
+	cfg := newPolicy(configns.FromContext(ctx).RevisionGC, logger, nsAnnotations, config.Annotations)
+
+	candidates, err := gcCandidates(cfg, revisionLister, config, logger)
+	if err != nil {
+		return err
+	}
+
+	if cfg.DryRun {
+		for _, c := range candidates {
+			rule, reported := c.rev.Annotations[serving.RevisionGCCandidateAnnotationKey]
+			if !reported {
+				// Only report the Revisions which were not candidates already.
+				logger.Infof("Revision %s would be deleted as %s", c.rev.Name, c.rule)
+				recorder.Eventf(config, corev1.EventTypeNormal, "RevisionGCCandidate",
+					"Revision %q would be deleted as %s", c.rev.Name, c.rule)
+			}
+			if rule != c.rule {
+				if err := annotateGCCandidate(ctx, client, c.rev, &c.rule); err != nil {
+					return err
+				}
+			}
+		}
+		return clearGCCandidates(ctx, client, revisionLister, config, candidates)
+	}
+
+	for _, c := range candidates {
+		logger.Infof("Deleting revision %s as %s", c.rev.Name, c.rule)
+		if err := client.ServingV1().Revisions(c.rev.Namespace).Delete(ctx, c.rev.Name, metav1.DeleteOptions{}); err != nil {
+			logger.Errorw("Failed to GC revision: "+c.rev.Name, zap.Error(err))
+			recorder.Eventf(config, corev1.EventTypeWarning, "RevisionDeletionFailed",
+				"Failed to delete Revision %q: %v", c.rev.Name, err)
+			continue
+		}
+		recorder.Eventf(config, corev1.EventTypeNormal, "RevisionDeleted",
+			"Deleted Revision %q as %s", c.rev.Name, c.rule)
+	}
+	// Clear the candidates reported before the dry-run mode was disabled.
+	return clearGCCandidates(ctx, client, revisionLister, config, candidates)
+}
+
+// candidate is a Revision to be collected, and the rule it is collected by.
+type candidate struct {
+	rev  *v1.Revision
+	rule string
+}
+
+// gcCandidates returns the Revisions of the Configuration to be collected
+// under the policy, oldest first.
+func gcCandidates(cfg *policy, revisionLister listers.RevisionLister, config *v1.Configuration, logger *zap.SugaredLogger) ([]candidate, error) {
 	min, max := int(cfg.MinNonActiveRevisions), int(cfg.MaxNonActiveRevisions)
 	if max == gc.Disabled && cfg.RetainSinceCreateTime == gc.Disabled && cfg.RetainSinceLastActiveTime == gc.Disabled {
-		return nil // all deletion settings are disabled
+		return nil, nil // all deletion settings are disabled
 	}
 
 	selector := labels.SelectorFromSet(labels.Set{serving.ConfigurationLabelKey: config.Name})
 	revs, err := revisionLister.Revisions(config.Namespace).List(selector)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(revs) <= min {
-		return nil // not enough total revs
+		return nil, nil // not enough total revs
 	}
 
 	// Filter out active revs
-	revs = nonactiveRevisions(revs, config)
+	revs = nonactiveRevisions(revs, config, cfg)
 
 	if len(revs) <= min {
-		return nil // not enough non-active revs
+		return nil, nil // not enough non-active revs
 	}
 
 	// Sort by last active ascending (oldest first)
@@ -71,58 +138,100 @@ func collect(
 		return a.Before(b)
 	})
 
+	var candidates []candidate
+	staleRule := fmt.Sprintf("it is stale under retain-since-create-time=%s and retain-since-last-active-time=%s",
+		formatDisabledOrDuration(cfg.RetainSinceCreateTime), formatDisabledOrDuration(cfg.RetainSinceLastActiveTime))
+
 	count := len(revs)
 	// If we need `min` to remain, this is the max count of rev can delete.
 	maxIdx := len(revs) - min
 	staleCount := 0
 	for i := 0; i < count; i++ {
 		rev := revs[i]
-		if !isRevisionStale(cfg, rev, logger) {
+		if !isRevisionStale(&cfg.Config, rev, logger) {
 			continue
 		}
-		logger.Info("Deleting stale revision: ", rev.ObjectMeta.Name)
-		if err := client.ServingV1().Revisions(rev.Namespace).Delete(ctx, rev.Name, metav1.DeleteOptions{}); err != nil {
-			logger.Errorw("Failed to GC revision: "+rev.Name, zap.Error(err))
-		}
+		candidates = append(candidates, candidate{rev: rev, rule: staleRule})
 		revs[i] = nil
 		staleCount++
 		if staleCount >= maxIdx {
-			return nil // Reaches max revs to delete
+			return candidates, nil // Reaches max revs to delete
 		}
-
 	}
 
 	nonStaleCount := count - staleCount
 	if max == gc.Disabled || nonStaleCount <= max {
-		return nil
+		return candidates, nil
 	}
 	needsDeleteCount := nonStaleCount - max
 
-	// Stale revisions has been deleted, delete extra revisions past max.
-	logger.Infof("Maximum number of revisions (%d) reached, deleting oldest non-active (%d) revisions",
+	// Stale revisions are collected, collect extra revisions past max.
+	logger.Infof("Maximum number of revisions (%d) reached, collecting oldest non-active (%d) revisions",
 		max, needsDeleteCount)
-	deletedCount := 0
+	maxRule := fmt.Sprintf("it exceeds max-non-active-revisions=%d", max)
 	for _, rev := range revs {
-		if deletedCount == needsDeleteCount {
+		if len(candidates) == staleCount+needsDeleteCount {
 			break
 		}
 		if rev == nil {
 			continue
 		}
-		logger.Info("Deleting non-active revision: ", rev.ObjectMeta.Name)
-		if err := client.ServingV1().Revisions(rev.Namespace).Delete(ctx, rev.Name, metav1.DeleteOptions{}); err != nil {
-			logger.Errorw("Failed to GC revision: "+rev.Name, zap.Error(err))
+		candidates = append(candidates, candidate{rev: rev, rule: maxRule})
+	}
+	return candidates, nil
+}
+
+// clearGCCandidates removes the annotation of the candidates from the
+// Revisions of the Configuration which are no longer candidates.
+func clearGCCandidates(ctx context.Context, client clientset.Interface, revisionLister listers.RevisionLister, config *v1.Configuration, candidates []candidate) error {
+	selector := labels.SelectorFromSet(labels.Set{serving.ConfigurationLabelKey: config.Name})
+	revs, err := revisionLister.Revisions(config.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	names := make(sets.String, len(candidates))
+	for _, c := range candidates {
+		names.Insert(c.rev.Name)
+	}
+	for _, rev := range revs {
+		if _, ok := rev.Annotations[serving.RevisionGCCandidateAnnotationKey]; ok && !names.Has(rev.Name) {
+			if err := annotateGCCandidate(ctx, client, rev, nil); err != nil {
+				return err
+			}
 		}
-		deletedCount++
 	}
 	return nil
 }
 
+// annotateGCCandidate sets the annotation of the candidates of the Revision
+// to the rule, or removes it when the rule is nil.
+func annotateGCCandidate(ctx context.Context, client clientset.Interface, rev *v1.Revision, rule *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				serving.RevisionGCCandidateAnnotationKey: rule,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.ServingV1().Revisions(rev.Namespace).Patch(ctx, rev.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func formatDisabledOrDuration(d time.Duration) string {
+	if d == gc.Disabled {
+		return "disabled"
+	}
+	return d.String()
+}
+
 // nonactiveRevisions swaps keeps only non active revisions.
-func nonactiveRevisions(revs []*v1.Revision, config *v1.Configuration) []*v1.Revision {
+func nonactiveRevisions(revs []*v1.Revision, config *v1.Configuration, p *policy) []*v1.Revision {
 	swap := len(revs)
 	for i := 0; i < swap; {
-		if isRevisionActive(revs[i], config) {
+		if isRevisionActive(revs[i], config, p) {
 			swap--
 			revs[i] = revs[swap]
 		} else {
@@ -132,12 +241,12 @@ func nonactiveRevisions(revs []*v1.Revision, config *v1.Configuration) []*v1.Rev
 	return revs[:swap]
 }
 
-func isRevisionActive(rev *v1.Revision, config *v1.Configuration) bool {
+func isRevisionActive(rev *v1.Revision, config *v1.Configuration, p *policy) bool {
 	if config.Status.LatestReadyRevisionName == rev.Name {
 		return true // never delete latest ready, even if config is not active.
 	}
 
-	if strings.EqualFold(rev.Annotations[serving.RevisionPreservedAnnotationKey], "true") {
+	if strings.EqualFold(rev.Annotations[serving.RevisionPreservedAnnotationKey], "true") || p.isProtected(rev) {
 		return true
 	}
 	// Anything that the labeler hasn't explicitly labelled as inactive.
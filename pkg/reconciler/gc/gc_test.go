@@ -25,7 +25,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/clock"
 	clientgotesting "k8s.io/client-go/testing"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakenamespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	fakeservingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	fakerevisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision/fake"
@@ -404,6 +406,103 @@ func TestCollectSettings(t *testing.T) {
 	}
 }
 
+func TestCollectOverrides(t *testing.T) {
+	cfgMap := &config.Config{
+		RevisionGC: &gc.Config{
+			RetainSinceCreateTime:     time.Duration(gc.Disabled),
+			RetainSinceLastActiveTime: time.Duration(gc.Disabled),
+			MinNonActiveRevisions:     0,
+			MaxNonActiveRevisions:     1,
+		},
+	}
+
+	now := time.Now()
+	old := now.Add(-11 * time.Minute)
+	older := now.Add(-12 * time.Minute)
+	oldest := now.Add(-13 * time.Minute)
+	fc := clock.NewFakePassiveClock(now)
+
+	revs := []*v1.Revision{
+		rev("overrides-test", "foo", 5554, MarkRevisionReady,
+			WithRevName("5554"),
+			WithRevisionLabel("release", "stable"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(oldest)),
+		rev("overrides-test", "foo", 5555, MarkRevisionReady,
+			WithRevName("5555"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(older)),
+		rev("overrides-test", "foo", 5556, MarkRevisionReady,
+			WithRevName("5556"),
+			WithRoutingState(v1.RoutingStateActive, fc),
+			WithRoutingStateModified(old)),
+	}
+
+	deleteRev := func(name string) clientgotesting.DeleteActionImpl {
+		return clientgotesting.DeleteActionImpl{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  v1.SchemeGroupVersion.WithResource("revisions"),
+			},
+			Name: name,
+		}
+	}
+
+	table := []struct {
+		name        string
+		cfg         *v1.Configuration
+		ns          *corev1.Namespace
+		wantDeletes []clientgotesting.DeleteActionImpl
+	}{{
+		name:        "no overrides",
+		cfg:         cfg("overrides-test", "foo", 5556, WithLatestReady("5556")),
+		wantDeletes: []clientgotesting.DeleteActionImpl{deleteRev("5554")},
+	}, {
+		name: "configuration overrides max",
+		cfg: cfg("overrides-test", "foo", 5556, WithLatestReady("5556"),
+			WithConfigAnn(serving.GCMaxNonActiveRevisionsKey, "0")),
+		wantDeletes: []clientgotesting.DeleteActionImpl{deleteRev("5554"), deleteRev("5555")},
+	}, {
+		name: "namespace overrides max",
+		cfg:  cfg("overrides-test", "foo", 5556, WithLatestReady("5556")),
+		ns:   namespace("foo", serving.GCMaxNonActiveRevisionsKey, "disabled"),
+	}, {
+		name: "configuration overrides namespace",
+		cfg: cfg("overrides-test", "foo", 5556, WithLatestReady("5556"),
+			WithConfigAnn(serving.GCMaxNonActiveRevisionsKey, "0")),
+		ns:          namespace("foo", serving.GCMaxNonActiveRevisionsKey, "disabled"),
+		wantDeletes: []clientgotesting.DeleteActionImpl{deleteRev("5554"), deleteRev("5555")},
+	}, {
+		name: "namespace protects revisions",
+		cfg: cfg("overrides-test", "foo", 5556, WithLatestReady("5556"),
+			WithConfigAnn(serving.GCMaxNonActiveRevisionsKey, "0")),
+		ns:          namespace("foo", serving.GCProtectedRevisionsKey, "release in (stable,previous)"),
+		wantDeletes: []clientgotesting.DeleteActionImpl{deleteRev("5555")},
+	}, {
+		name: "namespace minimum exceeds configuration maximum",
+		cfg: cfg("overrides-test", "foo", 5556, WithLatestReady("5556"),
+			WithConfigAnn(serving.GCMaxNonActiveRevisionsKey, "0")),
+		ns:          namespace("foo", serving.GCMinNonActiveRevisionsKey, "2"),
+		wantDeletes: []clientgotesting.DeleteActionImpl{deleteRev("5554"), deleteRev("5555")},
+	}, {
+		name:        "invalid namespace override is ignored",
+		cfg:         cfg("overrides-test", "foo", 5556, WithLatestReady("5556")),
+		ns:          namespace("foo", serving.GCMaxNonActiveRevisionsKey, "many"),
+		wantDeletes: []clientgotesting.DeleteActionImpl{deleteRev("5554")},
+	}}
+
+	for _, test := range table {
+		t.Run(test.name, func(t *testing.T) {
+			var namespaces []*corev1.Namespace
+			if test.ns != nil {
+				namespaces = append(namespaces, test.ns)
+			}
+			runTest(t, cfgMap, revs, test.cfg, test.wantDeletes, namespaces...)
+		})
+	}
+}
+
 func TestGCInOrder(t *testing.T) {
 	now := time.Now()
 	old1 := now.Add(-11 * time.Minute)
@@ -522,7 +621,8 @@ func runTest(
 	cfgMap *config.Config,
 	revs []*v1.Revision,
 	cfg *v1.Configuration,
-	wantDeletes []clientgotesting.DeleteActionImpl) {
+	wantDeletes []clientgotesting.DeleteActionImpl,
+	namespaces ...*corev1.Namespace) {
 	t.Helper()
 	ctx, _ := SetupFakeContext(t)
 	ctx = config.ToContext(ctx, cfgMap)
@@ -532,10 +632,14 @@ func runTest(
 	for _, rev := range revs {
 		ri.Informer().GetIndexer().Add(rev)
 	}
+	ni := fakenamespaceinformer.Get(ctx)
+	for _, ns := range namespaces {
+		ni.Informer().GetIndexer().Add(ns)
+	}
 
 	recorderList := ActionRecorderList{client}
 
-	collect(ctx, client, ri.Lister(), cfg)
+	collect(ctx, client, ri.Lister(), ni.Lister(), cfg)
 
 	actions, err := recorderList.ActionsByVerb()
 	if err != nil {
@@ -644,3 +748,12 @@ func TestIsRevisionStale(t *testing.T) {
 		})
 	}
 }
+
+func namespace(name, annotationKey, annotationValue string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{annotationKey: annotationValue},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/gc"
+)
+
+// policy is the garbage collection policy of the Revisions of a
+// Configuration: config-gc, overridden by the annotations of the Namespace of
+// the Configuration, and then by the annotations of the Configuration.
+type policy struct {
+	gc.Config
+
+	// protected select the Revisions which are never collected, in addition
+	// to the Revisions annotated with serving.knative.dev/no-gc.
+	protected []labels.Selector
+}
+
+// newPolicy overrides the config with each of the annotations in order. The
+// annotations of Namespaces are not validated by the webhook, so the invalid
+// overrides are logged and ignored.
+func newPolicy(cfg *gc.Config, logger *zap.SugaredLogger, annotations ...map[string]string) *policy {
+	p := &policy{Config: *cfg}
+	for _, annos := range annotations {
+		if k, v, _ := serving.GCRetainSinceCreateTimeAnnotation.Get(annos); v != "" {
+			if err := serving.ParseDisabledOrDuration(v, &p.RetainSinceCreateTime); err != nil {
+				logger.Warnw("Ignoring invalid GC override "+k, zap.Error(err))
+			}
+		}
+		if k, v, _ := serving.GCRetainSinceLastActiveTimeAnnotation.Get(annos); v != "" {
+			if err := serving.ParseDisabledOrDuration(v, &p.RetainSinceLastActiveTime); err != nil {
+				logger.Warnw("Ignoring invalid GC override "+k, zap.Error(err))
+			}
+		}
+		if k, v, _ := serving.GCMinNonActiveRevisionsAnnotation.Get(annos); v != "" {
+			if min, err := strconv.ParseUint(v, 10, 64); err != nil {
+				logger.Warnw("Ignoring invalid GC override "+k, zap.Error(err))
+			} else {
+				p.MinNonActiveRevisions = int64(min)
+			}
+		}
+		if k, v, _ := serving.GCMaxNonActiveRevisionsAnnotation.Get(annos); v != "" {
+			if err := serving.ParseDisabledOrInt64(v, &p.MaxNonActiveRevisions); err != nil {
+				logger.Warnw("Ignoring invalid GC override "+k, zap.Error(err))
+			}
+		}
+		// The protections of the Namespace and of the Configuration add up.
+		if k, v, _ := serving.GCProtectedRevisionsAnnotation.Get(annos); v != "" {
+			if selector, err := labels.Parse(v); err != nil {
+				logger.Warnw("Ignoring invalid GC override "+k, zap.Error(err))
+			} else {
+				p.protected = append(p.protected, selector)
+			}
+		}
+	}
+
+	// The overrides of different objects may conflict, e.g. the minimum of a
+	// Configuration may exceed the maximum of its Namespace.
+	if p.MaxNonActiveRevisions != gc.Disabled && p.MinNonActiveRevisions > p.MaxNonActiveRevisions {
+		p.MinNonActiveRevisions = p.MaxNonActiveRevisions
+	}
+	return p
+}
+
+// isProtected returns whether a protected selector selects the Revision.
+func (p *policy) isProtected(rev *v1.Revision) bool {
+	for _, selector := range p.protected {
+		if selector.Matches(labels.Set(rev.Labels)) {
+			return true
+		}
+	}
+	return false
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	clientset "knative.dev/serving/pkg/client/clientset/versioned"
@@ -32,7 +33,8 @@ type reconciler struct {
 	client clientset.Interface
 
 	// listers index properties about resources
-	revisionLister listers.RevisionLister
+	revisionLister  listers.RevisionLister
+	namespaceLister corev1listers.NamespaceLister
 }
 
 // Check that our reconciler implements configreconciler.Interface
@@ -43,5 +45,5 @@ func (c *reconciler) ReconcileKind(ctx context.Context, config *v1.Configuration
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	return collect(ctx, c.client, c.revisionLister, config)
+	return collect(ctx, c.client, c.revisionLister, c.namespaceLister, config)
 }
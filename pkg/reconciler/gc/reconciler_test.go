@@ -18,18 +18,23 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgotesting "k8s.io/client-go/testing"
 
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgrec "knative.dev/pkg/reconciler"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	configreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/configuration"
@@ -45,6 +50,10 @@ import (
 	. "knative.dev/serving/pkg/testing/v1"
 )
 
+// staleRule is the rule the stale Revisions are collected by under the
+// configs of the tests.
+const staleRule = "it is stale under retain-since-create-time=5m0s and retain-since-last-active-time=5m0s"
+
 func TestGCReconcile(t *testing.T) {
 	now := time.Now()
 
@@ -52,17 +61,12 @@ func TestGCReconcile(t *testing.T) {
 	older := now.Add(-12 * time.Minute)
 	oldest := now.Add(-13 * time.Minute)
 
-	controllerOpts := controller.Options{
-		ConfigStore: &testConfigStore{
-			config: &config.Config{
-				RevisionGC: &gc.Config{
-					RetainSinceCreateTime:     5 * time.Minute,
-					RetainSinceLastActiveTime: 5 * time.Minute,
-					MinNonActiveRevisions:     1,
-					MaxNonActiveRevisions:     gc.Disabled,
-				},
-			},
-		}}
+	gcConfig := &gc.Config{
+		RetainSinceCreateTime:     5 * time.Minute,
+		RetainSinceLastActiveTime: 5 * time.Minute,
+		MinNonActiveRevisions:     1,
+		MaxNonActiveRevisions:     gc.Disabled,
+	}
 
 	fc := clock.NewFakePassiveClock(time.Now())
 	table := TableTest{{
@@ -93,18 +97,208 @@ func TestGCReconcile(t *testing.T) {
 			},
 			Name: "5554",
 		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "RevisionDeleted", `Deleted Revision "5554" as it is stale under retain-since-create-time=5m0s and retain-since-last-active-time=5m0s`),
+		},
+		Key: "foo/keep-two",
+	}, {
+		Name: "namespace overrides the minimum",
+		Objects: []runtime.Object{
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+					Annotations: map[string]string{
+						serving.GCMinNonActiveRevisionsKey: "2",
+					},
+				},
+			},
+			cfg("keep-two", "foo", 5556,
+				WithLatestCreated("5556"),
+				WithLatestReady("5556"),
+				WithConfigObservedGen),
+			rev("keep-two", "foo", 5554, MarkRevisionReady,
+				WithRevName("5554"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(oldest)),
+			rev("keep-two", "foo", 5555, MarkRevisionReady,
+				WithRevName("5555"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(older)),
+			rev("keep-two", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old)),
+		},
+		Key: "foo/keep-two",
+	}, {
+		Name: "delete clears the candidates of a former dry run",
+		Objects: []runtime.Object{
+			cfg("keep-two", "foo", 5556,
+				WithLatestCreated("5556"),
+				WithLatestReady("5556"),
+				WithConfigObservedGen,
+				WithGCCandidates("5554", "5555")),
+			rev("keep-two", "foo", 5554, MarkRevisionReady,
+				WithRevName("5554"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(oldest),
+				WithRevisionAnn(serving.RevisionGCCandidateAnnotationKey, staleRule)),
+			rev("keep-two", "foo", 5555, MarkRevisionReady,
+				WithRevName("5555"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(older),
+				WithRevisionAnn(serving.RevisionGCCandidateAnnotationKey, staleRule)),
+			rev("keep-two", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old)),
+		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  v1.SchemeGroupVersion.WithResource("revisions"),
+			},
+			Name: "5554",
+		}},
+		// The deleted Revision keeps its annotation.
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchGCCandidate("foo", "5555", nil),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "RevisionDeleted", `Deleted Revision "5554" as it is stale under retain-since-create-time=5m0s and retain-since-last-active-time=5m0s`),
+		},
 		Key: "foo/keep-two",
 	}}
 
-	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+	table.Test(t, makeFactory(gcConfig))
+}
+
+func TestGCReconcileDryRun(t *testing.T) {
+	now := time.Now()
+
+	old := now.Add(-11 * time.Minute)
+	older := now.Add(-12 * time.Minute)
+	oldest := now.Add(-13 * time.Minute)
+
+	gcConfig := &gc.Config{
+		RetainSinceCreateTime:     5 * time.Minute,
+		RetainSinceLastActiveTime: 5 * time.Minute,
+		MinNonActiveRevisions:     0,
+		MaxNonActiveRevisions:     gc.Disabled,
+		DryRun:                    true,
+	}
+
+	fc := clock.NewFakePassiveClock(time.Now())
+	// revs returns the Revisions of the Configuration, those named annotated
+	// as candidates.
+	revs := func(reported ...string) []runtime.Object {
+		names := sets.NewString(reported...)
+		annotate := func(rev *v1.Revision) {
+			if names.Has(rev.Name) {
+				WithRevisionAnn(serving.RevisionGCCandidateAnnotationKey, staleRule)(rev)
+			}
+		}
+		return []runtime.Object{
+			rev("keep-one", "foo", 5554, MarkRevisionReady,
+				WithRevName("5554"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(oldest),
+				annotate),
+			rev("keep-one", "foo", 5555, MarkRevisionReady,
+				WithRevName("5555"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(older),
+				annotate),
+			rev("keep-one", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old),
+				annotate),
+		}
+	}
+	keepOne := cfg("keep-one", "foo", 5556,
+		WithLatestCreated("5556"),
+		WithLatestReady("5556"),
+		WithConfigObservedGen)
+
+	rule := staleRule
+	table := TableTest{{
+		Name:    "report candidates",
+		Objects: append([]runtime.Object{keepOne}, revs()...),
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchGCCandidate("foo", "5554", &rule),
+			patchGCCandidate("foo", "5555", &rule),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "RevisionGCCandidate", `Revision "5554" would be deleted as `+staleRule),
+			Eventf(corev1.EventTypeNormal, "RevisionGCCandidate", `Revision "5555" would be deleted as `+staleRule),
+		},
+		Key: "foo/keep-one",
+	}, {
+		Name:    "report new candidates only",
+		Objects: append([]runtime.Object{keepOne}, revs("5554")...),
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchGCCandidate("foo", "5555", &rule),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "RevisionGCCandidate", `Revision "5555" would be deleted as `+staleRule),
+		},
+		Key: "foo/keep-one",
+	}, {
+		Name:    "candidates already reported",
+		Objects: append([]runtime.Object{keepOne}, revs("5554", "5555")...),
+		Key:     "foo/keep-one",
+	}, {
+		Name:    "clear former candidates",
+		Objects: append([]runtime.Object{keepOne}, revs("5554", "5555", "5556")...),
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchGCCandidate("foo", "5556", nil),
+		},
+		Key: "foo/keep-one",
+	}}
+
+	table.Test(t, makeFactory(gcConfig))
+}
+
+func makeFactory(gcConfig *gc.Config) Factory {
+	controllerOpts := controller.Options{
+		ConfigStore: &testConfigStore{
+			config: &config.Config{
+				RevisionGC: gcConfig,
+			},
+		}}
+
+	return MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
 		r := &reconciler{
-			client:         servingclient.Get(ctx),
-			revisionLister: listers.GetRevisionLister(),
+			client:          servingclient.Get(ctx),
+			revisionLister:  listers.GetRevisionLister(),
+			namespaceLister: listers.GetNamespaceLister(),
 		}
 		return configreconciler.NewReconciler(ctx, logging.FromContext(ctx),
 			servingclient.Get(ctx), listers.GetConfigurationLister(),
 			controller.GetEventRecorder(ctx), r, controllerOpts)
-	}))
+	})
+}
+
+func patchGCCandidate(namespace, name string, rule *string) clientgotesting.PatchActionImpl {
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				serving.RevisionGCCandidateAnnotationKey: rule,
+			},
+		},
+	})
+	return clientgotesting.PatchActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: namespace,
+			Verb:      "patch",
+			Resource:  v1.SchemeGroupVersion.WithResource("revisions"),
+		},
+		Name:      name,
+		PatchType: types.MergePatchType,
+		Patch:     patch,
+	}
 }
 
 func cfg(name, namespace string, generation int64, co ...ConfigOption) *v1.Configuration {
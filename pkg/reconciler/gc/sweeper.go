@@ -0,0 +1,314 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	cachingclientset "knative.dev/caching/pkg/client/clientset/versioned"
+	cachinglisters "knative.dev/caching/pkg/client/listers/caching/v1alpha1"
+	netclientset "knative.dev/networking/pkg/client/clientset/versioned"
+	netlisters "knative.dev/networking/pkg/client/listers/networking/v1alpha1"
+	namespacereconciler "knative.dev/pkg/client/injection/kube/reconciler/core/v1/namespace"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/serving"
+	clientset "knative.dev/serving/pkg/client/clientset/versioned"
+	palisters "knative.dev/serving/pkg/client/listers/autoscaling/v1alpha1"
+	listers "knative.dev/serving/pkg/client/listers/serving/v1"
+	"knative.dev/serving/pkg/gc"
+	configns "knative.dev/serving/pkg/reconciler/gc/config"
+)
+
+// orphanGracePeriod is the age under which child resources are not swept, as
+// the informers may not have observed their owners yet.
+const orphanGracePeriod = 10 * time.Minute
+
+// revisionChildSelector selects the child resources of Revisions.
+var revisionChildSelector = func() labels.Selector {
+	req, _ := labels.NewRequirement(serving.RevisionLabelKey, selection.Exists, nil)
+	return labels.NewSelector().Add(*req)
+}()
+
+// childKind lists and deletes the child resources of Revisions of a kind.
+type childKind struct {
+	name   string
+	list   func(namespace string) ([]metav1.Object, error)
+	delete func(ctx context.Context, namespace, name string) error
+}
+
+// sweeper deletes the child resources of Revisions which outlived their
+// Revision or their Configuration, e.g. when the Kubernetes garbage collector
+// missed them.
+type sweeper struct {
+	configurationLister listers.ConfigurationLister
+	revisionLister      listers.RevisionLister
+	paLister            palisters.PodAutoscalerLister
+	kinds               []childKind
+
+	// reported holds, by Namespace, the orphans reported in dry-run mode, so
+	// that they are reported again only when they change.
+	mu       sync.Mutex
+	reported map[string]sets.String
+}
+
+// Check that our sweeper implements namespacereconciler.Interface
+var _ namespacereconciler.Interface = (*sweeper)(nil)
+
+func newSweeper(
+	client clientset.Interface,
+	netclient netclientset.Interface,
+	cachingclient cachingclientset.Interface,
+	configurationLister listers.ConfigurationLister,
+	revisionLister listers.RevisionLister,
+	paLister palisters.PodAutoscalerLister,
+	metricLister palisters.MetricLister,
+	sksLister netlisters.ServerlessServiceLister,
+	imageLister cachinglisters.ImageLister,
+) *sweeper {
+	// PodAutoscalers come first, as the Kubernetes garbage collector also
+	// deletes their Metrics and ServerlessServices.
+	return &sweeper{
+		configurationLister: configurationLister,
+		revisionLister:      revisionLister,
+		paLister:            paLister,
+		reported:            make(map[string]sets.String),
+		kinds: []childKind{{
+			name: "PodAutoscaler",
+			list: func(ns string) ([]metav1.Object, error) {
+				pas, err := paLister.PodAutoscalers(ns).List(revisionChildSelector)
+				objs := make([]metav1.Object, 0, len(pas))
+				for _, pa := range pas {
+					objs = append(objs, pa)
+				}
+				return objs, err
+			},
+			delete: func(ctx context.Context, ns, name string) error {
+				return client.AutoscalingV1alpha1().PodAutoscalers(ns).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		}, {
+			name: "Metric",
+			list: func(ns string) ([]metav1.Object, error) {
+				metrics, err := metricLister.Metrics(ns).List(revisionChildSelector)
+				objs := make([]metav1.Object, 0, len(metrics))
+				for _, metric := range metrics {
+					objs = append(objs, metric)
+				}
+				return objs, err
+			},
+			delete: func(ctx context.Context, ns, name string) error {
+				return client.AutoscalingV1alpha1().Metrics(ns).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		}, {
+			name: "ServerlessService",
+			list: func(ns string) ([]metav1.Object, error) {
+				sks, err := sksLister.ServerlessServices(ns).List(revisionChildSelector)
+				objs := make([]metav1.Object, 0, len(sks))
+				for _, s := range sks {
+					objs = append(objs, s)
+				}
+				return objs, err
+			},
+			delete: func(ctx context.Context, ns, name string) error {
+				return netclient.NetworkingV1alpha1().ServerlessServices(ns).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		}, {
+			name: "Image",
+			list: func(ns string) ([]metav1.Object, error) {
+				images, err := imageLister.Images(ns).List(revisionChildSelector)
+				objs := make([]metav1.Object, 0, len(images))
+				for _, image := range images {
+					objs = append(objs, image)
+				}
+				return objs, err
+			},
+			delete: func(ctx context.Context, ns, name string) error {
+				return cachingclient.CachingV1alpha1().Images(ns).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		}},
+	}
+}
+
+// ReconcileKind sweeps the orphaned child resources of the Namespace. The
+// Namespace is only requeued when the sweep deferred some of the orphans, to
+// the end of their grace period or to the next sweep when too many of them
+// were deleted.
+func (s *sweeper) ReconcileKind(ctx context.Context, ns *corev1.Namespace) pkgreconciler.Event {
+	cfg := configns.FromContext(ctx).RevisionGC
+	if cfg.OrphanSweepPeriod == gc.Disabled {
+		return nil
+	}
+	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
+
+	var (
+		deleted int64
+		requeue time.Duration
+	)
+	reported, orphans := s.reportedOrphans(ns.Name), sets.NewString()
+	for _, kind := range s.kinds {
+		objs, err := kind.list(ns.Name)
+		if err != nil {
+			return fmt.Errorf("failed to list %ss: %w", kind.name, err)
+		}
+		for _, obj := range objs {
+			reason, err := s.orphanReason(obj)
+			if err != nil {
+				return err
+			}
+			if reason == "" {
+				continue
+			}
+			if age := time.Since(obj.GetCreationTimestamp().Time); age < orphanGracePeriod {
+				if d := orphanGracePeriod - age; requeue == 0 || d < requeue {
+					requeue = d
+				}
+				continue
+			}
+
+			if cfg.DryRun {
+				key := kind.name + "/" + obj.GetName()
+				if !reported.Has(key) {
+					recorder.Eventf(ns, corev1.EventTypeNormal, "OrphanGCCandidate",
+						"%s %q would be deleted as %s", kind.name, obj.GetName(), reason)
+				}
+				orphans.Insert(key)
+				continue
+			}
+			if deleted >= cfg.MaxOrphanDeletionsPerSweep {
+				logger.Infof("Deleted %d orphaned resources, deferring the others to the next sweep", deleted)
+				return controller.NewRequeueAfter(cfg.OrphanSweepPeriod)
+			}
+			if err := kind.delete(ctx, ns.Name, obj.GetName()); err != nil && !apierrs.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s %q: %w", kind.name, obj.GetName(), err)
+			}
+			recorder.Eventf(ns, corev1.EventTypeNormal, "OrphanDeleted",
+				"Deleted %s %q as %s", kind.name, obj.GetName(), reason)
+			deleted++
+		}
+	}
+	s.setReportedOrphans(ns.Name, orphans)
+
+	if requeue > 0 {
+		return controller.NewRequeueAfter(requeue)
+	}
+	return nil
+}
+
+// reportedOrphans returns the orphans of the Namespace reported in dry-run
+// mode by the previous sweep.
+func (s *sweeper) reportedOrphans(ns string) sets.String {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reported[ns]
+}
+
+// setReportedOrphans records the orphans of the Namespace reported in dry-run
+// mode by the sweep.
+func (s *sweeper) setReportedOrphans(ns string, orphans sets.String) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if orphans.Len() == 0 {
+		delete(s.reported, ns)
+	} else {
+		s.reported[ns] = orphans
+	}
+}
+
+// orphanReason returns why the child resource is orphaned, or "" when its
+// controller, its Revision and its Configuration are live.
+func (s *sweeper) orphanReason(obj metav1.Object) (string, error) {
+	if ref := metav1.GetControllerOf(obj); ref != nil {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return "", err
+		}
+		switch gk := gv.WithKind(ref.Kind).GroupKind(); {
+		case gk == schema.GroupKind{Group: serving.GroupName, Kind: "Revision"}:
+			if live, err := s.isLiveRevision(obj.GetNamespace(), ref.Name, ref.UID); err != nil || !live {
+				return fmt.Sprintf("its controller Revision %q no longer exists", ref.Name), err
+			}
+		case gk == schema.GroupKind{Group: autoscaling.InternalGroupName, Kind: "PodAutoscaler"}:
+			if live, err := s.isLivePodAutoscaler(obj.GetNamespace(), ref.Name, ref.UID); err != nil || !live {
+				return fmt.Sprintf("its controller PodAutoscaler %q no longer exists", ref.Name), err
+			}
+		}
+	}
+
+	name := obj.GetLabels()[serving.RevisionLabelKey]
+	uid := types.UID(obj.GetLabels()[serving.RevisionUID])
+	if live, err := s.isLiveRevision(obj.GetNamespace(), name, uid); err != nil || !live {
+		return fmt.Sprintf("its Revision %q no longer exists", name), err
+	}
+
+	// The Revision itself outlived its Configuration.
+	if name := obj.GetLabels()[serving.ConfigurationLabelKey]; name != "" {
+		uid := types.UID(obj.GetLabels()[serving.ConfigurationUIDLabelKey])
+		if live, err := s.isLiveConfiguration(obj.GetNamespace(), name, uid); err != nil || !live {
+			return fmt.Sprintf("its Configuration %q no longer exists", name), err
+		}
+	}
+	return "", nil
+}
+
+// isLiveConfiguration returns whether the Configuration exists, with the UID
+// if any.
+func (s *sweeper) isLiveConfiguration(ns, name string, uid types.UID) (bool, error) {
+	config, err := s.configurationLister.Configurations(ns).Get(name)
+	if apierrs.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return uid == "" || config.UID == uid, nil
+}
+
+// isLiveRevision returns whether the Revision exists, with the UID if any.
+func (s *sweeper) isLiveRevision(ns, name string, uid types.UID) (bool, error) {
+	rev, err := s.revisionLister.Revisions(ns).Get(name)
+	if apierrs.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return uid == "" || rev.UID == uid, nil
+}
+
+// isLivePodAutoscaler returns whether the PodAutoscaler exists, with the UID
+// if any.
+func (s *sweeper) isLivePodAutoscaler(ns, name string, uid types.UID) (bool, error) {
+	pa, err := s.paLister.PodAutoscalers(ns).Get(name)
+	if apierrs.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return uid == "" || pa.UID == uid, nil
+}
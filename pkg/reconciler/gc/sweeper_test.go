@@ -0,0 +1,387 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	caching "knative.dev/caching/pkg/apis/caching/v1alpha1"
+	fakecachingclient "knative.dev/caching/pkg/client/injection/client/fake"
+	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	fakenetclient "knative.dev/networking/pkg/client/injection/client/fake"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	namespacereconciler "knative.dev/pkg/client/injection/kube/reconciler/core/v1/namespace"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
+	"knative.dev/serving/pkg/gc"
+	"knative.dev/serving/pkg/reconciler/gc/config"
+
+	. "knative.dev/pkg/reconciler/testing"
+	. "knative.dev/serving/pkg/reconciler/testing/v1"
+)
+
+func TestSweeperReconcile(t *testing.T) {
+	sweepConfig := func(dryRun bool, maxDeletions int64) *gc.Config {
+		return &gc.Config{
+			OrphanSweepPeriod:          time.Hour,
+			MaxOrphanDeletionsPerSweep: maxDeletions,
+			DryRun:                     dryRun,
+		}
+	}
+	old := time.Now().Add(-time.Hour)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	liveConfig := &v1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "foo",
+			UID:       "config-uid",
+		},
+	}
+	liveRev := orphanRev("live", "live-uid")
+	// The Revision outlived its Configuration.
+	strandedRev := orphanRev("stranded", "stranded-uid")
+	strandedRev.Labels[serving.ConfigurationUIDLabelKey] = "former-config-uid"
+	livePA := childPA(liveRev, old)
+	objects := func() []runtime.Object {
+		return []runtime.Object{
+			ns,
+			liveConfig,
+			liveRev,
+			livePA,
+			childMetric(livePA, old),
+			childImage(liveRev, old),
+			// The Revision of the PodAutoscaler was deleted.
+			childPA(orphanRev("deleted", "deleted-uid"), old),
+			// The PodAutoscaler of the Metric was deleted.
+			childMetric(childPA(liveRev, old, withName("deleted-pa")), old),
+			// The Revision of the Image was recreated.
+			childImage(orphanRev("live", "former-uid"), old),
+			// The ServerlessService is too young to be swept.
+			childSKS(childPA(orphanRev("deleted", "deleted-uid"), old), time.Now()),
+		}
+	}
+
+	table := TableTest{{
+		Name: "bad workqueue key",
+		Key:  "too/many/parts",
+	}, {
+		Name: "key not found",
+		Key:  "foo",
+	}, {
+		Name: "sweeps disabled",
+		Key:  "foo",
+		Ctx: withGCConfig(context.Background(), &gc.Config{
+			OrphanSweepPeriod:          gc.Disabled,
+			MaxOrphanDeletionsPerSweep: 50,
+		}),
+		Objects: objects(),
+	}, {
+		Name:    "nothing to sweep",
+		Key:     "foo",
+		Ctx:     withGCConfig(context.Background(), sweepConfig(false, 50)),
+		Objects: []runtime.Object{ns, liveConfig, liveRev, livePA, childMetric(livePA, old)},
+	}, {
+		Name:    "delete orphans",
+		Key:     "foo",
+		Ctx:     withGCConfig(context.Background(), sweepConfig(false, 50)),
+		Objects: objects(),
+		WantErr: true,
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  autoscalingv1alpha1.SchemeGroupVersion.WithResource("podautoscalers"),
+			},
+			Name: "deleted",
+		}, {
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  autoscalingv1alpha1.SchemeGroupVersion.WithResource("metrics"),
+			},
+			Name: "deleted-pa",
+		}, {
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  caching.SchemeGroupVersion.WithResource("images"),
+			},
+			Name: "live-former-uid-cache",
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "OrphanDeleted", `Deleted PodAutoscaler "deleted" as its controller Revision "deleted" no longer exists`),
+			Eventf(corev1.EventTypeNormal, "OrphanDeleted", `Deleted Metric "deleted-pa" as its controller PodAutoscaler "deleted-pa" no longer exists`),
+			Eventf(corev1.EventTypeNormal, "OrphanDeleted", `Deleted Image "live-former-uid-cache" as its controller Revision "live" no longer exists`),
+		},
+	}, {
+		Name:    "rate limited",
+		Key:     "foo",
+		Ctx:     withGCConfig(context.Background(), sweepConfig(false, 1)),
+		Objects: objects(),
+		WantErr: true,
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  autoscalingv1alpha1.SchemeGroupVersion.WithResource("podautoscalers"),
+			},
+			Name: "deleted",
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "OrphanDeleted", `Deleted PodAutoscaler "deleted" as its controller Revision "deleted" no longer exists`),
+		},
+	}, {
+		Name:    "dry run",
+		Key:     "foo",
+		Ctx:     withGCConfig(context.Background(), sweepConfig(true, 1)),
+		Objects: objects(),
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "OrphanGCCandidate", `PodAutoscaler "deleted" would be deleted as its controller Revision "deleted" no longer exists`),
+			Eventf(corev1.EventTypeNormal, "OrphanGCCandidate", `Metric "deleted-pa" would be deleted as its controller PodAutoscaler "deleted-pa" no longer exists`),
+			Eventf(corev1.EventTypeNormal, "OrphanGCCandidate", `Image "live-former-uid-cache" would be deleted as its controller Revision "live" no longer exists`),
+		},
+	}, {
+		Name: "orphaned by labels only",
+		Key:  "foo",
+		Ctx:  withGCConfig(context.Background(), sweepConfig(false, 50)),
+		Objects: []runtime.Object{
+			ns,
+			liveConfig,
+			liveRev,
+			childImage(orphanRev("deleted", "deleted-uid"), old, withoutOwner),
+		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  caching.SchemeGroupVersion.WithResource("images"),
+			},
+			Name: "deleted-deleted-uid-cache",
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "OrphanDeleted", `Deleted Image "deleted-deleted-uid-cache" as its Revision "deleted" no longer exists`),
+		},
+	}, {
+		Name: "orphaned by the Configuration",
+		Key:  "foo",
+		Ctx:  withGCConfig(context.Background(), sweepConfig(false, 50)),
+		Objects: []runtime.Object{
+			ns,
+			liveConfig,
+			strandedRev,
+			childImage(strandedRev, old),
+		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  caching.SchemeGroupVersion.WithResource("images"),
+			},
+			Name: "stranded-stranded-uid-cache",
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "OrphanDeleted", `Deleted Image "stranded-stranded-uid-cache" as its Configuration "config" no longer exists`),
+		},
+	}}
+
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		s := newSweeper(
+			servingclient.Get(ctx),
+			fakenetclient.Get(ctx),
+			fakecachingclient.Get(ctx),
+			listers.GetConfigurationLister(),
+			listers.GetRevisionLister(),
+			listers.GetPodAutoscalerLister(),
+			listers.GetMetricLister(),
+			listers.GetServerlessServiceLister(),
+			listers.GetImageLister(),
+		)
+		return namespacereconciler.NewReconciler(ctx, logging.FromContext(ctx), fakekubeclient.Get(ctx),
+			listers.GetNamespaceLister(), controller.GetEventRecorder(ctx), s,
+			controller.Options{ConfigStore: &ctxConfigStore{}})
+	}))
+}
+
+func TestSweeperDryRunReportsChanges(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	liveConfig := &v1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "foo",
+			UID:       "config-uid",
+		},
+	}
+	deletedPA := childPA(orphanRev("deleted", "deleted-uid"), old)
+
+	sweep := func(s *sweeper, recorder *record.FakeRecorder) {
+		t.Helper()
+		ctx := controller.WithEventRecorder(withGCConfig(context.Background(), &gc.Config{
+			OrphanSweepPeriod:          time.Hour,
+			MaxOrphanDeletionsPerSweep: 50,
+			DryRun:                     true,
+		}), recorder)
+		if err := s.ReconcileKind((&ctxConfigStore{}).ToContext(ctx), ns); err != nil {
+			t.Fatal("ReconcileKind() =", err)
+		}
+	}
+	newSweeperOf := func(objs ...runtime.Object) *sweeper {
+		listers := NewListers(objs)
+		// The clients are not used in dry-run mode.
+		return newSweeper(nil, nil, nil,
+			listers.GetConfigurationLister(),
+			listers.GetRevisionLister(),
+			listers.GetPodAutoscalerLister(),
+			listers.GetMetricLister(),
+			listers.GetServerlessServiceLister(),
+			listers.GetImageLister())
+	}
+
+	s := newSweeperOf(ns, liveConfig, deletedPA)
+	recorder := record.NewFakeRecorder(10)
+	sweep(s, recorder)
+	sweep(s, recorder)
+	if got, want := len(recorder.Events), 1; got != want {
+		t.Errorf("Events after unchanged sweeps = %d, want: %d", got, want)
+	}
+
+	// A new orphan is reported alone.
+	next := newSweeperOf(ns, liveConfig, deletedPA, childImage(orphanRev("deleted", "deleted-uid"), old))
+	next.reported = s.reported
+	sweep(next, recorder)
+	if got, want := len(recorder.Events), 2; got != want {
+		t.Errorf("Events after a new orphan = %d, want: %d", got, want)
+	}
+}
+
+type gcConfigKey struct{}
+
+func withGCConfig(ctx context.Context, cfg *gc.Config) context.Context {
+	return context.WithValue(ctx, gcConfigKey{}, cfg)
+}
+
+// ctxConfigStore reads the GC config of each table row from its context.
+type ctxConfigStore struct{}
+
+func (*ctxConfigStore) ToContext(ctx context.Context) context.Context {
+	cfg, _ := ctx.Value(gcConfigKey{}).(*gc.Config)
+	return config.ToContext(ctx, &config.Config{RevisionGC: cfg})
+}
+
+// orphanRev returns a Revision of the Configuration "config", which does not
+// exist in the listers unless added to them.
+func orphanRev(name string, uid types.UID) *v1.Revision {
+	return &v1.Revision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+			UID:       uid,
+			Labels: map[string]string{
+				serving.ConfigurationLabelKey:    "config",
+				serving.ConfigurationUIDLabelKey: "config-uid",
+			},
+		},
+	}
+}
+
+type childOption func(metav1.Object)
+
+func withName(name string) childOption {
+	return func(obj metav1.Object) {
+		obj.SetName(name)
+		obj.SetUID(types.UID(name + "-uid"))
+	}
+}
+
+func withoutOwner(obj metav1.Object) {
+	obj.SetOwnerReferences(nil)
+}
+
+func childMeta(owner kmeta.OwnerRefable, rev *v1.Revision, name string, created time.Time) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         rev.Namespace,
+		UID:               types.UID(name + "-uid"),
+		CreationTimestamp: metav1.NewTime(created),
+		Labels: map[string]string{
+			serving.ConfigurationLabelKey:    rev.Labels[serving.ConfigurationLabelKey],
+			serving.ConfigurationUIDLabelKey: rev.Labels[serving.ConfigurationUIDLabelKey],
+			serving.RevisionLabelKey:         rev.Name,
+			serving.RevisionUID:              string(rev.UID),
+		},
+		OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(owner)},
+	}
+}
+
+func childPA(rev *v1.Revision, created time.Time, opts ...childOption) *autoscalingv1alpha1.PodAutoscaler {
+	pa := &autoscalingv1alpha1.PodAutoscaler{
+		ObjectMeta: childMeta(rev, rev, rev.Name, created),
+	}
+	for _, opt := range opts {
+		opt(pa)
+	}
+	return pa
+}
+
+func childMetric(pa *autoscalingv1alpha1.PodAutoscaler, created time.Time) *autoscalingv1alpha1.Metric {
+	rev := orphanRev(pa.Labels[serving.RevisionLabelKey], types.UID(pa.Labels[serving.RevisionUID]))
+	return &autoscalingv1alpha1.Metric{
+		ObjectMeta: childMeta(pa, rev, pa.Name, created),
+	}
+}
+
+func childSKS(pa *autoscalingv1alpha1.PodAutoscaler, created time.Time) *netv1alpha1.ServerlessService {
+	rev := orphanRev(pa.Labels[serving.RevisionLabelKey], types.UID(pa.Labels[serving.RevisionUID]))
+	return &netv1alpha1.ServerlessService{
+		ObjectMeta: childMeta(pa, rev, pa.Name, created),
+	}
+}
+
+func childImage(rev *v1.Revision, created time.Time, opts ...childOption) *caching.Image {
+	image := &caching.Image{
+		ObjectMeta: childMeta(rev, rev, rev.Name+"-"+string(rev.UID)+"-cache", created),
+	}
+	for _, opt := range opts {
+		opt(image)
+	}
+	return image
+}
@@ -19,6 +19,7 @@ package config
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	network "knative.dev/networking/pkg"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
@@ -26,6 +27,7 @@ import (
 	pkgtracing "knative.dev/pkg/tracing/config"
 	apiconfig "knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/deployment"
+	pkghttp "knative.dev/serving/pkg/http"
 )
 
 type cfgKey struct{}
@@ -37,9 +39,33 @@ type Config struct {
 	Logging       *logging.Config
 	Network       *network.Config
 	Observability *metrics.ObservabilityConfig
+	RequestLog    *pkghttp.RequestLogConfig
 	Tracing       *pkgtracing.Config
 }
 
+// ObservabilityConfig holds everything parsed from config-observability,
+// since the store can only keep a single value per ConfigMap.
+type ObservabilityConfig struct {
+	Metrics    *metrics.ObservabilityConfig
+	RequestLog *pkghttp.RequestLogConfig
+}
+
+// NewObservabilityConfigFromConfigMap creates an ObservabilityConfig from the supplied ConfigMap.
+func NewObservabilityConfigFromConfigMap(configMap *corev1.ConfigMap) (*ObservabilityConfig, error) {
+	obs, err := metrics.NewObservabilityConfigFromConfigMap(configMap)
+	if err != nil {
+		return nil, err
+	}
+	rl, err := pkghttp.NewRequestLogConfigFromConfigMap(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &ObservabilityConfig{
+		Metrics:    obs,
+		RequestLog: rl,
+	}, nil
+}
+
 // FromContext loads the configuration from the context.
 func FromContext(ctx context.Context) *Config {
 	return ctx.Value(cfgKey{}).(*Config)
@@ -50,6 +76,16 @@ func ToContext(ctx context.Context, c *Config) context.Context {
 	return context.WithValue(ctx, cfgKey{}, c)
 }
 
+// WithNamespaceFeatures returns the provided context with the Features of its
+// configuration overridden by the annotations of a namespace, see
+// apiconfig.Features.ForNamespace.
+func WithNamespaceFeatures(ctx context.Context, annotations map[string]string) context.Context {
+	ctx = apiconfig.WithNamespaceFeatures(ctx, annotations)
+	cfg := *FromContext(ctx)
+	cfg.Config = apiconfig.FromContext(ctx)
+	return ToContext(ctx, &cfg)
+}
+
 // Store is a typed wrapper around configmap.UntypedStore to handle our configmaps.
 type Store struct {
 	*configmap.UntypedStore
@@ -65,7 +101,7 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 			configmap.Constructors{
 				deployment.ConfigName:   deployment.NewConfigFromConfigMap,
 				logging.ConfigMapName(): logging.NewConfigFromConfigMap,
-				metrics.ConfigMapName(): metrics.NewObservabilityConfigFromConfigMap,
+				metrics.ConfigMapName(): NewObservabilityConfigFromConfigMap,
 				network.ConfigName:      network.NewConfigFromConfigMap,
 				pkgtracing.ConfigName:   pkgtracing.NewTracingConfigFromConfigMap,
 			},
@@ -104,8 +140,9 @@ func (s *Store) Load() *Config {
 	if net, ok := s.UntypedLoad(network.ConfigName).(*network.Config); ok {
 		cfg.Network = net.DeepCopy()
 	}
-	if obs, ok := s.UntypedLoad(metrics.ConfigMapName()).(*metrics.ObservabilityConfig); ok {
-		cfg.Observability = obs.DeepCopy()
+	if obs, ok := s.UntypedLoad(metrics.ConfigMapName()).(*ObservabilityConfig); ok {
+		cfg.Observability = obs.Metrics.DeepCopy()
+		cfg.RequestLog = obs.RequestLog.DeepCopy()
 	}
 	if tr, ok := s.UntypedLoad(pkgtracing.ConfigName).(*pkgtracing.Config); ok {
 		cfg.Tracing = tr.DeepCopy()
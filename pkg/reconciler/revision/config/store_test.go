@@ -33,6 +33,7 @@ import (
 	apiconfig "knative.dev/serving/pkg/apis/config"
 	autoscalerconfig "knative.dev/serving/pkg/autoscaler/config"
 	"knative.dev/serving/pkg/deployment"
+	pkghttp "knative.dev/serving/pkg/http"
 
 	. "knative.dev/pkg/configmap/testing"
 )
@@ -98,6 +99,13 @@ func TestStoreLoadWithContext(t *testing.T) {
 		}
 	})
 
+	t.Run("request log", func(t *testing.T) {
+		expected, _ := pkghttp.NewRequestLogConfigFromConfigMap(observabilityConfig)
+		if diff := cmp.Diff(expected, config.RequestLog); diff != "" {
+			t.Error("Unexpected request log config (-want, +got):", diff)
+		}
+	})
+
 	t.Run("logging", func(t *testing.T) {
 		expected, _ := logging.NewConfigFromConfigMap(loggingConfig)
 		if diff := cmp.Diff(expected, config.Logging); diff != "" {
@@ -184,6 +184,18 @@ var (
 		}, {
 			Name:  "ENABLE_HTTP2_AUTO_DETECTION",
 			Value: "false",
+		}, {
+			Name:  "SERVING_REQUEST_LOG_FORMAT",
+			Value: "template",
+		}, {
+			Name:  "SERVING_REQUEST_LOG_FIELDS",
+			Value: "latency,request,response,revision,trace",
+		}, {
+			Name:  "SERVING_REQUEST_LOG_SAMPLE_RATE",
+			Value: "1",
+		}, {
+			Name:  "SERVING_REQUEST_LOG_ERROR_SAMPLE_RATE",
+			Value: "1",
 		}},
 	}
 
@@ -844,6 +856,43 @@ func TestMakePodSpec(t *testing.T) {
 				),
 				queueContainer(),
 			}),
+	}, {
+		name: "with grpc readiness and liveness probes",
+		rev: revision("bar", "foo",
+			withContainers([]corev1.Container{{
+				Name:  servingContainerName,
+				Image: "busybox",
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{},
+					}},
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{},
+					}}}},
+			),
+			WithContainerStatuses([]v1.ContainerStatus{{
+				ImageDigest: "busybox@sha256:deadbeef",
+			}}),
+		),
+		want: podSpec(
+			[]corev1.Container{
+				servingContainer(
+					func(container *corev1.Container) {
+						container.Image = "busybox@sha256:deadbeef"
+						// The queue-proxy runs the readiness probe.
+						container.ReadinessProbe = nil
+					},
+					withLivenessProbe(corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{
+							Port: v1.DefaultUserPort,
+						},
+					}),
+				),
+				queueContainer(
+					withEnvVar("SERVING_READINESS_PROBE", fmt.Sprintf(`{"grpc":{"port":%d,"service":null}}`, v1.DefaultUserPort)),
+				),
+			}),
 	}, {
 		name: "complex pod spec",
 		rev: revision("bar", "foo",
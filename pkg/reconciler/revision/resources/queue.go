@@ -21,6 +21,7 @@ import (
 	"math"
 	"path"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -37,6 +38,7 @@ import (
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/deployment"
+	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/queue/readiness"
@@ -90,6 +92,12 @@ var (
 	}
 )
 
+// BuildQueueResources returns the resources of the queue-proxy container of
+// the pods of the revision.
+func BuildQueueResources(rev *v1.Revision, cfg *deployment.Config) corev1.ResourceRequirements {
+	return createQueueResources(cfg, rev.GetAnnotations(), rev.Spec.GetContainer())
+}
+
 func createQueueResources(cfg *deployment.Config, annotations map[string]string, userContainer *corev1.Container) corev1.ResourceRequirements {
 	resourceRequests := corev1.ResourceList{}
 	resourceLimits := corev1.ResourceList{}
@@ -221,6 +229,9 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 		if container.ReadinessProbe.TCPSocket != nil && container.ReadinessProbe.TCPSocket.Port.IntValue() != 0 {
 			probePort = container.ReadinessProbe.TCPSocket.Port.IntVal
 		}
+		if container.ReadinessProbe.GRPC != nil && container.ReadinessProbe.GRPC.Port != 0 {
+			probePort = container.ReadinessProbe.GRPC.Port
+		}
 
 		// The activator attempts to detect readiness itself by checking the Queue
 		// Proxy's health endpoint rather than waiting for Kubernetes to check and
@@ -251,6 +262,12 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 		}
 	}
 
+	requestLog := cfg.RequestLog
+	if requestLog == nil {
+		// The request log settings may not have been loaded yet.
+		requestLog, _ = pkghttp.NewRequestLogConfigFromMap(nil)
+	}
+
 	c := &corev1.Container{
 		Name:            QueueContainerName,
 		Image:           cfg.Deployment.QueueSidecarImage,
@@ -362,6 +379,18 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 		}, {
 			Name:  "ENABLE_HTTP2_AUTO_DETECTION",
 			Value: strconv.FormatBool(cfg.Features.AutoDetectHTTP2 == apicfg.Enabled),
+		}, {
+			Name:  "SERVING_REQUEST_LOG_FORMAT",
+			Value: string(requestLog.Format),
+		}, {
+			Name:  "SERVING_REQUEST_LOG_FIELDS",
+			Value: strings.Join(requestLog.Fields.List(), ","),
+		}, {
+			Name:  "SERVING_REQUEST_LOG_SAMPLE_RATE",
+			Value: fmt.Sprint(requestLog.SampleRate),
+		}, {
+			Name:  "SERVING_REQUEST_LOG_ERROR_SAMPLE_RATE",
+			Value: fmt.Sprint(requestLog.ErrorSampleRate),
 		}},
 	}
 
@@ -387,6 +416,8 @@ func applyReadinessProbeDefaultsForExec(p *corev1.Probe, port int32) {
 	case p.TCPSocket != nil:
 		p.TCPSocket.Host = localAddress
 		p.TCPSocket.Port = intstr.FromInt(int(port))
+	case p.GRPC != nil:
+		p.GRPC.Port = port
 	case p.Exec != nil:
 		// User-defined ExecProbe will still be run on user-container.
 		// Use TCP probe in queue-proxy.
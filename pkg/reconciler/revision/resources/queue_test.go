@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	network "knative.dev/networking/pkg"
 	"knative.dev/networking/pkg/apis/networking"
@@ -45,6 +47,7 @@ import (
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
 	"knative.dev/serving/pkg/deployment"
+	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/reconciler/revision/config"
 
@@ -72,11 +75,12 @@ var (
 		InitialScale:          1,
 		AllowZeroInitialScale: false,
 	}
-	deploymentConfig deployment.Config
-	logConfig        logging.Config
-	obsConfig        metrics.ObservabilityConfig
-	traceConfig      tracingconfig.Config
-	defaults, _      = apicfg.NewDefaultsConfigFromMap(nil)
+	deploymentConfig    deployment.Config
+	logConfig           logging.Config
+	obsConfig           metrics.ObservabilityConfig
+	traceConfig         tracingconfig.Config
+	defaults, _         = apicfg.NewDefaultsConfigFromMap(nil)
+	requestLogConfig, _ = pkghttp.NewRequestLogConfigFromMap(nil)
 )
 
 const testProbeJSONTemplate = `{"tcpSocket":{"port":%d,"host":"127.0.0.1"}}`
@@ -88,6 +92,7 @@ func TestMakeQueueContainer(t *testing.T) {
 		lc   logging.Config
 		nc   network.Config
 		oc   metrics.ObservabilityConfig
+		rlc  *pkghttp.RequestLogConfig
 		dc   deployment.Config
 		fc   apicfg.Features
 		want corev1.Container
@@ -248,6 +253,28 @@ func TestMakeQueueContainer(t *testing.T) {
 				"SERVING_ENABLE_PROBE_REQUEST_LOG": "false",
 			})
 		}),
+	}, {
+		name: "structured request log configuration as env var",
+		rev: revision("bar", "foo",
+			withContainers(containers)),
+		oc: metrics.ObservabilityConfig{
+			EnableRequestLog: true,
+		},
+		rlc: &pkghttp.RequestLogConfig{
+			Format:          pkghttp.RequestLogFormatJSON,
+			Fields:          sets.NewString(pkghttp.RequestLogFieldResponse, pkghttp.RequestLogFieldLatency),
+			SampleRate:      0.25,
+			ErrorSampleRate: 1,
+		},
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{
+				"SERVING_ENABLE_REQUEST_LOG":            "true",
+				"SERVING_REQUEST_LOG_FORMAT":            "json",
+				"SERVING_REQUEST_LOG_FIELDS":            "latency,response",
+				"SERVING_REQUEST_LOG_SAMPLE_RATE":       "0.25",
+				"SERVING_REQUEST_LOG_ERROR_SAMPLE_RATE": "1",
+			})
+		}),
 	}, {
 		name: "request metrics backend as env var",
 		rev: revision("bar", "foo",
@@ -383,10 +410,14 @@ func TestMakeQueueContainer(t *testing.T) {
 					}},
 				}
 			}
+			if test.rlc == nil {
+				test.rlc = requestLogConfig
+			}
 			cfg := &config.Config{
 				Tracing:       &traceConfig,
 				Logging:       &test.lc,
 				Observability: &test.oc,
+				RequestLog:    test.rlc,
 				Deployment:    &test.dc,
 				Config: &apicfg.Config{
 					Features: &test.fc,
@@ -411,6 +442,35 @@ func TestMakeQueueContainer(t *testing.T) {
 	}
 }
 
+func TestMakeQueueContainerWithoutRequestLogConfig(t *testing.T) {
+	rev := revision("bar", "foo", withContainers(containers))
+	cfg := &config.Config{
+		Tracing:       &traceConfig,
+		Logging:       &logConfig,
+		Observability: &obsConfig,
+		Deployment:    &deploymentConfig,
+		Config: &apicfg.Config{
+			Features: &apicfg.Features{},
+		},
+	}
+	got, err := makeQueueContainer(rev, cfg)
+	if err != nil {
+		t.Fatal("makeQueueContainer returned error:", err)
+	}
+
+	want := map[string]string{
+		"SERVING_REQUEST_LOG_FORMAT":            string(requestLogConfig.Format),
+		"SERVING_REQUEST_LOG_FIELDS":            strings.Join(requestLogConfig.Fields.List(), ","),
+		"SERVING_REQUEST_LOG_SAMPLE_RATE":       "1",
+		"SERVING_REQUEST_LOG_ERROR_SAMPLE_RATE": "1",
+	}
+	for _, env := range got.Env {
+		if w, ok := want[env.Name]; ok && env.Value != w {
+			t.Errorf("%s = %q, want: %q", env.Name, env.Value, w)
+		}
+	}
+}
+
 func TestMakeQueueContainerWithPercentageAnnotation(t *testing.T) {
 	tests := []struct {
 		name string
@@ -876,33 +936,103 @@ func TestTCPProbeGeneration(t *testing.T) {
 	}
 }
 
+func TestGRPCProbeGeneration(t *testing.T) {
+	const userPort = 12345
+	userProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Service: ptr.String("foo.Health"),
+			},
+		},
+		PeriodSeconds:    2,
+		SuccessThreshold: 1,
+	}
+	testRev := revision("bar", "foo",
+		func(revision *v1.Revision) {
+			revision.Spec = v1.RevisionSpec{
+				TimeoutSeconds: ptr.Int64(45),
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: servingContainerName,
+						Ports: []corev1.ContainerPort{{
+							ContainerPort: userPort,
+						}},
+						ReadinessProbe: userProbe,
+					}},
+				},
+			}
+		})
+
+	wantProbe := userProbe.DeepCopy()
+	wantProbe.GRPC.Port = userPort
+	wantProbe.TimeoutSeconds = 1
+	wantProbeJSON, err := json.Marshal(wantProbe)
+	if err != nil {
+		t.Fatal("failed to marshal expected probe")
+	}
+	want := queueContainer(func(c *corev1.Container) {
+		c.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Port: intstr.FromInt(int(queueHTTPPort.ContainerPort)),
+					HTTPHeaders: []corev1.HTTPHeader{{
+						Name:  network.ProbeHeaderName,
+						Value: queue.Name,
+					}},
+				},
+			},
+			PeriodSeconds:    2,
+			SuccessThreshold: 1,
+		}
+		c.Env = env(map[string]string{"USER_PORT": strconv.Itoa(userPort)})
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "SERVING_READINESS_PROBE",
+			Value: string(wantProbeJSON),
+		})
+	})
+
+	got, err := makeQueueContainer(testRev, revConfig())
+	if err != nil {
+		t.Fatal("makeQueueContainer returned error")
+	}
+	sortEnv(got.Env)
+	sortEnv(want.Env)
+	if !cmp.Equal(want, *got, quantityComparer) {
+		t.Errorf("makeQueueContainer (-want, +got) =\n%s", cmp.Diff(want, *got, quantityComparer))
+	}
+}
+
 var defaultEnv = map[string]string{
-	"CONCURRENCY_STATE_ENDPOINT":       "",
-	"CONCURRENCY_STATE_TOKEN_PATH":     "/var/run/secrets/tokens/state-token",
-	"CONTAINER_CONCURRENCY":            "0",
-	"ENABLE_HTTP2_AUTO_DETECTION":      "false",
-	"ENABLE_PROFILING":                 "false",
-	"METRICS_DOMAIN":                   metrics.Domain(),
-	"METRICS_COLLECTOR_ADDRESS":        "",
-	"QUEUE_SERVING_PORT":               "8012",
-	"QUEUE_SERVING_TLS_PORT":           "8112",
-	"REVISION_TIMEOUT_SECONDS":         "45",
-	"SERVING_CONFIGURATION":            "",
-	"SERVING_ENABLE_PROBE_REQUEST_LOG": "false",
-	"SERVING_ENABLE_REQUEST_LOG":       "false",
-	"SERVING_LOGGING_CONFIG":           "",
-	"SERVING_LOGGING_LEVEL":            "",
-	"SERVING_NAMESPACE":                "foo",
-	"SERVING_REQUEST_LOG_TEMPLATE":     "",
-	"SERVING_REQUEST_METRICS_BACKEND":  "",
-	"SERVING_REVISION":                 "bar",
-	"SERVING_SERVICE":                  "",
-	"SYSTEM_NAMESPACE":                 system.Namespace(),
-	"TRACING_CONFIG_BACKEND":           "",
-	"TRACING_CONFIG_DEBUG":             "false",
-	"TRACING_CONFIG_SAMPLE_RATE":       "0",
-	"TRACING_CONFIG_ZIPKIN_ENDPOINT":   "",
-	"USER_PORT":                        strconv.Itoa(v1.DefaultUserPort),
+	"CONCURRENCY_STATE_ENDPOINT":            "",
+	"CONCURRENCY_STATE_TOKEN_PATH":          "/var/run/secrets/tokens/state-token",
+	"CONTAINER_CONCURRENCY":                 "0",
+	"ENABLE_HTTP2_AUTO_DETECTION":           "false",
+	"ENABLE_PROFILING":                      "false",
+	"METRICS_DOMAIN":                        metrics.Domain(),
+	"METRICS_COLLECTOR_ADDRESS":             "",
+	"QUEUE_SERVING_PORT":                    "8012",
+	"QUEUE_SERVING_TLS_PORT":                "8112",
+	"REVISION_TIMEOUT_SECONDS":              "45",
+	"SERVING_CONFIGURATION":                 "",
+	"SERVING_ENABLE_PROBE_REQUEST_LOG":      "false",
+	"SERVING_ENABLE_REQUEST_LOG":            "false",
+	"SERVING_LOGGING_CONFIG":                "",
+	"SERVING_LOGGING_LEVEL":                 "",
+	"SERVING_NAMESPACE":                     "foo",
+	"SERVING_REQUEST_LOG_TEMPLATE":          "",
+	"SERVING_REQUEST_LOG_FORMAT":            "template",
+	"SERVING_REQUEST_LOG_FIELDS":            "latency,request,response,revision,trace",
+	"SERVING_REQUEST_LOG_SAMPLE_RATE":       "1",
+	"SERVING_REQUEST_LOG_ERROR_SAMPLE_RATE": "1",
+	"SERVING_REQUEST_METRICS_BACKEND":       "",
+	"SERVING_REVISION":                      "bar",
+	"SERVING_SERVICE":                       "",
+	"SYSTEM_NAMESPACE":                      system.Namespace(),
+	"TRACING_CONFIG_BACKEND":                "",
+	"TRACING_CONFIG_DEBUG":                  "false",
+	"TRACING_CONFIG_SAMPLE_RATE":            "0",
+	"TRACING_CONFIG_ZIPKIN_ENDPOINT":        "",
+	"USER_PORT":                             strconv.Itoa(v1.DefaultUserPort),
 }
 
 func probeJSON(container *corev1.Container) string {
@@ -974,6 +1104,7 @@ func revConfig() *config.Config {
 		Logging:       &logConfig,
 		Network:       &network.Config{},
 		Observability: &obsConfig,
+		RequestLog:    requestLogConfig,
 		Tracing:       &traceConfig,
 	}
 }
@@ -45,6 +45,7 @@ import (
 	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
 	revisionreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/revision"
+	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/reconciler/revision/config"
 	"knative.dev/serving/pkg/reconciler/revision/resources"
 
@@ -691,6 +692,7 @@ func TestReconcile(t *testing.T) {
 			podAutoscalerLister: listers.GetPodAutoscalerLister(),
 			imageLister:         listers.GetImageLister(),
 			deploymentLister:    listers.GetDeploymentLister(),
+			namespaceLister:     listers.GetNamespaceLister(),
 			resolver:            &nopResolver{},
 		}
 
@@ -890,8 +892,9 @@ func reconcilerTestConfig() *config.Config {
 		Observability: &metrics.ObservabilityConfig{
 			LoggingURLTemplate: "http://logger.io/${REVISION_UID}",
 		},
-		Logging: &logging.Config{},
-		Tracing: &tracingconfig.Config{},
-		Network: &network.Config{},
+		RequestLog: &pkghttp.RequestLogConfig{},
+		Logging:    &logging.Config{},
+		Tracing:    &tracingconfig.Config{},
+		Network:    &network.Config{},
 	}
 }
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MinWindow is the shortest window the metrics of a revision are
+// evaluated over. Shorter windows don't span enough scrapes of the
+// queue-proxy metrics to compute the request rates.
+const MinWindow = time.Minute
+
+// Result contains the request metrics of a revision over a window.
+type Result struct {
+	// Requests is the number of requests served by the revision.
+	Requests float64
+
+	// Errors is the number of requests answered with a 5xx response code.
+	Errors float64
+
+	// LatencyP95 is the 95th percentile of the request latency.
+	LatencyP95 time.Duration
+}
+
+// ErrorRate returns the fraction of requests that failed.
+func (r *Result) ErrorRate() float64 {
+	if r.Requests <= 0 {
+		return 0
+	}
+	return r.Errors / r.Requests
+}
+
+// Source provides the request metrics of revisions.
+type Source interface {
+	// Query returns the request metrics of the revision over the window
+	// ending now.
+	Query(ctx context.Context, namespace, revision string, window time.Duration) (*Result, error)
+}
+
+// SLO describes the limits a revision has to meet for its rollout to advance.
+type SLO struct {
+	// MaxErrorRate is the largest fraction of failed requests.
+	MaxErrorRate float64
+
+	// MaxLatency is the largest 95th percentile request latency,
+	// 0 disables the check.
+	MaxLatency time.Duration
+
+	// MinRequests is the number of requests below which the result is not
+	// considered significant and the SLO holds.
+	MinRequests float64
+}
+
+// Check returns an error describing the violated limit if the result doesn't
+// meet the SLO, nil otherwise.
+func (s SLO) Check(r *Result) error {
+	if r.Requests < s.MinRequests {
+		return nil
+	}
+	if er := r.ErrorRate(); er > s.MaxErrorRate {
+		return fmt.Errorf("error rate %.2f%% exceeds %.2f%%", er*100, s.MaxErrorRate*100)
+	}
+	if s.MaxLatency > 0 && r.LatencyP95 > s.MaxLatency {
+		return fmt.Errorf("95th percentile latency %v exceeds %v", r.LatencyP95, s.MaxLatency)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSLOCheck(t *testing.T) {
+	slo := SLO{
+		MaxErrorRate: 0.05,
+		MaxLatency:   100 * time.Millisecond,
+		MinRequests:  10,
+	}
+	tests := []struct {
+		name   string
+		slo    SLO
+		result Result
+		want   string
+	}{{
+		name:   "healthy",
+		slo:    slo,
+		result: Result{Requests: 100, Errors: 5, LatencyP95: 100 * time.Millisecond},
+	}, {
+		name:   "no traffic",
+		slo:    slo,
+		result: Result{},
+	}, {
+		name:   "too few requests",
+		slo:    slo,
+		result: Result{Requests: 9, Errors: 9, LatencyP95: time.Second},
+	}, {
+		name:   "error rate",
+		slo:    slo,
+		result: Result{Requests: 100, Errors: 6, LatencyP95: 10 * time.Millisecond},
+		want:   "error rate 6.00% exceeds 5.00%",
+	}, {
+		name:   "latency",
+		slo:    slo,
+		result: Result{Requests: 100, LatencyP95: 101 * time.Millisecond},
+		want:   "95th percentile latency 101ms exceeds 100ms",
+	}, {
+		name:   "latency not checked",
+		slo:    SLO{MaxErrorRate: 0.05},
+		result: Result{Requests: 100, LatencyP95: time.Hour},
+	}, {
+		name:   "no errors allowed",
+		slo:    SLO{},
+		result: Result{Requests: 1000, Errors: 1},
+		want:   "error rate 0.10% exceeds 0.00%",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.slo.Check(&tc.result)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tc.want {
+				t.Errorf("Check() = %q, want: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResultErrorRate(t *testing.T) {
+	if got := (&Result{}).ErrorRate(); got != 0 {
+		t.Errorf("ErrorRate() without requests = %v, want: 0", got)
+	}
+	if got := (&Result{Requests: 8, Errors: 2}).ErrorRate(); got != 0.25 {
+		t.Errorf("ErrorRate() = %v, want: 0.25", got)
+	}
+}
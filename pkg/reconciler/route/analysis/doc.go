@@ -0,0 +1,24 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+This package contains the types and functions that judge the health of
+a revision during a gradual rollout. The request metrics reported by the
+queue-proxy are read from a pluggable Source and compared against the
+limits of an SLO before the rollout takes its next step.
+*/
+
+package analysis
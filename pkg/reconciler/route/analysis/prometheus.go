@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The queue-proxy metrics as they are exported to Prometheus.
+const (
+	requestCountMetric     = "revision_request_count"
+	requestLatenciesMetric = "revision_request_latencies_bucket"
+)
+
+// queryTimeout bounds each query, so an unresponsive server holds up the
+// reconciliation of the Routes for no longer than that.
+const queryTimeout = 5 * time.Second
+
+// PrometheusSource reads the request metrics of revisions through the
+// HTTP query API of Prometheus, or any other server compatible with it.
+type PrometheusSource struct {
+	// URL is the base URL of the query API, without the /api/v1 suffix.
+	URL string
+
+	// Client is the HTTP client used to send the queries.
+	Client *http.Client
+}
+
+var _ Source = (*PrometheusSource)(nil)
+
+// NewPrometheusSource creates a PrometheusSource querying the API at baseURL.
+func NewPrometheusSource(baseURL string) *PrometheusSource {
+	return &PrometheusSource{
+		URL:    strings.TrimSuffix(baseURL, "/"),
+		Client: &http.Client{Timeout: queryTimeout},
+	}
+}
+
+// Query implements Source.
+func (p *PrometheusSource) Query(ctx context.Context, namespace, revision string, window time.Duration) (*Result, error) {
+	if window < MinWindow {
+		window = MinWindow
+	}
+	rng := strconv.FormatInt(int64(window.Seconds()), 10) + "s"
+	sel := fmt.Sprintf("namespace_name=%q,revision_name=%q", namespace, revision)
+
+	requests, err := p.query(ctx,
+		fmt.Sprintf("sum(increase(%s{%s}[%s]))", requestCountMetric, sel, rng))
+	if err != nil {
+		return nil, err
+	}
+	failures, err := p.query(ctx,
+		fmt.Sprintf(`sum(increase(%s{%s,response_code_class="5xx"}[%s]))`, requestCountMetric, sel, rng))
+	if err != nil {
+		return nil, err
+	}
+	latencyMs, err := p.query(ctx,
+		fmt.Sprintf("histogram_quantile(0.95, sum by (le) (rate(%s{%s}[%s])))", requestLatenciesMetric, sel, rng))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Requests:   requests,
+		Errors:     failures,
+		LatencyP95: time.Duration(latencyMs * float64(time.Millisecond)),
+	}, nil
+}
+
+// queryResponse is the envelope of the responses of the query API.
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			// Value is a [<unix time>, "<sample value>"] pair.
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// query evaluates an instant query that yields at most one sample and returns
+// its value. Queries without samples, or with a NaN sample, yield 0.
+func (p *PrometheusSource) query(ctx context.Context, q string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		p.URL+"/api/v1/query?"+url.Values{"query": {q}}.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read metrics response: %w", err)
+	}
+	qr := &queryResponse{}
+	if err := json.Unmarshal(body, qr); err != nil {
+		return 0, fmt.Errorf("failed to parse metrics response with status %d: %w", resp.StatusCode, err)
+	}
+	if qr.Status != "success" {
+		return 0, fmt.Errorf("metrics query %q failed: %s", q, qr.Error)
+	}
+	if qr.Data.ResultType != "vector" {
+		return 0, fmt.Errorf("metrics query %q returned a %s, want a vector", q, qr.Data.ResultType)
+	}
+
+	if len(qr.Data.Result) == 0 {
+		return 0, nil
+	} else if len(qr.Data.Result) > 1 {
+		return 0, fmt.Errorf("metrics query %q returned %d samples, want 1", q, len(qr.Data.Result))
+	}
+	v := qr.Data.Result[0].Value
+	if len(v) != 2 {
+		return 0, fmt.Errorf("metrics query %q returned a malformed sample %v", q, v)
+	}
+	s, ok := v[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("metrics query %q returned a malformed sample %v", q, v)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("metrics query %q returned a malformed sample %v: %w", q, v, err)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, nil
+	}
+	return f, nil
+}
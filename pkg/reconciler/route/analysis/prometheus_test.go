@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"knative.dev/serving/pkg/reconciler/route/analysis"
+	analysistesting "knative.dev/serving/pkg/reconciler/route/analysis/testing"
+)
+
+func TestPrometheusSource(t *testing.T) {
+	prom := analysistesting.NewFakePrometheus()
+	defer prom.Close()
+
+	want := &analysis.Result{
+		Requests:   120,
+		Errors:     3,
+		LatencyP95: 250 * time.Millisecond,
+	}
+	prom.SetResult("ns", "rev-2", want)
+
+	src := analysis.NewPrometheusSource(prom.URL + "/")
+	got, err := src.Query(context.Background(), "ns", "rev-2", 5*time.Minute)
+	if err != nil {
+		t.Fatal("Query() =", err)
+	}
+	if !cmp.Equal(got, want) {
+		t.Error("Query() (-want, +got):", cmp.Diff(want, got))
+	}
+	if got, want := prom.Queries(), 3; got != want {
+		t.Errorf("Queries = %d, want: %d", got, want)
+	}
+
+	// Revisions without metrics have no samples.
+	got, err = src.Query(context.Background(), "ns", "rev-1", time.Second)
+	if err != nil {
+		t.Fatal("Query() =", err)
+	}
+	if !cmp.Equal(got, &analysis.Result{}) {
+		t.Errorf("Query() = %#v, want an empty result", got)
+	}
+}
+
+func TestPrometheusSourceQueries(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("query"))
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"NaN"]}]}}`))
+	}))
+	defer srv.Close()
+
+	got, err := analysis.NewPrometheusSource(srv.URL).Query(context.Background(), "ns", "rev", 10*time.Second)
+	if err != nil {
+		t.Fatal("Query() =", err)
+	}
+	if !cmp.Equal(got, &analysis.Result{}) {
+		t.Errorf("Query() = %#v, want an empty result for NaN samples", got)
+	}
+
+	// Windows shorter than a minute are extended to analysis.MinWindow.
+	wantQueries := []string{
+		`sum(increase(revision_request_count{namespace_name="ns",revision_name="rev"}[60s]))`,
+		`sum(increase(revision_request_count{namespace_name="ns",revision_name="rev",response_code_class="5xx"}[60s]))`,
+		`histogram_quantile(0.95, sum by (le) (rate(revision_request_latencies_bucket{namespace_name="ns",revision_name="rev"}[60s])))`,
+	}
+	if !cmp.Equal(queries, wantQueries) {
+		t.Error("Queries (-want, +got):", cmp.Diff(wantQueries, queries))
+	}
+}
+
+func TestPrometheusSourceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{{
+		name:   "query error",
+		status: http.StatusBadRequest,
+		body:   `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+		want:   "parse error",
+	}, {
+		name:   "not json",
+		status: http.StatusBadGateway,
+		body:   "<html>bad gateway</html>",
+		want:   "failed to parse metrics response with status 502",
+	}, {
+		name:   "matrix",
+		status: http.StatusOK,
+		body:   `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		want:   "returned a matrix, want a vector",
+	}, {
+		name:   "several samples",
+		status: http.StatusOK,
+		body:   `{"status":"success","data":{"resultType":"vector","result":[{"value":[1,"1"]},{"value":[1,"2"]}]}}`,
+		want:   "returned 2 samples, want 1",
+	}, {
+		name:   "malformed sample",
+		status: http.StatusOK,
+		body:   `{"status":"success","data":{"resultType":"vector","result":[{"value":[1,2]}]}}`,
+		want:   "returned a malformed sample",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			_, err := analysis.NewPrometheusSource(srv.URL).Query(context.Background(), "ns", "rev", time.Minute)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Query() = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrometheusSourceTimeout(t *testing.T) {
+	stop := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-stop:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(stop)
+
+	src := analysis.NewPrometheusSource(srv.URL)
+	if src.Client.Timeout == 0 {
+		t.Fatal("Client.Timeout = 0, want the queries to time out")
+	}
+	src.Client.Timeout = 10 * time.Millisecond
+
+	if _, err := src.Query(context.Background(), "ns", "rev", time.Minute); err == nil {
+		t.Error("Query() = nil, want a timeout error")
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides a local stand-in for the Prometheus query API
+// that serves the request metrics of revisions from memory.
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"knative.dev/serving/pkg/reconciler/route/analysis"
+)
+
+var revisionSelector = regexp.MustCompile(`namespace_name="([^"]*)",revision_name="([^"]*)"`)
+
+// FakePrometheus is an HTTP server implementing the part of the Prometheus
+// query API used by analysis.PrometheusSource. Revisions without results
+// yield no samples.
+type FakePrometheus struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	results map[string]*analysis.Result
+	queries int
+}
+
+// NewFakePrometheus starts a new FakePrometheus. It must be closed by the caller.
+func NewFakePrometheus() *FakePrometheus {
+	p := &FakePrometheus{results: map[string]*analysis.Result{}}
+	p.Server = httptest.NewServer(http.HandlerFunc(p.serveQuery))
+	return p
+}
+
+// SetResult sets the metrics served for the revision.
+func (p *FakePrometheus) SetResult(namespace, revision string, r *analysis.Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[namespace+"/"+revision] = r
+}
+
+// Queries returns the number of queries served so far.
+func (p *FakePrometheus) Queries() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.queries
+}
+
+func (p *FakePrometheus) serveQuery(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v1/query" {
+		http.NotFound(w, r)
+		return
+	}
+	q := r.URL.Query().Get("query")
+	m := revisionSelector.FindStringSubmatch(q)
+	if m == nil {
+		writeResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"status":    "error",
+			"errorType": "bad_data",
+			"error":     "unsupported query " + q,
+		})
+		return
+	}
+
+	p.mu.Lock()
+	p.queries++
+	res := p.results[m[1]+"/"+m[2]]
+	p.mu.Unlock()
+
+	samples := []interface{}{}
+	if res != nil {
+		var v float64
+		switch {
+		case strings.HasPrefix(q, "histogram_quantile"):
+			v = float64(res.LatencyP95) / float64(time.Millisecond)
+		case strings.Contains(q, `response_code_class="5xx"`):
+			v = res.Errors
+		default:
+			v = res.Requests
+		}
+		samples = append(samples, map[string]interface{}{
+			"metric": map[string]string{},
+			"value":  []interface{}{float64(time.Now().Unix()), strconv.FormatFloat(v, 'f', -1, 64)},
+		})
+	}
+	writeResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"resultType": "vector",
+			"result":     samples,
+		},
+	})
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	cm "knative.dev/pkg/configmap"
+)
+
+const (
+	// RolloutConfigName is the config map name for the rollout analysis configuration.
+	RolloutConfigName = "config-rollout"
+)
+
+// Rollout contains the settings used to gate the steps of a gradual
+// rollout on the health of the revision being rolled out.
+type Rollout struct {
+	// MetricsURL is the base URL of a Prometheus compatible query API the
+	// request metrics of the revisions are read from.
+	// Rollouts are not analyzed if it is empty.
+	MetricsURL string
+
+	// MaxErrorRate is the largest fraction of requests answered with a
+	// 5xx response code the new revision may have for the rollout to
+	// advance.
+	MaxErrorRate float64
+
+	// MaxLatency is the largest 95th percentile request latency the new
+	// revision may have for the rollout to advance. 0 disables the check.
+	MaxLatency time.Duration
+
+	// MinRequestCount is the number of requests the new revision has to
+	// receive between two steps for them to be analyzed. Steps with less
+	// traffic advance without analysis.
+	MinRequestCount int64
+}
+
+func defaultRolloutConfig() *Rollout {
+	return &Rollout{
+		MaxErrorRate:    0.05,
+		MinRequestCount: 10,
+	}
+}
+
+// AnalysisEnabled returns true if the rollout steps should be gated on the
+// metrics of the revisions.
+func (r *Rollout) AnalysisEnabled() bool {
+	return r != nil && r.MetricsURL != ""
+}
+
+// NewRolloutFromConfigMap creates a Rollout from the supplied ConfigMap.
+func NewRolloutFromConfigMap(configMap *corev1.ConfigMap) (*Rollout, error) {
+	r := defaultRolloutConfig()
+
+	if err := cm.Parse(configMap.Data,
+		cm.AsString("metrics-url", &r.MetricsURL),
+		cm.AsFloat64("max-error-rate", &r.MaxErrorRate),
+		cm.AsDuration("max-latency", &r.MaxLatency),
+		cm.AsInt64("min-request-count", &r.MinRequestCount),
+	); err != nil {
+		return nil, fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	if r.MetricsURL != "" {
+		if u, err := url.Parse(r.MetricsURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("metrics-url = %q must be an absolute URL", r.MetricsURL)
+		}
+	}
+	if r.MaxErrorRate < 0 || r.MaxErrorRate > 1 {
+		return nil, fmt.Errorf("max-error-rate = %v, must be in [0, 1] range", r.MaxErrorRate)
+	}
+	if r.MaxLatency < 0 {
+		return nil, fmt.Errorf("max-latency = %v, must not be negative", r.MaxLatency)
+	}
+	if r.MinRequestCount < 0 {
+		return nil, fmt.Errorf("min-request-count = %d, must not be negative", r.MinRequestCount)
+	}
+	return r, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestOurRollout(t *testing.T) {
+	actual, example := ConfigMapsFromTestFile(t, RolloutConfigName)
+	for _, tt := range []struct {
+		name string
+		fail bool
+		want *Rollout
+		data map[string]string
+	}{{
+		name: "actual config",
+		want: defaultRolloutConfig(),
+		data: actual.Data,
+	}, {
+		name: "example config",
+		want: defaultRolloutConfig(),
+		data: example.Data,
+	}, {
+		name: "with value overrides",
+		want: &Rollout{
+			MetricsURL:      "http://prometheus.monitoring:9090",
+			MaxErrorRate:    0.01,
+			MaxLatency:      250 * time.Millisecond,
+			MinRequestCount: 100,
+		},
+		data: map[string]string{
+			"metrics-url":       "http://prometheus.monitoring:9090",
+			"max-error-rate":    "0.01",
+			"max-latency":       "250ms",
+			"min-request-count": "100",
+		},
+	}, {
+		name: "relative metrics url",
+		fail: true,
+		data: map[string]string{
+			"metrics-url": "prometheus:9090/api",
+		},
+	}, {
+		name: "error rate too large",
+		fail: true,
+		data: map[string]string{
+			"max-error-rate": "1.5",
+		},
+	}, {
+		name: "negative error rate",
+		fail: true,
+		data: map[string]string{
+			"max-error-rate": "-0.1",
+		},
+	}, {
+		name: "negative latency",
+		fail: true,
+		data: map[string]string{
+			"max-latency": "-1s",
+		},
+	}, {
+		name: "unparsable latency",
+		fail: true,
+		data: map[string]string{
+			"max-latency": "fast",
+		},
+	}, {
+		name: "negative request count",
+		fail: true,
+		data: map[string]string{
+			"min-request-count": "-1",
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRolloutFromConfigMap(&corev1.ConfigMap{Data: tt.data})
+			if tt.fail != (err != nil) {
+				t.Fatal("Unexpected error value:", err)
+			}
+
+			if !cmp.Equal(tt.want, got) {
+				t.Error("Rollout config (-want, +got):", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestRolloutAnalysisEnabled(t *testing.T) {
+	var r *Rollout
+	if r.AnalysisEnabled() {
+		t.Error("AnalysisEnabled() = true for nil config")
+	}
+	if r = defaultRolloutConfig(); r.AnalysisEnabled() {
+		t.Error("AnalysisEnabled() = true for the default config")
+	}
+	if r.MetricsURL = "http://prometheus:9090"; !r.AnalysisEnabled() {
+		t.Error("AnalysisEnabled() = false with a metrics URL")
+	}
+}
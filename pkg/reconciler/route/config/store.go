@@ -35,6 +35,7 @@ type Config struct {
 	GC       *gc.Config
 	Network  *network.Config
 	Features *cfgmap.Features
+	Rollout  *Rollout
 }
 
 // FromContext obtains a Config injected into the passed context.
@@ -54,6 +55,10 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 		cfg.Features, _ = cfgmap.NewFeaturesConfigFromMap(map[string]string{})
 	}
 
+	if cfg.Rollout == nil {
+		cfg.Rollout = defaultRolloutConfig()
+	}
+
 	return cfg
 }
 
@@ -62,6 +67,15 @@ func ToContext(ctx context.Context, c *Config) context.Context {
 	return context.WithValue(ctx, cfgKey{}, c)
 }
 
+// WithNamespaceFeatures returns the provided context with the Features of its
+// Config overridden by the annotations of a namespace, see
+// cfgmap.Features.ForNamespace.
+func WithNamespaceFeatures(ctx context.Context, annotations map[string]string) context.Context {
+	cfg := *FromContextOrDefaults(ctx)
+	cfg.Features = cfg.Features.ForNamespace(annotations)
+	return ToContext(ctx, &cfg)
+}
+
 // Store is a typed wrapper around configmap.Untyped store to handle our configmaps.
 //
 // +k8s:deepcopy-gen=false
@@ -90,6 +104,7 @@ func NewStore(ctx context.Context, onAfterStore ...func(name string, value inter
 				gc.ConfigName:             gc.NewConfigFromConfigMapFunc(ctx),
 				network.ConfigName:        network.NewConfigFromConfigMap,
 				cfgmap.FeaturesConfigName: cfgmap.NewFeaturesConfigFromConfigMap,
+				RolloutConfigName:         NewRolloutFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -116,5 +131,9 @@ func (s *Store) Load() *Config {
 		config.Features = featureConfig.(*cfgmap.Features).DeepCopy()
 	}
 
+	if rolloutConfig := s.UntypedLoad(RolloutConfigName); rolloutConfig != nil {
+		config.Rollout = rolloutConfig.(*Rollout).DeepCopy()
+	}
+
 	return config
 }
@@ -37,11 +37,13 @@ func TestStoreLoadWithContext(t *testing.T) {
 	gcConfig := ConfigMapFromTestFile(t, gc.ConfigName)
 	networkConfig := ConfigMapFromTestFile(t, network.ConfigName)
 	featureConfig := ConfigMapFromTestFile(t, cfgmap.FeaturesConfigName)
+	rolloutConfig := ConfigMapFromTestFile(t, RolloutConfigName)
 
 	store.OnConfigChanged(domainConfig)
 	store.OnConfigChanged(gcConfig)
 	store.OnConfigChanged(networkConfig)
 	store.OnConfigChanged(featureConfig)
+	store.OnConfigChanged(rolloutConfig)
 
 	config := FromContext(store.ToContext(context.Background()))
 
@@ -61,6 +63,13 @@ func TestStoreLoadWithContext(t *testing.T) {
 			t.Error("Unexpected controller config (-want, +got):", diff)
 		}
 	})
+
+	t.Run("rollout", func(t *testing.T) {
+		expected, _ := NewRolloutFromConfigMap(rolloutConfig)
+		if diff := cmp.Diff(expected, config.Rollout); diff != "" {
+			t.Error("Unexpected controller config (-want, +got):", diff)
+		}
+	})
 }
 
 func TestStoreLoadWithContextOrDefaults(t *testing.T) {
@@ -98,3 +107,19 @@ func TestStoreImmutableConfig(t *testing.T) {
 		t.Error("Domain config is not immutable")
 	}
 }
+
+func TestWithNamespaceFeatures(t *testing.T) {
+	features, _ := cfgmap.NewFeaturesConfigFromMap(map[string]string{})
+	features.TagHeaderBasedRouting = cfgmap.Allowed
+	ctx := ToContext(context.Background(), &Config{Features: features})
+
+	nsCtx := WithNamespaceFeatures(ctx, map[string]string{
+		cfgmap.FeaturesAnnotationPrefix + "tag-header-based-routing": "Enabled",
+	})
+	if got, want := FromContext(nsCtx).Features.TagHeaderBasedRouting, cfgmap.Enabled; got != want {
+		t.Errorf("TagHeaderBasedRouting = %v, want: %v", got, want)
+	}
+	if got, want := FromContext(ctx).Features.TagHeaderBasedRouting, cfgmap.Allowed; got != want {
+		t.Error("WithNamespaceFeatures mutated the config of the context")
+	}
+}
@@ -18,12 +18,14 @@ package route
 
 import (
 	"context"
+	"sync"
 
 	netclient "knative.dev/networking/pkg/client/injection/client"
 	certificateinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/certificate"
 	ingressinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/ingress"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
 	configurationinformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration"
@@ -31,6 +33,7 @@ import (
 	routeinformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/route"
 	routereconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/route"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/tools/cache"
 	network "knative.dev/networking/pkg"
@@ -38,9 +41,35 @@ import (
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 )
 
+// newMetricsSource returns a source reading the request metrics of the
+// revisions from the Prometheus compatible API at url.
+func newMetricsSource(url string) analysis.Source {
+	return analysis.NewPrometheusSource(url)
+}
+
+// cachedMetricsSource wraps newSource, so that the source is only built
+// again when the URL configured in config-rollout changes, rather than on
+// every reconciliation.
+func cachedMetricsSource(newSource func(url string) analysis.Source) func(url string) analysis.Source {
+	var (
+		mu     sync.Mutex
+		cached string
+		src    analysis.Source
+	)
+	return func(url string) analysis.Source {
+		mu.Lock()
+		defer mu.Unlock()
+		if src == nil || url != cached {
+			cached, src = url, newSource(url)
+		}
+		return src
+	}
+}
+
 // NewController initializes the controller and is called by the generated code
 // Registers eventhandlers to enqueue events
 func NewController(
@@ -66,6 +95,7 @@ func newController(
 	revisionInformer := revisioninformer.Get(ctx)
 	ingressInformer := ingressinformer.Get(ctx)
 	certificateInformer := certificateinformer.Get(ctx)
+	namespaceInformer := namespaceinformer.Get(ctx)
 
 	c := &Reconciler{
 		kubeclient:          kubeclient.Get(ctx),
@@ -77,7 +107,9 @@ func newController(
 		endpointsLister:     endpointsInformer.Lister(),
 		ingressLister:       ingressInformer.Lister(),
 		certificateLister:   certificateInformer.Lister(),
+		namespaceLister:     namespaceInformer.Lister(),
 		clock:               clock,
+		metricsSource:       cachedMetricsSource(newMetricsSource),
 	}
 	impl := routereconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 		configsToResync := []interface{}{
@@ -103,6 +135,17 @@ func newController(
 	certificateInformer.Informer().AddEventHandler(handleControllerOf)
 	ingressInformer.Informer().AddEventHandler(handleControllerOf)
 
+	// The annotations of a Namespace may override the features of the
+	// Routes of the Namespace.
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.PassNew(func(obj interface{}) {
+			namespace := obj.(*corev1.Namespace).Name
+			impl.FilteredGlobalResync(func(obj interface{}) bool {
+				return obj.(*v1.Route).Namespace == namespace
+			}, routeInformer.Informer())
+		}),
+	})
+
 	c.tracker = impl.Tracker
 
 	// Make sure trackers are deleted once the observers are removed.
@@ -81,6 +81,11 @@ func TestNewRouteCallsSyncHandler(t *testing.T) {
 			Name:      cfgmap.FeaturesConfigName,
 			Namespace: system.Namespace(),
 		},
+	}, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.RolloutConfigName,
+			Namespace: system.Namespace(),
+		},
 	})
 
 	servingClient := fakeservingclient.Get(ctx)
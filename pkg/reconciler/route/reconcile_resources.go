@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -40,6 +41,7 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/resources"
 	"knative.dev/serving/pkg/reconciler/route/resources/names"
@@ -338,9 +340,11 @@ func (c *Reconciler) reconcileRollout(
 		// If not, check if there's a cluster-wide default.
 		rd = cfg.Network.RolloutDurationSecs
 	}
+	// A custom schedule enables the rollout as well.
+	steps := rolloutSteps(r)
 	curRO := tc.BuildRollout()
 	// When rollout is disabled just create the baseline annotation.
-	if rd <= 0 {
+	if rd <= 0 && len(steps) == 0 {
 		return curRO
 	}
 	// Get the current rollout state as described by the traffic.
@@ -361,10 +365,15 @@ func (c *Reconciler) reconcileRollout(
 	rtView := r.Status.GetCondition(v1.RouteConditionIngressReady)
 	if prevRO != nil && ingress.IsReady() && !rtView.IsTrue() {
 		logger.Debug("Observing Ingress not-ready to ready switch condition for rollout")
-		prevRO.ObserveReady(ctx, now, float64(rd))
+		prevRO.ObserveReady(ctx, now, float64(rd), steps)
 	}
+	// Apply the approvals and the manual controls.
+	controlRollout(ctx, r, prevRO, now)
+	// Gate the steps that are due on the health of the revisions.
+	c.analyzeRollout(ctx, r, prevRO, now)
 
 	effectiveRO, nextStepTime := curRO.Step(ctx, prevRO, now)
+	effectiveRO.Aborted = r.RolloutControl() == serving.RolloutControlAbort
 	if nextStepTime > 0 {
 		nextStepTime -= now
 		c.enqueueAfter(r, time.Duration(nextStepTime))
@@ -380,3 +389,125 @@ func (c *Reconciler) reconcileRollout(
 	}
 	return effectiveRO
 }
+
+// rolloutSteps returns the custom rollout schedule of the route, if any.
+func rolloutSteps(r *v1.Route) []traffic.RolloutStep {
+	var ret []traffic.RolloutStep
+	for _, s := range r.RolloutSteps() {
+		ret = append(ret, traffic.RolloutStep{
+			Percent:  s.Percent,
+			Duration: int64(s.Duration),
+			Pause:    s.Pause,
+		})
+	}
+	return ret
+}
+
+// controlRollout lets the rollouts in ro paused at an approved step
+// continue and applies the rollout-control annotation of the route to them.
+func controlRollout(ctx context.Context, r *v1.Route, ro *traffic.Rollout, nowTS int64) {
+	if ro == nil {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	for _, cr := range ro.AwaitingApproval() {
+		if r.RolloutApproved(cr.LatestRevision(), cr.PausedAt()) {
+			logger.Infof("Rollout of revision %s was approved at %d%%", cr.LatestRevision(), cr.PausedAt())
+			cr.Approve(nowTS)
+		}
+	}
+
+	control := r.RolloutControl()
+	for _, cr := range ro.Configurations {
+		switch control {
+		case serving.RolloutControlPause:
+			cr.Pause()
+		case serving.RolloutControlAbort:
+			// Aborting is edge-triggered: the rollouts started after the
+			// annotation was set proceed, until it is set again.
+			if !ro.Aborted && len(cr.Revisions) > 1 && cr.RolledBack == nil {
+				rev := cr.LatestRevision()
+				logger.Infof("Aborting the rollout of revision %s of config %s", rev, cr.ConfigurationName)
+				controller.GetEventRecorder(ctx).Eventf(r, corev1.EventTypeNormal, "RolloutAborted",
+					"Aborted the rollout of revision %q", rev)
+				cr.Rollback("the rollout was aborted")
+			}
+		default:
+			cr.Resume(nowTS)
+		}
+	}
+}
+
+// analysisTimeout bounds the analysis of the rollouts of a Route, so that
+// the queries leave enough of the reconciliation deadline to update the
+// Ingress.
+const analysisTimeout = 5 * time.Second
+
+// analyzeRollout checks the revisions of the configuration rollouts in ro
+// which are due to take a step against the rollout SLO. The rollouts of the
+// revisions violating it are rolled back. If the metrics of a revision
+// cannot be read in time, its next step is postponed.
+func (c *Reconciler) analyzeRollout(ctx context.Context, r *v1.Route, ro *traffic.Rollout, nowTS int64) {
+	cfg := config.FromContext(ctx).Rollout
+	if ro == nil || !cfg.AnalysisEnabled() {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
+	slo := rolloutSLO(cfg, r)
+	src := c.metricsSource(cfg.MetricsURL)
+
+	ctx, cancel := context.WithTimeout(ctx, analysisTimeout)
+	defer cancel()
+
+	for _, cr := range ro.Configurations {
+		if !cr.StepDue(nowTS) {
+			continue
+		}
+		rev := cr.Revisions[len(cr.Revisions)-1].RevisionName
+		// Once out of time, the remaining steps are postponed without
+		// querying the metrics.
+		var res *analysis.Result
+		err := ctx.Err()
+		if err == nil {
+			res, err = src.Query(ctx, r.Namespace, rev, time.Duration(cr.StepParams.StepDuration))
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Infof("Out of time to analyze revision %s, postponing the rollout step", rev)
+			} else {
+				logger.Warnw("Failed to read the metrics of revision "+rev+", postponing the rollout step", zap.Error(err))
+			}
+			cr.StepParams.NextStepTime = nowTS + cr.StepParams.StepDuration
+			continue
+		}
+		if err := slo.Check(res); err != nil {
+			logger.Infof("Rolling back revision %s of config %s: %v", rev, cr.ConfigurationName, err)
+			recorder.Eventf(r, corev1.EventTypeWarning, "RolledBack",
+				"Rolled back revision %q: %v", rev, err)
+			cr.Rollback(err.Error())
+		}
+	}
+}
+
+// rolloutSLO returns the SLO the rollouts of the route are gated on,
+// taking the overrides from the route annotations into account.
+func rolloutSLO(cfg *config.Rollout, r *v1.Route) analysis.SLO {
+	slo := analysis.SLO{
+		MaxErrorRate: cfg.MaxErrorRate,
+		MaxLatency:   cfg.MaxLatency,
+		MinRequests:  float64(cfg.MinRequestCount),
+	}
+	// The webhook validates the annotations, so just ignore invalid values.
+	if _, v, ok := serving.RolloutMaxErrorRateAnnotation.Get(r.Annotations); ok {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			slo.MaxErrorRate = f
+		}
+	}
+	if _, v, ok := serving.RolloutMaxLatencyAnnotation.Get(r.Annotations); ok {
+		if d, err := time.ParseDuration(v); err == nil {
+			slo.MaxLatency = d
+		}
+	}
+	return slo
+}
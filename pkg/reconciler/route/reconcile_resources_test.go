@@ -37,6 +37,7 @@ import (
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	fakeservingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	fakerevisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision/fake"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/resources"
 	"knative.dev/serving/pkg/reconciler/route/traffic"
@@ -624,3 +625,83 @@ func updateContext(ctx context.Context, rolloutDurationSecs int) context.Context
 func getContext() context.Context {
 	return updateContext(context.Background(), 0)
 }
+
+// countingSource counts the queries and blocks them until their context
+// is done.
+type countingSource struct {
+	url     string
+	queries int
+}
+
+func (s *countingSource) Query(ctx context.Context, _, _ string, _ time.Duration) (*analysis.Result, error) {
+	s.queries++
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestCachedMetricsSource(t *testing.T) {
+	built := 0
+	get := cachedMetricsSource(func(url string) analysis.Source {
+		built++
+		return &countingSource{url: url}
+	})
+
+	first := get("http://prometheus")
+	if got := get("http://prometheus"); got != first {
+		t.Error("The source was built again for the same URL")
+	}
+	if got := get("http://thanos").(*countingSource).url; got != "http://thanos" {
+		t.Errorf("Source URL = %q, want: %q", got, "http://thanos")
+	}
+	if built != 2 {
+		t.Errorf("Built %d sources, want: 2", built)
+	}
+}
+
+func TestAnalyzeRolloutDeadline(t *testing.T) {
+	src := &countingSource{}
+	c := &Reconciler{
+		metricsSource: func(string) analysis.Source { return src },
+	}
+
+	cfg := reconcilerTestConfig()
+	cfg.Rollout.MetricsURL = "http://prometheus"
+	ctx, cancel := context.WithTimeout(config.ToContext(context.Background(), cfg), 10*time.Millisecond)
+	defer cancel()
+
+	now := time.Now().UnixNano()
+	rollout := func(name string) *traffic.ConfigurationRollout {
+		return &traffic.ConfigurationRollout{
+			ConfigurationName: name,
+			Percent:           100,
+			Revisions: []traffic.RevisionRollout{{
+				RevisionName: name + "-00001",
+				Percent:      50,
+			}, {
+				RevisionName: name + "-00002",
+				Percent:      50,
+			}},
+			StepParams: traffic.RolloutParams{
+				NextStepTime: now,
+				StepDuration: int64(time.Minute),
+				StepSize:     10,
+			},
+		}
+	}
+	ro := &traffic.Rollout{Configurations: []*traffic.ConfigurationRollout{rollout("a"), rollout("b")}}
+
+	c.analyzeRollout(ctx, Route("test-ns", "test-route"), ro, now)
+
+	// The first query runs into the deadline, the second is not sent.
+	if src.queries != 1 {
+		t.Errorf("Sent %d queries, want: 1", src.queries)
+	}
+	for _, cr := range ro.Configurations {
+		if cr.RolledBack != nil {
+			t.Errorf("Rollout of %s was rolled back: %#v", cr.ConfigurationName, cr.RolledBack)
+		}
+		if got, want := cr.StepParams.NextStepTime, now+int64(time.Minute); got != want {
+			t.Errorf("NextStepTime of %s = %d, want: %d", cr.ConfigurationName, got, want)
+		}
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/davecgh/go-spew/spew"
 	"go.uber.org/zap"
@@ -31,13 +33,16 @@ import (
 	"knative.dev/networking/pkg/apis/networking"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	ingress "knative.dev/networking/pkg/ingress"
+	"knative.dev/pkg/kmap"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
 	"knative.dev/serving/pkg/activator"
 	apicfg "knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingnetworking "knative.dev/serving/pkg/networking"
+	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/domains"
 	"knative.dev/serving/pkg/reconciler/route/resources/labels"
@@ -136,6 +141,10 @@ func makeIngressSpec(
 
 	featuresConfig := config.FromContextOrDefaults(ctx).Features
 	networkConfig := config.FromContextOrDefaults(ctx).Network
+	var fault *servingv1.TrafficFault
+	if featuresConfig.RouteFaultInjection != apicfg.Disabled {
+		fault = r.Spec.Fault
+	}
 
 	for _, name := range names {
 		visibilities := []netv1alpha1.IngressVisibility{netv1alpha1.IngressVisibilityClusterLocal}
@@ -150,6 +159,7 @@ func makeIngressSpec(
 			}
 			rule := makeIngressRule(domains, r.Namespace,
 				visibility, tc.Targets[name], ro.RolloutsByTag(name), networkConfig.ActivatorCA)
+			appendFaultHeader(&rule.HTTP.Paths[0], name, fault)
 			if featuresConfig.TagHeaderBasedRouting == apicfg.Enabled {
 				if rule.HTTP.Paths[0].AppendHeaders == nil {
 					rule.HTTP.Paths[0].AppendHeaders = make(map[string]string, 1)
@@ -171,7 +181,7 @@ func makeIngressSpec(
 					// Since names are sorted `DefaultTarget == ""` is the first one,
 					// so just pass the subslice.
 					rule.HTTP.Paths = append(
-						makeTagBasedRoutingIngressPaths(r.Namespace, tc, ro, networkConfig.ActivatorCA, names[1:]), rule.HTTP.Paths...)
+						makeTagBasedRoutingIngressPaths(r.Namespace, tc, ro, networkConfig.ActivatorCA, fault, names[1:]), rule.HTTP.Paths...)
 				} else {
 					// If a request is routed by a tag-attached hostname instead of the tag header,
 					// the request may not have the tag header "Knative-Serving-Tag",
@@ -183,6 +193,18 @@ func makeIngressSpec(
 					rule.HTTP.Paths[0].AppendHeaders[network.TagHeaderName] = name
 				}
 			}
+			if name == traffic.DefaultTarget && (len(r.Spec.Match) > 0 || len(tc.PathTargets) > 0) {
+				// The requests matching the rules of the Route are routed to
+				// their targets before the remaining ones are split by
+				// percentage, and the requests for the paths of the target
+				// groups to the groups, so their paths precede the catch-all one.
+				tagHeaders := featuresConfig.TagHeaderBasedRouting == apicfg.Enabled
+				last := len(rule.HTTP.Paths) - 1
+				paths := append(
+					makeMatchIngressPaths(r.Namespace, r.Spec.Match, tc, ro, networkConfig.ActivatorCA, fault, tagHeaders),
+					makePathIngressPaths(r.Namespace, tc, ro, networkConfig.ActivatorCA, fault, tagHeaders)...)
+				rule.HTTP.Paths = append(append(rule.HTTP.Paths[:last:last], paths...), rule.HTTP.Paths[last])
+			}
 			// If this is a public rule, we need to configure ACME challenge paths.
 			if visibility == netv1alpha1.IngressVisibilityExternalIP {
 				rule.HTTP.Paths = append(
@@ -192,6 +214,8 @@ func makeIngressSpec(
 		}
 	}
 
+	appendSplitRoute(rules, r.Name)
+
 	httpOption, err := servingnetworking.GetHTTPOption(ctx, config.FromContext(ctx).Network, r.GetAnnotations())
 	if err != nil {
 		return netv1alpha1.IngressSpec{}, err
@@ -278,18 +302,103 @@ func makeIngressRule(domains []string, ns string,
 }
 
 // `names` must not include `""` — the DefaultTarget.
-func makeTagBasedRoutingIngressPaths(ns string, tc *traffic.Config, ro *traffic.Rollout, activatorCA string, names []string) []netv1alpha1.HTTPIngressPath {
+func makeTagBasedRoutingIngressPaths(ns string, tc *traffic.Config, ro *traffic.Rollout, activatorCA string,
+	fault *servingv1.TrafficFault, names []string) []netv1alpha1.HTTPIngressPath {
 	paths := make([]netv1alpha1.HTTPIngressPath, 0, len(names))
 
 	for _, name := range names {
 		path := makeBaseIngressPath(ns, tc.Targets[name], ro.RolloutsByTag(name), activatorCA)
 		path.Headers = map[string]netv1alpha1.HeaderMatch{network.TagHeaderName: {Exact: name}}
+		appendFaultHeader(path, name, fault)
+		paths = append(paths, *path)
+	}
+
+	return paths
+}
+
+// makeMatchIngressPaths returns the paths routing the requests matching
+// the rules of a Route to their tagged targets.
+func makeMatchIngressPaths(ns string, matches []servingv1.TrafficMatch, tc *traffic.Config, ro *traffic.Rollout,
+	activatorCA string, fault *servingv1.TrafficFault, appendTagHeader bool) []netv1alpha1.HTTPIngressPath {
+	paths := make([]netv1alpha1.HTTPIngressPath, 0, len(matches))
+
+	for _, m := range matches {
+		path := makeBaseIngressPath(ns, tc.Targets[m.Tag], ro.RolloutsByTag(m.Tag), activatorCA)
+		path.Headers = make(map[string]netv1alpha1.HeaderMatch, len(m.Headers))
+		for _, h := range m.Headers {
+			path.Headers[h.Name] = netv1alpha1.HeaderMatch{Exact: h.Exact}
+		}
+		if appendTagHeader {
+			// As with tag-attached hostnames, tell the queue-proxy which
+			// tag the request was routed to.
+			path.AppendHeaders = map[string]string{network.TagHeaderName: m.Tag}
+		}
+		appendFaultHeader(path, m.Tag, fault)
+		paths = append(paths, *path)
+	}
+
+	return paths
+}
+
+// makePathIngressPaths returns the paths splitting the requests for the
+// path prefixes of the traffic target groups, longest prefix first.
+func makePathIngressPaths(ns string, tc *traffic.Config, ro *traffic.Rollout,
+	activatorCA string, fault *servingv1.TrafficFault, appendDefaultRouteHeader bool) []netv1alpha1.HTTPIngressPath {
+	prefixes := make([]string, 0, len(tc.PathTargets))
+	for prefix := range tc.PathTargets {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	paths := make([]netv1alpha1.HTTPIngressPath, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		path := makeBaseIngressPath(ns, tc.PathTargets[prefix], ro.RolloutsByPath(prefix), activatorCA)
+		path.Path = prefix
+		if appendDefaultRouteHeader {
+			// The groups are served on the hostname of the default route.
+			path.AppendHeaders = map[string]string{network.DefaultRouteHeaderName: "true"}
+		}
+		appendFaultHeader(path, traffic.DefaultTarget, fault)
 		paths = append(paths, *path)
 	}
 
 	return paths
 }
 
+// appendFaultHeader tells the queue proxy to inject the fault into the
+// requests routed by the path to the targets of the tag, unless the fault
+// is restricted to another tag, in which case the header stays cleared.
+func appendFaultHeader(path *netv1alpha1.HTTPIngressPath, tag string, fault *servingv1.TrafficFault) {
+	if fault == nil || (fault.Tag != "" && fault.Tag != tag) {
+		return
+	}
+	f := queue.Fault{
+		Percent:     int(fault.Percent),
+		AbortStatus: fault.AbortStatus,
+	}
+	if fault.Delay != nil {
+		f.Delay = fault.Delay.Duration
+	}
+	for _, h := range fault.Headers {
+		f.Headers = append(f.Headers, queue.FaultHeaderMatch{
+			Name:   h.Name,
+			Exact:  h.Exact,
+			Prefix: h.Prefix,
+			Regex:  h.Regex,
+		})
+	}
+	// Marshaling strings and numbers cannot fail.
+	b, _ := json.Marshal(f)
+	for i := range path.Splits {
+		path.Splits[i].AppendHeaders[queue.FaultHeaderName] = string(b)
+	}
+}
+
 func rolloutConfig(cfgName string, ros []*traffic.ConfigurationRollout) *traffic.ConfigurationRollout {
 	idx := sort.Search(len(ros), func(i int) bool {
 		return ros[i].ConfigurationName >= cfgName
@@ -307,7 +416,7 @@ func makeBaseIngressPath(ns string, targets traffic.RevisionTargets,
 	splits := make([]netv1alpha1.IngressBackendSplit, 0, len(targets))
 	for _, t := range targets {
 		var cfg *traffic.ConfigurationRollout
-		if *t.Percent == 0 {
+		if t.Share() == 0 {
 			continue
 		}
 
@@ -320,6 +429,7 @@ func makeBaseIngressPath(ns string, targets traffic.RevisionTargets,
 		} else {
 			servicePort = intstr.FromInt(networking.ServicePort(t.Protocol))
 		}
+		first := len(splits)
 		if cfg == nil || len(cfg.Revisions) < 2 {
 			// No rollout in progress.
 			splits = append(splits, netv1alpha1.IngressBackendSplit{
@@ -330,7 +440,7 @@ func makeBaseIngressPath(ns string, targets traffic.RevisionTargets,
 					// Otherwise, the serverless services can't guarantee seamless positive handoff.
 					ServicePort: servicePort,
 				},
-				Percent: int(*t.Percent),
+				Percent: int(t.Share()),
 				AppendHeaders: map[string]string{
 					activator.RevisionHeaderName:      t.TrafficTarget.RevisionName,
 					activator.RevisionHeaderNamespace: ns,
@@ -355,9 +465,137 @@ func makeBaseIngressPath(ns string, targets traffic.RevisionTargets,
 				})
 			}
 		}
+		for i := first; i < len(splits); i++ {
+			appendHeaderMutations(splits[i].AppendHeaders, t.Headers)
+			// The split header is cleared unless splitByWeight sets it, so
+			// the clients cannot pick the revision of their requests.
+			splits[i].AppendHeaders[activator.SplitHeaderName] = ""
+		}
+	}
+
+	if len(targets) > 0 && targets[0].Weight != nil {
+		splits = splitByWeight(splits)
+	}
+
+	mirrors := mirrorHeader(targets)
+	for i := range splits {
+		if mirrors != "" {
+			// The Ingress cannot mirror requests, so they are routed through
+			// the activator, which copies them to the shadow revisions.
+			splits[i].ServiceNamespace = system.Namespace()
+			splits[i].ServiceName = servingnetworking.ActivatorServiceName
+		}
+		// The header is cleared when there are no mirrors, so the clients
+		// cannot have their requests copied.
+		splits[i].AppendHeaders[activator.MirrorHeaderName] = mirrors
+		// Likewise, the fault header is cleared until appendFaultHeader
+		// sets it, so the clients cannot inject faults themselves.
+		splits[i].AppendHeaders[queue.FaultHeaderName] = ""
+		// Routes never rewrite the path, so it is cleared too.
+		splits[i].AppendHeaders[queue.PathRewriteHeaderName] = ""
 	}
 
 	return &netv1alpha1.HTTPIngressPath{
 		Splits: splits,
 	}
 }
+
+// splitByWeight converts the splits of a Route split by weight, whose
+// percents are basis points, to the whole percents of the Ingress. When a
+// split is finer than a percent, the requests of the parts of the splits
+// finer than a percent are routed through the activator, which splits them
+// over the revisions of the split header. The whole percents of the splits
+// are still routed by the Ingress.
+func splitByWeight(splits []netv1alpha1.IngressBackendSplit) []netv1alpha1.IngressBackendSplit {
+	const factor = servingv1.MaxTrafficWeight / 100
+	ret := make([]netv1alpha1.IngressBackendSplit, 0, len(splits)+1)
+	var (
+		weights []string
+		rest    int
+	)
+	for _, s := range splits {
+		if r := s.Percent % factor; r != 0 {
+			weights = append(weights, s.AppendHeaders[activator.RevisionHeaderName]+"="+strconv.Itoa(r))
+			rest += r
+		}
+		if s.Percent >= factor {
+			s.Percent /= factor
+			ret = append(ret, s)
+		}
+	}
+	if rest == 0 {
+		return ret
+	}
+
+	// The targets of the Routes split by weight only have the header
+	// mutations of the Route, common to all the splits. The weights sum to
+	// MaxTrafficWeight, so the rest is a whole percent.
+	headers := kmap.Copy(splits[0].AppendHeaders)
+	headers[activator.RevisionHeaderName] = ""
+	headers[activator.SplitHeaderName] = strings.Join(weights, ",")
+	return append(ret, netv1alpha1.IngressBackendSplit{
+		IngressBackend: netv1alpha1.IngressBackend{
+			ServiceNamespace: system.Namespace(),
+			ServiceName:      servingnetworking.ActivatorServiceName,
+			ServicePort:      splits[0].ServicePort,
+		},
+		Percent:       rest / factor,
+		AppendHeaders: headers,
+	})
+}
+
+// appendSplitRoute tells the activator which Route the requests it splits
+// belong to, so it only splits them over the revisions of the Route.
+func appendSplitRoute(rules []netv1alpha1.IngressRule, route string) {
+	for _, rule := range rules {
+		for _, path := range rule.HTTP.Paths {
+			for _, split := range path.Splits {
+				if split.AppendHeaders[activator.SplitHeaderName] != "" {
+					split.AppendHeaders[activator.RouteHeaderName] = route
+				}
+			}
+		}
+	}
+}
+
+// appendHeaderMutations adds the header mutations of a target to the
+// headers appended to the requests of its splits. The Ingress sets the
+// headers of the requests, the queue proxy applies the other mutations.
+// The header of the queue proxy is cleared when there are none of them, so
+// the clients cannot have their requests and responses mutated.
+func appendHeaderMutations(headers map[string]string, hm *servingv1.HeaderMutations) {
+	headers[queue.HeaderMutationsHeaderName] = ""
+	if hm == nil {
+		return
+	}
+	var qm queue.HeaderMutations
+	if req := hm.Request; req != nil {
+		for name, value := range req.Set {
+			headers[name] = value
+		}
+		qm.RequestAdd, qm.RequestRemove = req.Add, req.Remove
+	}
+	if resp := hm.Response; resp != nil {
+		qm.ResponseSet, qm.ResponseAdd, qm.ResponseRemove = resp.Set, resp.Add, resp.Remove
+	}
+	if len(qm.RequestAdd)+len(qm.RequestRemove)+len(qm.ResponseSet)+len(qm.ResponseAdd)+len(qm.ResponseRemove) == 0 {
+		return
+	}
+	// Marshaling maps of strings and slices of strings cannot fail.
+	b, _ := json.Marshal(qm)
+	headers[queue.HeaderMutationsHeaderName] = string(b)
+}
+
+// mirrorHeader returns the value of the mirror header of the requests split
+// over the targets, listing the revisions of their shadow targets.
+func mirrorHeader(targets traffic.RevisionTargets) string {
+	var mirrors []string
+	for _, t := range targets {
+		// A shadow target alone in its group, e.g. behind its tag, serves
+		// the requests of the group itself.
+		if t.MirrorPercent != nil && t.Share() == 0 {
+			mirrors = append(mirrors, t.RevisionName+"="+strconv.FormatInt(*t.MirrorPercent, 10))
+		}
+	}
+	return strings.Join(mirrors, ",")
+}
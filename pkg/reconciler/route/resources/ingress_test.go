@@ -270,8 +270,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 1,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "rune-01911",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "rune-01911",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -281,8 +286,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 41,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "valhalla-01981",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "valhalla-01981",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -292,8 +302,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 68,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "valhalla-01982",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "valhalla-01982",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -313,8 +328,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 1,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "rune-01911",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "rune-01911",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -324,8 +344,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 41,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "valhalla-01981",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "valhalla-01981",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -335,8 +360,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 68,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "valhalla-01982",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "valhalla-01982",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -358,8 +388,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 60,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-02018",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-02018",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -369,8 +404,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 15,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-02019",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-02019",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -380,8 +420,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 5,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-02020",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-02020",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -391,8 +436,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 20,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-beta",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-beta",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -412,8 +462,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 60,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-02018",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-02018",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -423,8 +478,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 15,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-02019",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-02019",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -434,8 +494,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 5,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-02020",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-02020",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -445,8 +510,13 @@ func TestMakeIngressWithActualRollout(t *testing.T) {
 					},
 					Percent: 20,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "thor-beta",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "thor-beta",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -509,8 +579,13 @@ func TestMakeIngressSpecCorrectRules(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -530,8 +605,13 @@ func TestMakeIngressSpecCorrectRules(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -553,8 +633,13 @@ func TestMakeIngressSpecCorrectRules(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -574,8 +659,13 @@ func TestMakeIngressSpecCorrectRules(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -718,8 +808,13 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}, {
@@ -734,8 +829,13 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -760,8 +860,13 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}, {
@@ -776,8 +881,13 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -802,8 +912,13 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -826,8 +941,13 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -851,6 +971,479 @@ func TestMakeIngressSpecCorrectRulesWithTagBasedRouting(t *testing.T) {
 }
 
 // One active target.
+func TestMakeIngressSpecCorrectRulesWithMatch(t *testing.T) {
+	targets := map[string]traffic.RevisionTargets{
+		traffic.DefaultTarget: {{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: "config",
+				RevisionName:      "v2",
+				Percent:           ptr.Int64(100),
+			},
+		}},
+		"canary": {{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: "config",
+				RevisionName:      "v1",
+				Percent:           ptr.Int64(100),
+			},
+		}},
+	}
+
+	r := Route(ns, "test-route", WithURL, WithSpecMatch(v1.TrafficMatch{
+		Tag: "canary",
+		Headers: []v1.TrafficHeaderMatch{{
+			Name:  "X-User",
+			Exact: "alice",
+		}, {
+			Name:  "X-Beta",
+			Exact: "true",
+		}},
+	}))
+
+	split := func(rev string) []netv1alpha1.IngressBackendSplit {
+		return []netv1alpha1.IngressBackendSplit{{
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: ns,
+				ServiceName:      rev,
+				ServicePort:      intstr.FromInt(80),
+			},
+			Percent: 100,
+			AppendHeaders: map[string]string{
+				"Knative-Serving-Revision":         rev,
+				"Knative-Serving-Namespace":        ns,
+				"Knative-Serving-Mirror":           "",
+				"Knative-Serving-Header-Mutations": "",
+				"Knative-Serving-Fault":            "",
+				"Knative-Serving-Split":            "",
+				"Knative-Serving-Path-Rewrite":     "",
+			},
+		}}
+	}
+	expected := []netv1alpha1.HTTPIngressPath{{
+		Headers: map[string]netv1alpha1.HeaderMatch{
+			"X-User": {Exact: "alice"},
+			"X-Beta": {Exact: "true"},
+		},
+		Splits: split("v1"),
+	}, {
+		Splits: split("v2"),
+	}}
+
+	tc := &traffic.Config{Targets: targets}
+	ro := tc.BuildRollout()
+	ci, err := makeIngressSpec(testContext(), r, nil /*tls*/, tc, ro)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	if got, want := len(ci.Rules), 4; got != want {
+		t.Fatalf("len(Rules) = %d, want: %d", got, want)
+	}
+	for _, rule := range ci.Rules {
+		if strings.HasPrefix(rule.Hosts[0], "canary-") {
+			// The tag-attached hostnames only route to their target.
+			if want := split("v1"); len(rule.HTTP.Paths) != 1 || !cmp.Equal(want, rule.HTTP.Paths[0].Splits) {
+				t.Errorf("Unexpected paths for %v: %v", rule.Hosts, rule.HTTP.Paths)
+			}
+			continue
+		}
+		if !cmp.Equal(expected, rule.HTTP.Paths) {
+			t.Errorf("Unexpected paths for %v (-want, +got): %s", rule.Hosts, cmp.Diff(expected, rule.HTTP.Paths))
+		}
+	}
+}
+
+func TestMakeIngressSpecCorrectRulesWithPaths(t *testing.T) {
+	target := func(rev, path string) traffic.RevisionTargets {
+		return traffic.RevisionTargets{{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: "config-" + rev,
+				RevisionName:      rev,
+				Path:              path,
+				Percent:           ptr.Int64(100),
+			},
+		}}
+	}
+	split := func(rev string) []netv1alpha1.IngressBackendSplit {
+		return []netv1alpha1.IngressBackendSplit{{
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: ns,
+				ServiceName:      rev,
+				ServicePort:      intstr.FromInt(80),
+			},
+			Percent: 100,
+			AppendHeaders: map[string]string{
+				"Knative-Serving-Revision":         rev,
+				"Knative-Serving-Namespace":        ns,
+				"Knative-Serving-Mirror":           "",
+				"Knative-Serving-Header-Mutations": "",
+				"Knative-Serving-Fault":            "",
+				"Knative-Serving-Split":            "",
+				"Knative-Serving-Path-Rewrite":     "",
+			},
+		}}
+	}
+
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: target("web", ""),
+		},
+		PathTargets: map[string]traffic.RevisionTargets{
+			"/api":    target("api", "/api"),
+			"/api/v2": target("api-v2", "/api/v2"),
+			"/static": target("static", "/static"),
+		},
+	}
+	// The longest prefixes come first.
+	expected := []netv1alpha1.HTTPIngressPath{{
+		Path:   "/api/v2",
+		Splits: split("api-v2"),
+	}, {
+		Path:   "/static",
+		Splits: split("static"),
+	}, {
+		Path:   "/api",
+		Splits: split("api"),
+	}, {
+		Splits: split("web"),
+	}}
+
+	r := Route(ns, "test-route", WithURL)
+	ci, err := makeIngressSpec(testContext(), r, nil /*tls*/, tc, tc.BuildRollout())
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	if got, want := len(ci.Rules), 2; got != want {
+		t.Fatalf("len(Rules) = %d, want: %d", got, want)
+	}
+	for _, rule := range ci.Rules {
+		if !cmp.Equal(expected, rule.HTTP.Paths) {
+			t.Errorf("Unexpected paths for %v (-want, +got): %s", rule.Hosts, cmp.Diff(expected, rule.HTTP.Paths))
+		}
+	}
+}
+
+func TestMakeIngressSpecFault(t *testing.T) {
+	target := func(rev, tag string) traffic.RevisionTargets {
+		return traffic.RevisionTargets{{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: "config",
+				RevisionName:      rev,
+				Tag:               tag,
+				Percent:           ptr.Int64(100),
+			},
+		}}
+	}
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: target("web", ""),
+			"canary":              target("canary", "canary"),
+		},
+	}
+	r := Route(ns, "test-route", WithURL)
+	r.Spec.Fault = &v1.TrafficFault{
+		Percent:     10,
+		AbortStatus: 503,
+		Tag:         "canary",
+		Headers: []v1.HeaderMatch{{
+			Name:   "User-Agent",
+			Prefix: "curl/",
+		}},
+	}
+	const wantFault = `{"percent":10,"abortStatus":503,"headers":[{"name":"User-Agent","prefix":"curl/"}]}`
+
+	for _, flag := range []apicfg.Flag{apicfg.Enabled, apicfg.Disabled} {
+		t.Run(string(flag), func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Features.TagHeaderBasedRouting = apicfg.Enabled
+			cfg.Features.RouteFaultInjection = flag
+			ctx := config.ToContext(context.Background(), cfg)
+
+			ci, err := makeIngressSpec(ctx, r, nil /*tls*/, tc, tc.BuildRollout())
+			if err != nil {
+				t.Fatal("Unexpected error", err)
+			}
+
+			// The fault is injected into the requests routed by the
+			// hostname or the header of the tag.
+			injected := 0
+			for _, rule := range ci.Rules {
+				for _, path := range rule.HTTP.Paths {
+					want := ""
+					if flag == apicfg.Enabled && (path.AppendHeaders[network.TagHeaderName] == "canary" ||
+						path.Headers[network.TagHeaderName].Exact == "canary") {
+						want = wantFault
+						injected++
+					}
+					// The header is cleared on the other paths.
+					for _, split := range path.Splits {
+						if got, ok := split.AppendHeaders["Knative-Serving-Fault"]; !ok || got != want {
+							t.Errorf("Fault of %v to %s = %q, want: %q", rule.Hosts, split.ServiceName, got, want)
+						}
+					}
+				}
+			}
+			// Both the public and the cluster-local hostnames and headers.
+			if want := map[apicfg.Flag]int{apicfg.Enabled: 4}[flag]; injected != want {
+				t.Errorf("Fault injected on %d paths, want: %d", injected, want)
+			}
+		})
+	}
+}
+
+func TestMakeIngressRuleMirror(t *testing.T) {
+	targets := traffic.RevisionTargets{{
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "config",
+			RevisionName:      "revision",
+			Percent:           ptr.Int64(100),
+		},
+	}, {
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "model",
+			RevisionName:      "shadow",
+			Percent:           ptr.Int64(0),
+			MirrorPercent:     ptr.Int64(10),
+		},
+	}}
+	domains := []string{"test.org"}
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: targets,
+		},
+	}
+	ro := tc.BuildRollout()
+	rule := makeIngressRule(domains, ns,
+		netv1alpha1.IngressVisibilityExternalIP, targets, ro.RolloutsByTag(traffic.DefaultTarget), "" /* activatorCA */)
+	expected := netv1alpha1.IngressRule{
+		Hosts: []string{
+			"test.org",
+		},
+		HTTP: &netv1alpha1.HTTPIngressRuleValue{
+			Paths: []netv1alpha1.HTTPIngressPath{{
+				// The requests are routed through the activator, which
+				// copies them to the shadow.
+				Splits: []netv1alpha1.IngressBackendSplit{{
+					IngressBackend: netv1alpha1.IngressBackend{
+						ServiceNamespace: system.Namespace(),
+						ServiceName:      "activator-service",
+						ServicePort:      intstr.FromInt(80),
+					},
+					Percent: 100,
+					AppendHeaders: map[string]string{
+						"Knative-Serving-Revision":         "revision",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "shadow=10",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
+					},
+				}},
+			}},
+		},
+		Visibility: netv1alpha1.IngressVisibilityExternalIP,
+	}
+
+	if !cmp.Equal(expected, rule) {
+		t.Error("Unexpected rule (-want, +got):", cmp.Diff(expected, rule))
+	}
+
+	// Behind its tag, the shadow serves the requests itself.
+	tagged := traffic.RevisionTargets{targets[1]}
+	tagged[0].Percent = ptr.Int64(100)
+	path := makeBaseIngressPath(ns, tagged, nil, "" /* activatorCA */)
+	if got, want := path.Splits[0].ServiceName, "shadow"; got != want {
+		t.Errorf("ServiceName = %s, want: %s", got, want)
+	}
+	if got := path.Splits[0].AppendHeaders["Knative-Serving-Mirror"]; got != "" {
+		t.Errorf("Tagged shadow got mirror header %q, want it cleared", got)
+	}
+}
+
+func TestMakeIngressRuleWeights(t *testing.T) {
+	targets := traffic.RevisionTargets{{
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "config",
+			RevisionName:      "revision",
+			Weight:            ptr.Int64(9000),
+		},
+	}, {
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "config",
+			RevisionName:      "canary",
+			Weight:            ptr.Int64(1000),
+		},
+	}}
+	domains := []string{"test.org"}
+
+	// Whole percents are split by the Ingress.
+	rule := makeIngressRule(domains, ns,
+		netv1alpha1.IngressVisibilityExternalIP, targets, nil /* rollouts */, "" /* activatorCA */)
+	splits := rule.HTTP.Paths[0].Splits
+	if got, want := len(splits), 2; got != want {
+		t.Fatalf("Got %d splits, want: %d", got, want)
+	}
+	if got, want := []int{splits[0].Percent, splits[1].Percent}, []int{90, 10}; !cmp.Equal(got, want) {
+		t.Errorf("Split percents = %v, want: %v", got, want)
+	}
+
+	// Finer splits are made by the activator, for the share of the requests
+	// finer than a percent only.
+	targets[0].Weight = ptr.Int64(9995)
+	targets[1].Weight = ptr.Int64(5)
+	rule = makeIngressRule(domains, ns,
+		netv1alpha1.IngressVisibilityExternalIP, targets, nil /* rollouts */, "" /* activatorCA */)
+	expected := netv1alpha1.IngressRule{
+		Hosts: []string{
+			"test.org",
+		},
+		HTTP: &netv1alpha1.HTTPIngressRuleValue{
+			Paths: []netv1alpha1.HTTPIngressPath{{
+				Splits: []netv1alpha1.IngressBackendSplit{{
+					IngressBackend: netv1alpha1.IngressBackend{
+						ServiceNamespace: ns,
+						ServiceName:      "revision",
+						ServicePort:      intstr.FromInt(80),
+					},
+					Percent: 99,
+					AppendHeaders: map[string]string{
+						"Knative-Serving-Revision":         "revision",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Path-Rewrite":     "",
+					},
+				}, {
+					IngressBackend: netv1alpha1.IngressBackend{
+						ServiceNamespace: system.Namespace(),
+						ServiceName:      "activator-service",
+						ServicePort:      intstr.FromInt(80),
+					},
+					Percent: 1,
+					AppendHeaders: map[string]string{
+						"Knative-Serving-Revision":         "",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Split":            "revision=95,canary=5",
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Path-Rewrite":     "",
+					},
+				}},
+			}},
+		},
+		Visibility: netv1alpha1.IngressVisibilityExternalIP,
+	}
+	if !cmp.Equal(expected, rule) {
+		t.Error("Unexpected rule (-want, +got):", cmp.Diff(expected, rule))
+	}
+}
+
+func TestAppendSplitRoute(t *testing.T) {
+	rules := []netv1alpha1.IngressRule{{
+		HTTP: &netv1alpha1.HTTPIngressRuleValue{
+			Paths: []netv1alpha1.HTTPIngressPath{{
+				Splits: []netv1alpha1.IngressBackendSplit{{
+					AppendHeaders: map[string]string{"Knative-Serving-Split": ""},
+				}, {
+					AppendHeaders: map[string]string{"Knative-Serving-Split": "revision=95,canary=5"},
+				}},
+			}},
+		},
+	}}
+	appendSplitRoute(rules, "route")
+
+	splits := rules[0].HTTP.Paths[0].Splits
+	if got, ok := splits[0].AppendHeaders["Knative-Serving-Route"]; ok {
+		t.Errorf("Route of the split of the Ingress = %q, want none", got)
+	}
+	if got, want := splits[1].AppendHeaders["Knative-Serving-Route"], "route"; got != want {
+		t.Errorf("Route of the split of the activator = %q, want: %q", got, want)
+	}
+}
+
+func TestMakeIngressRuleHeaders(t *testing.T) {
+	targets := traffic.RevisionTargets{{
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "config",
+			RevisionName:      "revision",
+			Percent:           ptr.Int64(90),
+		},
+	}, {
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "config",
+			RevisionName:      "canary",
+			Percent:           ptr.Int64(10),
+			Headers: &v1.HeaderMutations{
+				Request: &v1.HeaderOperations{
+					Set:    map[string]string{"Canary": "true"},
+					Remove: []string{"Debug"},
+				},
+				Response: &v1.HeaderOperations{
+					Add: map[string]string{"Vary": "Canary"},
+				},
+			},
+		},
+	}}
+	domains := []string{"test.org"}
+	rule := makeIngressRule(domains, ns,
+		netv1alpha1.IngressVisibilityExternalIP, targets, nil /* rollouts */, "" /* activatorCA */)
+	expected := netv1alpha1.IngressRule{
+		Hosts: []string{
+			"test.org",
+		},
+		HTTP: &netv1alpha1.HTTPIngressRuleValue{
+			Paths: []netv1alpha1.HTTPIngressPath{{
+				Splits: []netv1alpha1.IngressBackendSplit{{
+					IngressBackend: netv1alpha1.IngressBackend{
+						ServiceNamespace: ns,
+						ServiceName:      "revision",
+						ServicePort:      intstr.FromInt(80),
+					},
+					Percent: 90,
+					AppendHeaders: map[string]string{
+						"Knative-Serving-Revision":         "revision",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
+					},
+				}, {
+					IngressBackend: netv1alpha1.IngressBackend{
+						ServiceNamespace: ns,
+						ServiceName:      "canary",
+						ServicePort:      intstr.FromInt(80),
+					},
+					Percent: 10,
+					// The Ingress sets the request headers, the queue proxy
+					// applies the other mutations.
+					AppendHeaders: map[string]string{
+						"Knative-Serving-Revision":         "canary",
+						"Knative-Serving-Namespace":        ns,
+						"Canary":                           "true",
+						"Knative-Serving-Header-Mutations": `{"requestRemove":["Debug"],"responseAdd":{"Vary":"Canary"}}`,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
+					},
+				}},
+			}},
+		},
+		Visibility: netv1alpha1.IngressVisibilityExternalIP,
+	}
+
+	if !cmp.Equal(expected, rule) {
+		t.Error("Unexpected rule (-want, +got):", cmp.Diff(expected, rule))
+	}
+}
+
 func TestMakeIngressRuleVanilla(t *testing.T) {
 	domains := []string{"a.com", "b.org"}
 	targets := traffic.RevisionTargets{{
@@ -883,8 +1476,13 @@ func TestMakeIngressRuleVanilla(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "revision-shark",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "revision-shark",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -933,8 +1531,13 @@ func TestMakeIngressRuleZeroPercentTarget(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "revision-dolphin",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "revision-dolphin",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -983,8 +1586,13 @@ func TestMakeIngressRuleTwoTargets(t *testing.T) {
 					},
 					Percent: 80,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Namespace": ns,
-						"Knative-Serving-Revision":  "revision-beluga",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Revision":         "revision-beluga",
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}, {
 					IngressBackend: netv1alpha1.IngressBackend{
@@ -994,8 +1602,13 @@ func TestMakeIngressRuleTwoTargets(t *testing.T) {
 					},
 					Percent: 20,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Namespace": ns,
-						"Knative-Serving-Revision":  "new-revision-narwhal",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Revision":         "new-revision-narwhal",
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -1125,8 +1738,13 @@ func TestMakeIngressWithActivatorCA(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -1146,8 +1764,13 @@ func TestMakeIngressWithActivatorCA(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -1169,8 +1792,13 @@ func TestMakeIngressWithActivatorCA(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -1190,8 +1818,13 @@ func TestMakeIngressWithActivatorCA(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v1",
-						"Knative-Serving-Namespace": ns,
+						"Knative-Serving-Revision":         "v1",
+						"Knative-Serving-Namespace":        ns,
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}},
@@ -1288,8 +1921,13 @@ func TestMakeIngressACMEChallenges(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": "test-ns",
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        "test-ns",
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}}},
@@ -1318,8 +1956,13 @@ func TestMakeIngressACMEChallenges(t *testing.T) {
 					},
 					Percent: 100,
 					AppendHeaders: map[string]string{
-						"Knative-Serving-Revision":  "v2",
-						"Knative-Serving-Namespace": "test-ns",
+						"Knative-Serving-Revision":         "v2",
+						"Knative-Serving-Namespace":        "test-ns",
+						"Knative-Serving-Mirror":           "",
+						"Knative-Serving-Header-Mutations": "",
+						"Knative-Serving-Fault":            "",
+						"Knative-Serving-Split":            "",
+						"Knative-Serving-Path-Rewrite":     "",
 					},
 				}},
 			}}},
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubelabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -51,6 +52,7 @@ import (
 	listers "knative.dev/serving/pkg/client/listers/serving/v1"
 	kaccessor "knative.dev/serving/pkg/reconciler/accessor"
 	networkaccessor "knative.dev/serving/pkg/reconciler/accessor/networking"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/domains"
 	"knative.dev/serving/pkg/reconciler/route/resources"
@@ -73,10 +75,15 @@ type Reconciler struct {
 	endpointsLister     corev1listers.EndpointsLister
 	ingressLister       networkinglisters.IngressLister
 	certificateLister   networkinglisters.CertificateLister
+	namespaceLister     corev1listers.NamespaceLister
 	tracker             tracker.Interface
 
 	clock        clock.PassiveClock
 	enqueueAfter func(interface{}, time.Duration)
+
+	// metricsSource returns the source of the metrics the rollouts are
+	// gated on, given the URL configured in config-rollout.
+	metricsSource func(url string) analysis.Source
 }
 
 // Check that our Reconciler implements routereconciler.Interface
@@ -101,6 +108,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, r *v1.Route) pkgreconcil
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	// The annotations of the Namespace may override the features.
+	if ns, err := c.namespaceLister.Get(r.Namespace); err == nil {
+		ctx = config.WithNamespaceFeatures(ctx, ns.Annotations)
+	} else if !apierrs.IsNotFound(err) {
+		return err
+	}
+
 	logger := logging.FromContext(ctx)
 	logger.Debugf("Reconciling route: %#v", r.Spec)
 
@@ -148,12 +162,25 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, r *v1.Route) pkgreconcil
 	}
 
 	roInProgress := !effectiveRO.Done()
+	r.Status.Rollouts = effectiveRO.Status()
 	if ingress.GetObjectMeta().GetGeneration() != ingress.Status.ObservedGeneration {
 		r.Status.MarkIngressNotConfigured()
 	} else if !roInProgress {
 		r.Status.PropagateIngressStatus(ingress.Status)
 	}
 
+	if rolledBack := effectiveRO.RolledBack(); len(rolledBack) > 0 {
+		rb := rolledBack[0].RolledBack
+		r.Status.MarkRolloutRolledBack(rb.RevisionName, rb.Reason)
+		// The traffic is served by the revisions the rollouts were rolled back to.
+		r.Status.Traffic, err = traffic.GetRevisionTrafficTargets(ctx, r, effectiveRO)
+		if err != nil {
+			return err
+		}
+	} else {
+		r.Status.ClearRolloutRolledBack()
+	}
+
 	logger.Info("Updating placeholder k8s services with ingress information")
 	if err := c.updatePlaceholderServices(ctx, r, services, ingress); err != nil {
 		return err
@@ -166,7 +193,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, r *v1.Route) pkgreconcil
 	if roInProgress {
 		logger.Info("Rollout is in progress")
 		// Rollout in progress, so mark the status as such.
-		r.Status.MarkIngressRolloutInProgress()
+		if len(effectiveRO.Paused()) > 0 {
+			r.Status.MarkIngressRolloutPaused()
+		} else if awaiting := effectiveRO.AwaitingApproval(); len(awaiting) > 0 {
+			r.Status.MarkIngressRolloutAwaitingApproval(awaiting[0].LatestRevision(), awaiting[0].PausedAt())
+		} else {
+			r.Status.MarkIngressRolloutInProgress()
+		}
 		// Update the route.Status.Traffic to contain correct traffic
 		// distribution based on rollout status.
 		r.Status.Traffic, err = traffic.GetRevisionTrafficTargets(ctx, r, effectiveRO)
@@ -33,6 +33,7 @@ import (
 
 	_ "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/certificate/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
 
 	"github.com/google/go-cmp/cmp"
@@ -127,6 +128,11 @@ func newTestSetup(t *testing.T, opts ...reconcilerOption) (
 			Name:      cfgmap.FeaturesConfigName,
 			Namespace: system.Namespace(),
 		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.RolloutConfigName,
+			Namespace: system.Namespace(),
+		},
 	}} {
 		configMapWatcher.OnChange(cfg)
 	}
@@ -236,8 +242,13 @@ func TestCreateRouteForOneReserveRevision(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  "test-rev",
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         "test-rev",
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -257,8 +268,13 @@ func TestCreateRouteForOneReserveRevision(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  "test-rev",
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         "test-rev",
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -357,8 +373,13 @@ func TestCreateRouteWithMultipleTargets(t *testing.T) {
 						},
 						Percent: 90,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -368,8 +389,13 @@ func TestCreateRouteWithMultipleTargets(t *testing.T) {
 						},
 						Percent: 10,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -389,8 +415,13 @@ func TestCreateRouteWithMultipleTargets(t *testing.T) {
 						},
 						Percent: 90,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -400,8 +431,13 @@ func TestCreateRouteWithMultipleTargets(t *testing.T) {
 						},
 						Percent: 10,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -475,8 +511,13 @@ func TestCreateRouteWithOneTargetReserve(t *testing.T) {
 						},
 						Percent: 90,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -486,8 +527,13 @@ func TestCreateRouteWithOneTargetReserve(t *testing.T) {
 						},
 						Percent: 10,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -507,8 +553,13 @@ func TestCreateRouteWithOneTargetReserve(t *testing.T) {
 						},
 						Percent: 90,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -518,8 +569,13 @@ func TestCreateRouteWithOneTargetReserve(t *testing.T) {
 						},
 						Percent: 10,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -607,8 +663,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -618,8 +679,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -639,8 +705,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -650,8 +721,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -673,8 +749,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -694,8 +775,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -717,8 +803,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -738,8 +829,13 @@ func TestCreateRouteWithDuplicateTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -813,8 +909,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -824,8 +925,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -843,8 +949,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -854,8 +965,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -877,8 +993,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -896,8 +1017,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -919,8 +1045,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -938,8 +1069,13 @@ func TestCreateRouteWithNamedTargets(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -1028,8 +1164,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 							},
 							Percent: 100,
 							AppendHeaders: map[string]string{
-								"Knative-Serving-Namespace": "test",
-								"Knative-Serving-Revision":  "p-deadbeef",
+								"Knative-Serving-Namespace":        "test",
+								"Knative-Serving-Revision":         "p-deadbeef",
+								"Knative-Serving-Mirror":           "",
+								"Knative-Serving-Header-Mutations": "",
+								"Knative-Serving-Fault":            "",
+								"Knative-Serving-Split":            "",
+								"Knative-Serving-Path-Rewrite":     "",
 							},
 						},
 					},
@@ -1048,8 +1189,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 							},
 							Percent: 100,
 							AppendHeaders: map[string]string{
-								"Knative-Serving-Namespace": "test",
-								"Knative-Serving-Revision":  "test-rev",
+								"Knative-Serving-Namespace":        "test",
+								"Knative-Serving-Revision":         "test-rev",
+								"Knative-Serving-Mirror":           "",
+								"Knative-Serving-Header-Mutations": "",
+								"Knative-Serving-Fault":            "",
+								"Knative-Serving-Split":            "",
+								"Knative-Serving-Path-Rewrite":     "",
 							},
 						},
 					},
@@ -1065,8 +1211,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -1076,8 +1227,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -1101,8 +1257,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 							},
 							Percent: 100,
 							AppendHeaders: map[string]string{
-								"Knative-Serving-Namespace": "test",
-								"Knative-Serving-Revision":  "p-deadbeef",
+								"Knative-Serving-Namespace":        "test",
+								"Knative-Serving-Revision":         "p-deadbeef",
+								"Knative-Serving-Mirror":           "",
+								"Knative-Serving-Header-Mutations": "",
+								"Knative-Serving-Fault":            "",
+								"Knative-Serving-Split":            "",
+								"Knative-Serving-Path-Rewrite":     "",
 							},
 						},
 					},
@@ -1121,8 +1282,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 							},
 							Percent: 100,
 							AppendHeaders: map[string]string{
-								"Knative-Serving-Namespace": "test",
-								"Knative-Serving-Revision":  "test-rev",
+								"Knative-Serving-Namespace":        "test",
+								"Knative-Serving-Revision":         "test-rev",
+								"Knative-Serving-Mirror":           "",
+								"Knative-Serving-Header-Mutations": "",
+								"Knative-Serving-Fault":            "",
+								"Knative-Serving-Split":            "",
+								"Knative-Serving-Path-Rewrite":     "",
 							},
 						},
 					},
@@ -1138,8 +1304,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}, {
 						IngressBackend: v1alpha1.IngressBackend{
@@ -1149,8 +1320,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 50,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 				}},
@@ -1172,8 +1348,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 					AppendHeaders: map[string]string{
@@ -1196,8 +1377,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  cfgrev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         cfgrev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 					AppendHeaders: map[string]string{
@@ -1222,8 +1408,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 					AppendHeaders: map[string]string{
@@ -1244,8 +1435,13 @@ func TestCreateRouteWithNamedTargetsAndTagBasedRouting(t *testing.T) {
 						},
 						Percent: 100,
 						AppendHeaders: map[string]string{
-							"Knative-Serving-Revision":  rev.Name,
-							"Knative-Serving-Namespace": testNamespace,
+							"Knative-Serving-Revision":         rev.Name,
+							"Knative-Serving-Namespace":        testNamespace,
+							"Knative-Serving-Mirror":           "",
+							"Knative-Serving-Header-Mutations": "",
+							"Knative-Serving-Fault":            "",
+							"Knative-Serving-Split":            "",
+							"Knative-Serving-Path-Rewrite":     "",
 						},
 					}},
 					AppendHeaders: map[string]string{
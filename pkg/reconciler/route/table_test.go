@@ -51,6 +51,8 @@ import (
 	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	routereconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/route"
 	kaccessor "knative.dev/serving/pkg/reconciler/accessor"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
+	analysistesting "knative.dev/serving/pkg/reconciler/route/analysis/testing"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/resources"
 	"knative.dev/serving/pkg/reconciler/route/traffic"
@@ -73,6 +75,7 @@ const (
 	rolloutDurationKey key = iota
 	externalSchemeKey
 	enableAutoTLSKey
+	rolloutMetricsURLKey
 )
 
 // This is heavily based on the way the OpenShift Ingress controller tests its reconciliation method.
@@ -486,7 +489,10 @@ func TestReconcile(t *testing.T) {
 						RevisionName:   "config-00001",
 						Percent:        ptr.Int64(1),
 						LatestRevision: ptr.Bool(true),
-					})),
+					}),
+				WithStatusRollouts(rolloutStatus("config", v1.RolloutStateProgressing,
+					fakeCurTime.Add(-3*time.Second), fakeCurTime.Add(3*time.Second),
+					fakeCurTime.Add(99*time.Second), "config-00000", 99, "config-00001", 1))),
 		}},
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "becomes-ready"),
@@ -916,7 +922,9 @@ func TestReconcile(t *testing.T) {
 					RevisionName: "config-00001", Percent: 99,
 				}, {
 					RevisionName: "config-00002", Percent: 1,
-				}}, fakeCurTime),
+				}}, fakeCurTime, withPreviousRevisions(traffic.RevisionRollout{
+					RevisionName: "config-00001", Percent: 100,
+				})),
 				withReadyIngress,
 			),
 		}},
@@ -933,7 +941,9 @@ func TestReconcile(t *testing.T) {
 						RevisionName:   "config-00002",
 						Percent:        ptr.Int64(1),
 						LatestRevision: ptr.Bool(true),
-					})),
+					}),
+				WithStatusRollouts(rolloutStatus("config", v1.RolloutStateProgressing,
+					fakeCurTime, time.Time{}, time.Time{}, "config-00001", 99, "config-00002", 1))),
 		}},
 		Key: "default/new-latest-ready",
 	}, {
@@ -2341,6 +2351,547 @@ func TestReconcile_ServiceLifecycle(t *testing.T) {
 	table.Test(t, MakeFactory(NewTestReconciler))
 }
 
+func TestReconcileRolloutAnalysis(t *testing.T) {
+	healthy := analysistesting.NewFakePrometheus()
+	defer healthy.Close()
+	healthy.SetResult("default", "config-00001", &analysis.Result{Requests: 100, Errors: 1})
+
+	failing := analysistesting.NewFakePrometheus()
+	defer failing.Close()
+	failing.SetResult("default", "config-00001", &analysis.Result{Requests: 100, Errors: 50})
+
+	// Every row starts with the rollout of config-00001 at 99%, which is due
+	// to take its last step.
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: {{
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "config-00001",
+					Percent:           ptr.Int64(100),
+					LatestRevision:    ptr.Bool(true),
+				},
+			}},
+		},
+	}
+	stepParams := traffic.RolloutParams{
+		NextStepTime: fakeCurTime.Add(-time.Second).UnixNano(),
+		StepSize:     4,
+		StartTime:    fakeCurTime.Add(-time.Hour).UnixNano(),
+		StepDuration: int64(time.Minute),
+	}
+	objects := func(ro ...RouteOption) []runtime.Object {
+		return []runtime.Object{
+			Route("default", "analyzed", append([]RouteOption{WithConfigTarget("config"),
+				WithRouteGeneration(2009), MarkInRollout}, ro...)...),
+			cfg("default", "config",
+				WithConfigGeneration(1), WithLatestCreated("config-00001"), WithLatestReady("config-00001")),
+			rev("default", "config", 1, MarkRevisionReady, WithRevName("config-00001")),
+			simpleIngress(
+				Route("default", "analyzed", append([]RouteOption{WithConfigTarget("config"), WithURL}, ro...)...),
+				tc,
+				simpleRollout("config", []traffic.RevisionRollout{{
+					RevisionName: "config-00000", Percent: 1,
+				}, {
+					RevisionName: "config-00001", Percent: 99,
+				}}, fakeCurTime.Add(-time.Hour), withStepParams(stepParams)),
+				withReadyIngress,
+			),
+		}
+	}
+	ctx := func(url string) context.Context {
+		return context.WithValue(context.WithValue(context.Background(),
+			rolloutDurationKey, 120), rolloutMetricsURLKey, url)
+	}
+	placeholder := func(ro ...RouteOption) runtime.Object {
+		return simplePlaceholderK8sService(getContext(),
+			Route("default", "analyzed", append([]RouteOption{WithConfigTarget("config")}, ro...)...), "")
+	}
+	k8sService := func(ro ...RouteOption) clientgotesting.UpdateActionImpl {
+		return clientgotesting.UpdateActionImpl{
+			Object: simpleK8sService(Route("default", "analyzed", append([]RouteOption{WithConfigTarget("config")}, ro...)...)),
+		}
+	}
+	raisedLimit := WithRouteAnnotation(map[string]string{serving.RolloutMaxErrorRateKey: "0.6"})
+	doneStatus := func(ro ...RouteOption) clientgotesting.UpdateActionImpl {
+		return clientgotesting.UpdateActionImpl{
+			Object: Route("default", "analyzed", append([]RouteOption{WithConfigTarget("config"),
+				WithURL, WithAddress, WithRouteConditionsAutoTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration,
+				MarkTrafficAssigned, MarkIngressReady}, ro...)...),
+		}
+	}
+	const reason = "error rate 50.00% exceeds 5.00%"
+
+	table := TableTest{{
+		Name:        "healthy revision completes the rollout",
+		Ctx:         ctx(healthy.URL),
+		Objects:     objects(),
+		WantCreates: []runtime.Object{placeholder()},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: simpleIngress(Route("default", "analyzed", WithConfigTarget("config"), WithURL),
+				tc, withReadyIngress),
+		}, k8sService()},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{doneStatus(WithStatusTraffic(
+			v1.TrafficTarget{
+				RevisionName:   "config-00001",
+				Percent:        ptr.Int64(100),
+				LatestRevision: ptr.Bool(true),
+			}))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "analyzed"),
+		},
+		Key: "default/analyzed",
+	}, {
+		Name:        "failing revision is rolled back",
+		Ctx:         ctx(failing.URL),
+		Objects:     objects(),
+		WantCreates: []runtime.Object{placeholder()},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(Route("default", "analyzed", WithConfigTarget("config"), WithURL),
+				tc, &traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00000",
+							Percent:      100,
+						}},
+						RolledBack: &traffic.RolledBackRevision{
+							RevisionName: "config-00001",
+							Reason:       reason,
+						},
+					}},
+				}, withReadyIngress),
+		}, k8sService()},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{doneStatus(
+			MarkRolledBack("config-00001", reason),
+			WithStatusTraffic(v1.TrafficTarget{
+				RevisionName:   "config-00000",
+				Percent:        ptr.Int64(100),
+				LatestRevision: ptr.Bool(true),
+			}))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "analyzed"),
+			Eventf(corev1.EventTypeWarning, "RolledBack", "Rolled back revision %q: %s", "config-00001", reason),
+		},
+		Key: "default/analyzed",
+	}, {
+		Name:        "annotation raises the error rate limit",
+		Ctx:         ctx(failing.URL),
+		Objects:     objects(raisedLimit),
+		WantCreates: []runtime.Object{placeholder(raisedLimit)},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: simpleIngress(Route("default", "analyzed", WithConfigTarget("config"), WithURL, raisedLimit),
+				tc, withReadyIngress),
+		}, k8sService(raisedLimit)},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{doneStatus(raisedLimit,
+			WithStatusTraffic(v1.TrafficTarget{
+				RevisionName:   "config-00001",
+				Percent:        ptr.Int64(100),
+				LatestRevision: ptr.Bool(true),
+			}))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "analyzed"),
+		},
+		Key: "default/analyzed",
+	}, {
+		Name:        "unreadable metrics postpone the step",
+		Ctx:         ctx(failing.URL + "/not-prometheus"),
+		Objects:     objects(),
+		WantCreates: []runtime.Object{placeholder()},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(Route("default", "analyzed", WithConfigTarget("config"), WithURL),
+				tc, &traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00000", Percent: 1,
+						}, {
+							RevisionName: "config-00001", Percent: 99,
+						}},
+						StepParams: traffic.RolloutParams{
+							NextStepTime: fakeCurTime.Add(time.Minute).UnixNano(),
+							StepSize:     stepParams.StepSize,
+							StartTime:    stepParams.StartTime,
+							StepDuration: stepParams.StepDuration,
+						},
+					}},
+				}, withReadyIngress),
+		}, k8sService()},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: Route("default", "analyzed", WithConfigTarget("config"),
+				WithURL, WithAddress, WithRouteConditionsAutoTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration,
+				MarkTrafficAssigned, MarkInRollout, WithStatusTraffic(
+					v1.TrafficTarget{
+						RevisionName:   "config-00000",
+						Percent:        ptr.Int64(1),
+						LatestRevision: ptr.Bool(true),
+					},
+					v1.TrafficTarget{
+						RevisionName:   "config-00001",
+						Percent:        ptr.Int64(99),
+						LatestRevision: ptr.Bool(true),
+					}),
+				WithStatusRollouts(rolloutStatus("config", v1.RolloutStateProgressing,
+					fakeCurTime.Add(-time.Hour), fakeCurTime.Add(time.Minute),
+					fakeCurTime.Add(time.Minute), "config-00000", 1, "config-00001", 99))),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "analyzed"),
+		},
+		Key: "default/analyzed",
+	}}
+
+	table.Test(t, MakeFactory(NewTestReconciler))
+}
+
+func TestReconcileRolloutSchedule(t *testing.T) {
+	prom := analysistesting.NewFakePrometheus()
+	defer prom.Close()
+
+	// Every row starts with the rollout of config-00001 paused at 25%.
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: {{
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "config-00001",
+					Percent:           ptr.Int64(100),
+					LatestRevision:    ptr.Bool(true),
+				},
+			}},
+		},
+	}
+	schedule := WithRouteAnnotation(map[string]string{serving.RolloutStepsKey: "10%:5m,25%:pause,50%:10m"})
+	approved := WithRouteAnnotation(map[string]string{
+		serving.RolloutStepsKey:    "10%:5m,25%:pause,50%:10m",
+		serving.RolloutApprovedKey: "config-00001@25",
+	})
+	steps := []traffic.RolloutStep{
+		{Percent: 10, Duration: int64(5 * time.Minute)},
+		{Percent: 25, Pause: true},
+		{Percent: 50, Duration: int64(10 * time.Minute)},
+	}
+	startTime := fakeCurTime.Add(-time.Hour).UnixNano()
+	rollout := func(oldPercent, newPercent int, params traffic.RolloutParams) *traffic.Rollout {
+		return &traffic.Rollout{
+			Configurations: []*traffic.ConfigurationRollout{{
+				ConfigurationName: "config",
+				Percent:           100,
+				Revisions: []traffic.RevisionRollout{{
+					RevisionName: "config-00000", Percent: oldPercent,
+				}, {
+					RevisionName: "config-00001", Percent: newPercent,
+				}},
+				StepParams: params,
+			}},
+		}
+	}
+	paused := rollout(75, 25, traffic.RolloutParams{
+		StartTime:        startTime,
+		Steps:            steps,
+		StepIndex:        1,
+		AwaitingApproval: true,
+	})
+	objects := func(ro ...RouteOption) []runtime.Object {
+		return []runtime.Object{
+			Route("default", "scheduled", append([]RouteOption{WithConfigTarget("config"),
+				WithRouteGeneration(2009), MarkInRollout, schedule}, ro...)...),
+			cfg("default", "config",
+				WithConfigGeneration(1), WithLatestCreated("config-00001"), WithLatestReady("config-00001")),
+			rev("default", "config", 1, MarkRevisionReady, WithRevName("config-00001")),
+			ingressWithRollout(
+				Route("default", "scheduled", append([]RouteOption{WithConfigTarget("config"), WithURL, schedule}, ro...)...),
+				tc, paused, withReadyIngress),
+		}
+	}
+	placeholder := func(ro ...RouteOption) runtime.Object {
+		return simplePlaceholderK8sService(getContext(),
+			Route("default", "scheduled", append([]RouteOption{WithConfigTarget("config"), schedule}, ro...)...), "")
+	}
+	k8sService := func(ro ...RouteOption) clientgotesting.UpdateActionImpl {
+		return clientgotesting.UpdateActionImpl{
+			Object: simpleK8sService(Route("default", "scheduled", append([]RouteOption{WithConfigTarget("config"), schedule}, ro...)...)),
+		}
+	}
+	status := func(oldPercent, newPercent int64, ro ...RouteOption) clientgotesting.UpdateActionImpl {
+		return clientgotesting.UpdateActionImpl{
+			Object: Route("default", "scheduled", append([]RouteOption{WithConfigTarget("config"), schedule,
+				WithURL, WithAddress, WithRouteConditionsAutoTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration, MarkTrafficAssigned,
+				WithStatusTraffic(v1.TrafficTarget{
+					RevisionName:   "config-00000",
+					Percent:        ptr.Int64(oldPercent),
+					LatestRevision: ptr.Bool(true),
+				}, v1.TrafficTarget{
+					RevisionName:   "config-00001",
+					Percent:        ptr.Int64(newPercent),
+					LatestRevision: ptr.Bool(true),
+				})}, ro...)...),
+		}
+	}
+
+	table := TableTest{{
+		Name:        "rollout waits for the approval of the pause step",
+		Objects:     objects(),
+		WantCreates: []runtime.Object{placeholder()},
+		WantUpdates: []clientgotesting.UpdateActionImpl{k8sService()},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(75, 25, MarkAwaitingApproval("config-00001", 25),
+			WithStatusRollouts(rolloutStatus("config", v1.RolloutStateAwaitingApproval,
+				fakeCurTime.Add(-time.Hour), time.Time{}, time.Time{}, "config-00000", 75, "config-00001", 25)))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "scheduled"),
+		},
+		Key: "default/scheduled",
+	}, {
+		Name:        "approved rollout takes the next step",
+		Objects:     objects(approved),
+		WantCreates: []runtime.Object{placeholder(approved)},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(
+				Route("default", "scheduled", WithConfigTarget("config"), WithURL, schedule, approved),
+				tc, rollout(50, 50, traffic.RolloutParams{
+					StartTime:    startTime,
+					Steps:        steps,
+					StepIndex:    2,
+					StepDuration: int64(10 * time.Minute),
+					NextStepTime: fakeCurTime.Add(10 * time.Minute).UnixNano(),
+				}), withReadyIngress),
+		}, k8sService(approved)},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(50, 50, approved, MarkInRollout,
+			WithStatusRollouts(rolloutStatus("config", v1.RolloutStateProgressing,
+				fakeCurTime.Add(-time.Hour), fakeCurTime.Add(10*time.Minute),
+				fakeCurTime.Add(10*time.Minute), "config-00000", 50, "config-00001", 50)))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "scheduled"),
+		},
+		Key: "default/scheduled",
+	}, {
+		Name: "approved rollout with unreadable metrics postpones the next step",
+		Ctx: context.WithValue(context.WithValue(context.Background(),
+			rolloutDurationKey, 120), rolloutMetricsURLKey, prom.URL+"/not-prometheus"),
+		Objects:     objects(approved),
+		WantCreates: []runtime.Object{placeholder(approved)},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(
+				Route("default", "scheduled", WithConfigTarget("config"), WithURL, schedule, approved),
+				tc, rollout(75, 25, traffic.RolloutParams{
+					StartTime:    startTime,
+					Steps:        steps,
+					StepIndex:    1,
+					StepDuration: int64(10 * time.Minute),
+					NextStepTime: fakeCurTime.Add(10 * time.Minute).UnixNano(),
+				}), withReadyIngress),
+		}, k8sService(approved)},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(75, 25, approved, MarkInRollout,
+			WithStatusRollouts(rolloutStatus("config", v1.RolloutStateProgressing,
+				fakeCurTime.Add(-time.Hour), fakeCurTime.Add(10*time.Minute),
+				fakeCurTime.Add(20*time.Minute), "config-00000", 75, "config-00001", 25)))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "scheduled"),
+		},
+		Key: "default/scheduled",
+	}}
+
+	table.Test(t, MakeFactory(NewTestReconciler))
+}
+
+func TestReconcileRolloutControl(t *testing.T) {
+	// Every row starts with the rollout of config-00001 at 99%, which is due
+	// to take its last step unless it is paused.
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: {{
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "config-00001",
+					Percent:           ptr.Int64(100),
+					LatestRevision:    ptr.Bool(true),
+				},
+			}},
+		},
+	}
+	stepParams := traffic.RolloutParams{
+		NextStepTime: fakeCurTime.Add(-time.Second).UnixNano(),
+		StepSize:     4,
+		StartTime:    fakeCurTime.Add(-time.Hour).UnixNano(),
+		StepDuration: int64(time.Minute),
+	}
+	pausedParams := stepParams
+	pausedParams.NextStepTime = 0
+	pausedParams.Paused = true
+	rollout := func(params traffic.RolloutParams) *traffic.Rollout {
+		return &traffic.Rollout{
+			Configurations: []*traffic.ConfigurationRollout{{
+				ConfigurationName: "config",
+				Percent:           100,
+				Revisions: []traffic.RevisionRollout{{
+					RevisionName: "config-00000", Percent: 1,
+				}, {
+					RevisionName: "config-00001", Percent: 99,
+				}},
+				StepParams: params,
+			}},
+		}
+	}
+	control := func(v string) RouteOption {
+		return WithRouteAnnotation(map[string]string{serving.RolloutControlKey: v})
+	}
+	objects := func(params traffic.RolloutParams, ro RouteOption) []runtime.Object {
+		return []runtime.Object{
+			Route("default", "controlled", WithConfigTarget("config"),
+				WithRouteGeneration(2009), MarkInRollout, ro),
+			cfg("default", "config",
+				WithConfigGeneration(1), WithLatestCreated("config-00001"), WithLatestReady("config-00001")),
+			rev("default", "config", 1, MarkRevisionReady, WithRevName("config-00001")),
+			ingressWithRollout(Route("default", "controlled", WithConfigTarget("config"), WithURL, ro),
+				tc, rollout(params), withReadyIngress),
+		}
+	}
+	placeholder := func(ro RouteOption) runtime.Object {
+		return simplePlaceholderK8sService(getContext(),
+			Route("default", "controlled", WithConfigTarget("config"), ro), "")
+	}
+	k8sService := func(ro RouteOption) clientgotesting.UpdateActionImpl {
+		return clientgotesting.UpdateActionImpl{
+			Object: simpleK8sService(Route("default", "controlled", WithConfigTarget("config"), ro)),
+		}
+	}
+	status := func(ro ...RouteOption) clientgotesting.UpdateActionImpl {
+		return clientgotesting.UpdateActionImpl{
+			Object: Route("default", "controlled", append([]RouteOption{WithConfigTarget("config"),
+				WithURL, WithAddress, WithRouteConditionsAutoTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration, MarkTrafficAssigned}, ro...)...),
+		}
+	}
+	inRollout := WithStatusTraffic(v1.TrafficTarget{
+		RevisionName:   "config-00000",
+		Percent:        ptr.Int64(1),
+		LatestRevision: ptr.Bool(true),
+	}, v1.TrafficTarget{
+		RevisionName:   "config-00001",
+		Percent:        ptr.Int64(99),
+		LatestRevision: ptr.Bool(true),
+	})
+	const reason = "the rollout was aborted"
+
+	table := TableTest{{
+		Name:        "pause holds the traffic split",
+		Ctx:         context.WithValue(context.Background(), rolloutDurationKey, 120),
+		Objects:     objects(stepParams, control("pause")),
+		WantCreates: []runtime.Object{placeholder(control("pause"))},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(Route("default", "controlled", WithConfigTarget("config"), WithURL, control("pause")),
+				tc, rollout(pausedParams), withReadyIngress),
+		}, k8sService(control("pause"))},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(control("pause"), MarkRolloutPaused, inRollout,
+			WithStatusRollouts(rolloutStatus("config", v1.RolloutStatePaused,
+				fakeCurTime.Add(-time.Hour), time.Time{}, time.Time{}, "config-00000", 1, "config-00001", 99)))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "controlled"),
+		},
+		Key: "default/controlled",
+	}, {
+		Name:        "resume schedules the next step",
+		Ctx:         context.WithValue(context.Background(), rolloutDurationKey, 120),
+		Objects:     objects(pausedParams, control("resume")),
+		WantCreates: []runtime.Object{placeholder(control("resume"))},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(Route("default", "controlled", WithConfigTarget("config"), WithURL, control("resume")),
+				tc, rollout(traffic.RolloutParams{
+					NextStepTime: fakeCurTime.Add(time.Minute).UnixNano(),
+					StepSize:     stepParams.StepSize,
+					StartTime:    stepParams.StartTime,
+					StepDuration: stepParams.StepDuration,
+				}), withReadyIngress),
+		}, k8sService(control("resume"))},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(control("resume"), MarkInRollout, inRollout,
+			WithStatusRollouts(rolloutStatus("config", v1.RolloutStateProgressing,
+				fakeCurTime.Add(-time.Hour), fakeCurTime.Add(time.Minute),
+				fakeCurTime.Add(time.Minute), "config-00000", 1, "config-00001", 99)))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "controlled"),
+		},
+		Key: "default/controlled",
+	}, {
+		Name:        "abort rolls back to the previous revision",
+		Ctx:         context.WithValue(context.Background(), rolloutDurationKey, 120),
+		Objects:     objects(pausedParams, control("abort")),
+		WantCreates: []runtime.Object{placeholder(control("abort"))},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(Route("default", "controlled", WithConfigTarget("config"), WithURL, control("abort")),
+				tc, &traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00000",
+							Percent:      100,
+						}},
+						RolledBack: &traffic.RolledBackRevision{
+							RevisionName: "config-00001",
+							Reason:       reason,
+						},
+					}},
+					Aborted: true,
+				}, withReadyIngress),
+		}, k8sService(control("abort"))},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(control("abort"), MarkIngressReady,
+			MarkRolledBack("config-00001", reason),
+			WithStatusTraffic(v1.TrafficTarget{
+				RevisionName:   "config-00000",
+				Percent:        ptr.Int64(100),
+				LatestRevision: ptr.Bool(true),
+			}))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "controlled"),
+			Eventf(corev1.EventTypeNormal, "RolloutAborted", "Aborted the rollout of revision %q", "config-00001"),
+		},
+		Key: "default/controlled",
+	}, {
+		Name: "abort doesn't roll back the rollouts started after it was set",
+		Ctx:  context.WithValue(context.Background(), rolloutDurationKey, 120),
+		Objects: func() []runtime.Object {
+			objs := objects(stepParams, control("abort"))
+			ro := rollout(stepParams)
+			ro.Aborted = true
+			objs[3] = ingressWithRollout(Route("default", "controlled", WithConfigTarget("config"), WithURL, control("abort")),
+				tc, ro, withReadyIngress)
+			return objs
+		}(),
+		WantCreates: []runtime.Object{placeholder(control("abort"))},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressWithRollout(Route("default", "controlled", WithConfigTarget("config"), WithURL, control("abort")),
+				tc, &traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00001",
+							Percent:      100,
+						}},
+					}},
+					Aborted: true,
+				}, withReadyIngress),
+		}, k8sService(control("abort"))},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{status(control("abort"), MarkIngressReady,
+			WithStatusTraffic(v1.TrafficTarget{
+				RevisionName:   "config-00001",
+				Percent:        ptr.Int64(100),
+				LatestRevision: ptr.Bool(true),
+			}))},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "controlled"),
+		},
+		Key: "default/controlled",
+	}}
+
+	table.Test(t, MakeFactory(NewTestReconciler))
+}
+
 func TestReconcileEnableAutoTLS(t *testing.T) {
 	table := TableTest{{
 		Name: "check that existing wildcard cert is used when creating a Route",
@@ -3034,9 +3585,11 @@ func NewTestReconciler(ctx context.Context, listers *Listers, cmw configmap.Watc
 		endpointsLister:     listers.GetEndpointsLister(),
 		ingressLister:       listers.GetIngressLister(),
 		certificateLister:   listers.GetCertificateLister(),
+		namespaceLister:     listers.GetNamespaceLister(),
 		tracker:             ctx.Value(TrackerKey).(tracker.Interface),
 		clock:               clock.NewFakePassiveClock(fakeCurTime),
 		enqueueAfter:        func(interface{}, time.Duration) {},
+		metricsSource:       cachedMetricsSource(newMetricsSource),
 	}
 
 	cfg := reconcilerTestConfig()
@@ -3049,6 +3602,9 @@ func NewTestReconciler(ctx context.Context, listers *Listers, cmw configmap.Watc
 	if v := ctx.Value(externalSchemeKey); v != nil {
 		cfg.Network.DefaultExternalScheme = v.(string)
 	}
+	if v := ctx.Value(rolloutMetricsURLKey); v != nil {
+		cfg.Rollout.MetricsURL = v.(string)
+	}
 
 	return routereconciler.NewReconciler(ctx,
 		logging.FromContext(ctx),
@@ -3283,6 +3839,10 @@ func reconcilerTestConfig() *config.Config {
 			PodSpecSchedulerName:     cfgmap.Disabled,
 			TagHeaderBasedRouting:    cfgmap.Disabled,
 		},
+		Rollout: &config.Rollout{
+			MaxErrorRate:    0.05,
+			MinRequestCount: 10,
+		},
 	}
 }
 
@@ -3314,6 +3874,33 @@ func url(s string) *apis.URL {
 
 type rolloutOption func(*traffic.Rollout)
 
+// rolloutStatus returns the status of the rollout of cfg to the revisions
+// in revs, given as name and percent pairs. Zero times are unset.
+func rolloutStatus(cfg string, state v1.RolloutState, start, nextStep, completion time.Time,
+	revs ...interface{}) v1.RolloutStatus {
+	rs := v1.RolloutStatus{
+		ConfigurationName:       cfg,
+		State:                   state,
+		StartTime:               statusTime(start),
+		NextStepTime:            statusTime(nextStep),
+		EstimatedCompletionTime: statusTime(completion),
+	}
+	for i := 0; i < len(revs); i += 2 {
+		rs.Revisions = append(rs.Revisions, v1.RolloutRevisionStatus{
+			RevisionName: revs[i].(string),
+			Percent:      int64(revs[i+1].(int)),
+		})
+	}
+	return rs
+}
+
+func statusTime(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
+
 func simpleRollout(cfg string, revs []traffic.RevisionRollout,
 	now time.Time, ros ...rolloutOption) IngressOption {
 	return func(i *netv1alpha1.Ingress) {
@@ -3337,6 +3924,14 @@ func simpleRollout(cfg string, revs []traffic.RevisionRollout,
 	}
 }
 
+func withPreviousRevisions(revs ...traffic.RevisionRollout) rolloutOption {
+	return func(ro *traffic.Rollout) {
+		for i := range ro.Configurations {
+			ro.Configurations[i].PreviousRevisions = revs
+		}
+	}
+}
+
 func withStepParams(p traffic.RolloutParams) rolloutOption {
 	return func(ro *traffic.Rollout) {
 		for i := range ro.Configurations {
@@ -28,7 +28,9 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/logging"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
 // Rollout encapsulates the current rollout state of the system.
@@ -39,20 +41,34 @@ import (
 type Rollout struct {
 	// Configurations are sorted by tag first and within same tag, by configuration name.
 	Configurations []*ConfigurationRollout `json:"configurations,omitempty"`
+
+	// Aborted is set while the rollout-control annotation of the Route is
+	// abort, so that only the rollouts in progress when it was set are
+	// rolled back.
+	Aborted bool `json:"aborted,omitempty"`
 }
 
-// ConfigurationRollout describes the rollout state for a given config+tag pair.
+// ConfigurationRollout describes the rollout state for a given config+tag
+// (or config+path) pair.
 type ConfigurationRollout struct {
-	// Name + tag pair uniquely identifies the rollout target.
-	// `tag` will be empty, if this is the `DefaultTarget`.
+	// Name + tag + path triple uniquely identifies the rollout target.
+	// `tag` will be empty, if this is the `DefaultTarget` or a target
+	// with a path.
 	ConfigurationName string `json:"configurationName"`
 	Tag               string `json:"tag,omitempty"`
+	// Path is set for the rollouts of the targets with a path only.
+	Path string `json:"path,omitempty"`
 
 	// Percent denotes the total percentage for this configuration.
 	// The individual percentages of the Revisions below will sum to this
 	// number.
 	Percent int `json:"percent"`
 
+	// Weighted is set for the rollouts of the Routes split by weight: all
+	// the percents of the rollout, save for its steps, are then basis
+	// points, so the traffic is moved in finer steps, starting at 0.01%.
+	Weighted bool `json:"weighted,omitempty"`
+
 	// The revisions in the rollout. In steady state this should
 	// contain 0 (no revision is ready) or 1 (rollout done).
 	// During the actual rollout it will contain N revisions
@@ -62,8 +78,25 @@ type ConfigurationRollout struct {
 	// Note: that it is not 100% of the route traffic, in more complex cases.
 	Revisions []RevisionRollout `json:"revisions,omitempty"`
 
+	// PreviousRevisions is the split of the traffic of the configuration
+	// when the rollout of the latest revision started. Rollbacks restore it.
+	PreviousRevisions []RevisionRollout `json:"previousRevisions,omitempty"`
+
 	// StepParams describes rollout params for the configuration.
 	StepParams RolloutParams `json:"stepParams"`
+
+	// RolledBack is set when the rollout of the latest revision was
+	// reverted, since the revision did not meet the rollout SLO.
+	// The configuration will not roll out this revision again.
+	RolledBack *RolledBackRevision `json:"rolledBack,omitempty"`
+}
+
+// RolledBackRevision describes a revision whose rollout was reverted.
+type RolledBackRevision struct {
+	// Name of the revision.
+	RevisionName string `json:"revisionName"`
+	// Reason the rollout was reverted for.
+	Reason string `json:"reason"`
 }
 
 // RolloutParams contains the timing and sizing parameters for the
@@ -84,6 +117,34 @@ type RolloutParams struct {
 
 	// How much traffic to move in a single step.
 	StepSize int `json:"stepSize,omitempty"`
+
+	// Steps is the custom schedule of the rollout. If set, StepSize is
+	// unused and StepDuration is the duration of the current step.
+	Steps []RolloutStep `json:"steps,omitempty"`
+
+	// StepIndex is the index of the current step in Steps.
+	StepIndex int `json:"stepIndex,omitempty"`
+
+	// AwaitingApproval is set while the rollout is paused at a
+	// pause step of the schedule.
+	AwaitingApproval bool `json:"awaitingApproval,omitempty"`
+
+	// Paused is set while the rollout is paused with the rollout-control
+	// annotation.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RolloutStep is a step of the custom schedule of a rollout.
+type RolloutStep struct {
+	// Percent is the share of the configuration percentage that is routed
+	// to the latest revision during the step.
+	Percent int `json:"percent"`
+
+	// Duration is the number of nanoseconds the step lasts.
+	Duration int64 `json:"duration,omitempty"`
+
+	// Pause steps last until they are approved.
+	Pause bool `json:"pause,omitempty"`
 }
 
 // RevisionRollout describes the revision in the config rollout.
@@ -98,19 +159,47 @@ type RevisionRollout struct {
 
 // RolloutsByTag returns the ConfigurationRollout(s) for the given tag.
 func (cur *Rollout) RolloutsByTag(t string) []*ConfigurationRollout {
+	return cur.rolloutsFor(rolloutKey{tag: t})
+}
+
+// RolloutsByPath returns the ConfigurationRollout(s) for the targets
+// with the given path.
+func (cur *Rollout) RolloutsByPath(p string) []*ConfigurationRollout {
+	return cur.rolloutsFor(rolloutKey{path: p})
+}
+
+func (cur *Rollout) rolloutsFor(k rolloutKey) []*ConfigurationRollout {
 	// TODO(vagababov): add an intermediate cache later.
 	ret := []*ConfigurationRollout{}
 	st := sort.Search(len(cur.Configurations), func(i int) bool {
-		return cur.Configurations[i].Tag >= t
+		return !cur.Configurations[i].key().less(k)
 	})
-	// Now append all configs rollouts with given tag.
-	// If tag != "", then there'll be only one such entry.
-	for ; st < len(cur.Configurations) && cur.Configurations[st].Tag == t; st++ {
+	// Now append all configs rollouts with given key.
+	// If tag != "" or path != "", then there'll be only one such entry.
+	for ; st < len(cur.Configurations) && cur.Configurations[st].key() == k; st++ {
 		ret = append(ret, cur.Configurations[st])
 	}
 	return ret
 }
 
+// rolloutKey is the tag + path pair the rollouts are grouped by.
+type rolloutKey struct {
+	tag, path string
+}
+
+// less orders the keys by path, then by tag, so the rollouts of the
+// targets without a path sort first.
+func (k rolloutKey) less(o rolloutKey) bool {
+	if k.path == o.path {
+		return k.tag < o.tag
+	}
+	return k.path < o.path
+}
+
+func (cur *ConfigurationRollout) key() rolloutKey {
+	return rolloutKey{tag: cur.Tag, path: cur.Path}
+}
+
 // Done returns true if all the Configuration rollouts in this
 // Rollout have completed.
 func (cur *Rollout) Done() bool {
@@ -122,11 +211,230 @@ func (cur *Rollout) Done() bool {
 	return true
 }
 
+// RolledBack returns the ConfigurationRollout(s) whose latest revision
+// was rolled back.
+func (cur *Rollout) RolledBack() []*ConfigurationRollout {
+	var ret []*ConfigurationRollout
+	for _, c := range cur.Configurations {
+		if c.RolledBack != nil {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// AwaitingApproval returns the ConfigurationRollout(s) paused at a pause
+// step of their schedule.
+func (cur *Rollout) AwaitingApproval() []*ConfigurationRollout {
+	var ret []*ConfigurationRollout
+	for _, c := range cur.Configurations {
+		if c.StepParams.AwaitingApproval {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Paused returns the ConfigurationRollout(s) paused with the
+// rollout-control annotation.
+func (cur *Rollout) Paused() []*ConfigurationRollout {
+	var ret []*ConfigurationRollout
+	for _, c := range cur.Configurations {
+		if c.StepParams.Paused {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Status returns the status of the configuration rollouts in progress.
+func (cur *Rollout) Status() []v1.RolloutStatus {
+	var ret []v1.RolloutStatus
+	for _, c := range cur.Configurations {
+		if c.done() {
+			continue
+		}
+		rs := v1.RolloutStatus{
+			ConfigurationName: c.ConfigurationName,
+			Tag:               c.Tag,
+			Path:              c.Path,
+			State:             v1.RolloutStateProgressing,
+			Revisions:         make([]v1.RolloutRevisionStatus, 0, len(c.Revisions)),
+		}
+		switch {
+		case c.StepParams.Paused:
+			rs.State = v1.RolloutStatePaused
+		case c.StepParams.AwaitingApproval:
+			rs.State = v1.RolloutStateAwaitingApproval
+		}
+		for _, r := range c.Revisions {
+			rrs := v1.RolloutRevisionStatus{
+				RevisionName: r.RevisionName,
+				Percent:      int64(r.Percent),
+			}
+			if c.Weighted {
+				rrs.Percent = int64(r.Percent) / (v1.MaxTrafficWeight / 100)
+				rrs.Weight = int64(r.Percent)
+			}
+			rs.Revisions = append(rs.Revisions, rrs)
+		}
+		rs.StartTime = statusTime(c.StepParams.StartTime)
+		rs.NextStepTime = statusTime(c.StepParams.NextStepTime)
+		rs.EstimatedCompletionTime = statusTime(c.estimateCompletion())
+		ret = append(ret, rs)
+	}
+	return ret
+}
+
+// statusTime converts the Unix timestamp in ns to a status time,
+// 0 yields nil.
+func statusTime(ts int64) *metav1.Time {
+	if ts <= 0 {
+		return nil
+	}
+	// The API server stores the times at a second precision.
+	t := metav1.NewTime(time.Unix(0, ts).Truncate(time.Second))
+	return &t
+}
+
+// estimateCompletion returns the Unix timestamp in ns by when the latest
+// revision will receive all the traffic if the rollout proceeds as
+// scheduled, or 0 if that is unknown.
+func (cur *ConfigurationRollout) estimateCompletion() int64 {
+	p := cur.StepParams
+	if cur.done() || p.NextStepTime == 0 {
+		// Paused, awaiting approval, or not yet scheduled.
+		return 0
+	}
+	if len(p.Steps) > 0 {
+		ts := p.NextStepTime
+		for _, s := range p.Steps[p.StepIndex+1:] {
+			if s.Pause {
+				return 0
+			}
+			ts += s.Duration
+		}
+		return ts
+	}
+	if p.StepSize == 0 {
+		return 0
+	}
+	remaining := cur.Percent - cur.Revisions[len(cur.Revisions)-1].Percent
+	steps := int64((remaining + p.StepSize - 1) / p.StepSize)
+	return p.NextStepTime + (steps-1)*p.StepDuration
+}
+
+// LatestRevision returns the name of the revision being rolled out.
+func (cur *ConfigurationRollout) LatestRevision() string {
+	if len(cur.Revisions) == 0 {
+		return ""
+	}
+	return cur.Revisions[len(cur.Revisions)-1].RevisionName
+}
+
+// PausedAt returns the percentage of the pause step the rollout is paused at.
+func (cur *ConfigurationRollout) PausedAt() int {
+	if !cur.StepParams.AwaitingApproval {
+		return 0
+	}
+	return cur.StepParams.Steps[cur.StepParams.StepIndex].Percent
+}
+
+// Pause holds the traffic split of the rollout until it is resumed.
+// It is a noop if there is no rollout going on.
+func (cur *ConfigurationRollout) Pause() {
+	if cur.done() {
+		return
+	}
+	cur.StepParams.Paused = true
+	cur.StepParams.NextStepTime = 0
+}
+
+// Resume lets the paused rollout continue, its next step is taken
+// a step duration after nowTS.
+func (cur *ConfigurationRollout) Resume(nowTS int64) {
+	if !cur.StepParams.Paused {
+		return
+	}
+	cur.StepParams.Paused = false
+	// Until ObserveReady computes the steps and while awaiting
+	// approval there is no next step to schedule.
+	if cur.StepParams.scheduled() && !cur.StepParams.AwaitingApproval {
+		cur.StepParams.NextStepTime = nowTS + cur.StepParams.StepDuration
+	}
+}
+
+// approvalBackoff is how long the step of an approved rollout is
+// postponed if it can't be taken right away, unless the next step of the
+// schedule has a duration.
+const approvalBackoff = int64(time.Minute)
+
+// Approve lets the rollout continue past the pause step it is paused at,
+// the next step is taken at nowTS.
+func (cur *ConfigurationRollout) Approve(nowTS int64) {
+	params := &cur.StepParams
+	if !params.AwaitingApproval {
+		return
+	}
+	params.AwaitingApproval = false
+	params.NextStepTime = nowTS
+	// The pause step has no duration, but the step may still be postponed,
+	// e.g. when the health of the revision can't be read.
+	params.StepDuration = approvalBackoff
+	if next := params.StepIndex + 1; next < len(params.Steps) && params.Steps[next].Duration > 0 {
+		params.StepDuration = params.Steps[next].Duration
+	}
+}
+
+// Rollback reverts the rollout of the latest revision of the configuration
+// and restores the traffic split from before the rollout started.
+// It is a noop if there is no rollout going on.
+func (cur *ConfigurationRollout) Rollback(reason string) {
+	if cur.done() {
+		return
+	}
+	last := len(cur.Revisions) - 1
+	cur.RolledBack = &RolledBackRevision{
+		RevisionName: cur.Revisions[last].RevisionName,
+		Reason:       reason,
+	}
+	prev := cur.PreviousRevisions
+	if len(prev) == 0 {
+		// The split wasn't recorded, move all the traffic back to the
+		// revision rolled out before.
+		prev = []RevisionRollout{{
+			RevisionName: cur.Revisions[last-1].RevisionName,
+			Percent:      cur.Percent,
+		}}
+	}
+	// The traffic of the configuration may have changed since.
+	diff := cur.Percent
+	for _, r := range prev {
+		diff -= r.Percent
+	}
+	cur.Revisions = resizeRevisions(append([]RevisionRollout(nil), prev...), diff)
+	cur.PreviousRevisions = nil
+	cur.StepParams = RolloutParams{}
+}
+
+// StepDue returns true if the rollout of the configuration is
+// ready to take its next step at nowTS.
+func (cur *ConfigurationRollout) StepDue(nowTS int64) bool {
+	return !cur.done() && cur.StepParams.scheduled() && !cur.StepParams.AwaitingApproval &&
+		!cur.StepParams.Paused && nowTS >= cur.StepParams.NextStepTime
+}
+
+// scheduled returns true once the steps of the rollout have been computed.
+func (p *RolloutParams) scheduled() bool {
+	return p.StepSize > 0 || len(p.Steps) > 0
+}
+
 // done returns true if there is no active rollout going on
 // for the configuration.
 func (cur *ConfigurationRollout) done() bool {
-	// Zero or just one revision.
-	return len(cur.Revisions) < 2
+	// Zero or just one revision, or the split restored by a rollback,
+	// which is held until a newer revision is rolled out.
+	return len(cur.Revisions) < 2 || cur.RolledBack != nil
 }
 
 // Validate validates current rollout for inconsistencies.
@@ -135,13 +443,22 @@ func (cur *ConfigurationRollout) done() bool {
 func (cur *Rollout) Validate() bool {
 	for _, c := range cur.Configurations {
 		// Cannot be over 100% in our system.
-		if c.Percent > 100 {
+		if c.Percent > c.total() {
 			return false
 		}
 		// Ensure step size is valid.
 		if c.StepParams.StepSize < 0 || c.StepParams.StepSize > c.Percent {
 			return false
 		}
+		// Ensure the schedule is valid.
+		if len(c.StepParams.Steps) > 0 && (c.StepParams.StepIndex < 0 || c.StepParams.StepIndex >= len(c.StepParams.Steps)) {
+			return false
+		}
+		for i, s := range c.StepParams.Steps {
+			if s.Percent < 1 || s.Percent > 99 || (i > 0 && s.Percent <= c.StepParams.Steps[i-1].Percent) {
+				return false
+			}
+		}
 		// If total % values in the revision do not add up — discard.
 		tot := 0
 		for _, r := range c.Revisions {
@@ -154,14 +471,59 @@ func (cur *Rollout) Validate() bool {
 	return true
 }
 
+// total returns the share of all the traffic, in the unit of the rollout.
+func (cur *ConfigurationRollout) total() int {
+	if cur.Weighted {
+		return v1.MaxTrafficWeight
+	}
+	return 100
+}
+
+// convert converts the shares of the rollout to the unit of the goal, when
+// the Route started or stopped splitting its traffic by weight. The
+// revisions whose share rounds down to 0 stop receiving traffic.
+func (cur *ConfigurationRollout) convert(weighted bool) {
+	if cur.Weighted == weighted {
+		return
+	}
+	cur.Weighted = weighted
+	const factor = v1.MaxTrafficWeight / 100
+	scale := func(p int) int {
+		if weighted {
+			return p * factor
+		}
+		return p / factor
+	}
+	cur.Percent = 0
+	out := cur.Revisions[:0]
+	for i, r := range cur.Revisions {
+		r.Percent = scale(r.Percent)
+		// Keep the latest revision, even with no traffic.
+		if r.Percent > 0 || i == len(cur.Revisions)-1 {
+			out = append(out, r)
+			cur.Percent += r.Percent
+		}
+	}
+	cur.Revisions = out
+	for i := range cur.PreviousRevisions {
+		cur.PreviousRevisions[i].Percent = scale(cur.PreviousRevisions[i].Percent)
+	}
+}
+
 // ObserveReady traverses the configs and the ones that are in rollout
 // but have not observed step time yet, will have it set, to
 // max(1, nowTS-cfg.StartTime).
-func (cur *Rollout) ObserveReady(ctx context.Context, nowTS int64, durationSecs float64) {
+// If steps is not empty the rollouts follow it instead of moving
+// the traffic in uniform steps over durationSecs.
+func (cur *Rollout) ObserveReady(ctx context.Context, nowTS int64, durationSecs float64, steps []RolloutStep) {
 	logger := logging.FromContext(ctx)
 	for i := range cur.Configurations {
 		c := cur.Configurations[i]
-		if c.StepParams.StepDuration == 0 && c.StepParams.StartTime > 0 {
+		if !c.StepParams.scheduled() && c.StepParams.StartTime > 0 && len(steps) > 0 {
+			c.StepParams.Steps = steps
+			c.enterStep(0, nowTS)
+			logger.Debugf("Scheduled rollout properties for %s: %#v", c.ConfigurationName, c.StepParams)
+		} else if !c.StepParams.scheduled() && c.StepParams.StartTime > 0 {
 			// In really ceil(nowTS-params.StartTime) should always give 1s, but
 			// given possible time drift, we'll ensure that at least 1s is returned.
 			minStepSec := math.Max(1, math.Ceil(time.Duration(nowTS-c.StepParams.StartTime).Seconds()))
@@ -190,13 +552,13 @@ func (cur *Rollout) Step(ctx context.Context, prev *Rollout, nowTS int64) (*Roll
 	// The algorithm below is simplest, but probably not the most performant.
 	// TODO: optimize in the later passes.
 
-	// Map the configs by tag.
-	currConfigs, prevConfigs := map[string][]*ConfigurationRollout{}, map[string][]*ConfigurationRollout{}
+	// Map the configs by tag and path.
+	currConfigs, prevConfigs := map[rolloutKey][]*ConfigurationRollout{}, map[rolloutKey][]*ConfigurationRollout{}
 	for i, cfg := range cur.Configurations {
-		currConfigs[cfg.Tag] = append(currConfigs[cfg.Tag], cur.Configurations[i])
+		currConfigs[cfg.key()] = append(currConfigs[cfg.key()], cur.Configurations[i])
 	}
 	for i, cfg := range prev.Configurations {
-		prevConfigs[cfg.Tag] = append(prevConfigs[cfg.Tag], prev.Configurations[i])
+		prevConfigs[cfg.key()] = append(prevConfigs[cfg.key()], prev.Configurations[i])
 	}
 
 	var ret []*ConfigurationRollout
@@ -281,38 +643,107 @@ func adjustPercentage(goal int, cr *ConfigurationRollout, logger *zap.SugaredLog
 	case diff > 0:
 		logger.Infof("Traffic for config %s increased by %d%%, assigning the difference to the latest revision",
 			cr.ConfigurationName)
-		cr.Revisions[len(cr.Revisions)-1].Percent += diff
+		cr.Revisions = resizeRevisions(cr.Revisions, diff)
 	case diff < 0:
-		diff = -diff // To make logic more natural.
 		logger.Infof("Traffic for config %s decreased by %d%%, removing the difference from the oldest revision(s)",
 			cr.ConfigurationName)
-		i := 0
-		for diff > 0 && i < len(cr.Revisions) {
-			if cr.Revisions[i].Percent > diff {
-				cr.Revisions[i].Percent -= diff
-				break
-			}
-			diff -= cr.Revisions[i].Percent
-			i++
-		}
-		// Remove the revisions that got cut to 0%.
-		cr.Revisions = cr.Revisions[i:]
+		cr.Revisions = resizeRevisions(cr.Revisions, diff)
 	default: // diff = 0
 		// noop; no log; this is the normal operation.
 	}
 }
 
+// resizeRevisions changes the traffic of the revisions by diff. If it grows,
+// the latest revision gets the difference, if it shrinks then the traffic
+// is removed from the oldest revision(s).
+func resizeRevisions(revs []RevisionRollout, diff int) []RevisionRollout {
+	if diff >= 0 {
+		if len(revs) > 0 {
+			revs[len(revs)-1].Percent += diff
+		}
+		return revs
+	}
+	diff = -diff // To make logic more natural.
+	i := 0
+	for diff > 0 && i < len(revs) {
+		if revs[i].Percent > diff {
+			revs[i].Percent -= diff
+			break
+		}
+		diff -= revs[i].Percent
+		i++
+	}
+	// Remove the revisions that got cut to 0%.
+	return revs[i:]
+}
+
 // stepRevisions performs re-adjustment of percentages on the revisions
 // to rollout more traffic to the last one.
 func stepRevisions(goal *ConfigurationRollout, nowTS int64) {
 	// Not yet ready to adjust the steps or we're done
 	// (shouldn't really be here, but better be defensive).
-	if nowTS < goal.StepParams.NextStepTime || len(goal.Revisions) < 2 {
+	if nowTS < goal.StepParams.NextStepTime || len(goal.Revisions) < 2 ||
+		goal.StepParams.AwaitingApproval || goal.StepParams.Paused {
+		return
+	}
+	if len(goal.StepParams.Steps) > 0 {
+		goal.enterStep(goal.StepParams.StepIndex+1, nowTS)
+		return
+	}
+
+	moveTraffic(goal, goal.StepParams.StepSize)
+	// Also set the next time.
+	if len(goal.Revisions) > 1 {
+		goal.StepParams.NextStepTime = nowTS + goal.StepParams.StepDuration
+	} else {
+		// This is the last step, we're done! Clear the params out.
+		goal.StepParams = RolloutParams{}
+	}
+}
+
+// enterStep moves the rollout to the i-th step of its schedule. Past the
+// last step all the traffic is moved to the latest revision.
+func (cur *ConfigurationRollout) enterStep(i int, nowTS int64) {
+	params := &cur.StepParams
+	params.StepIndex = i
+	latest := cur.Revisions[len(cur.Revisions)-1].Percent
+	if i >= len(params.Steps) {
+		moveTraffic(cur, cur.Percent-latest)
+	} else if target := cur.stepTarget(i); target > latest {
+		moveTraffic(cur, target-latest)
+	}
+	if len(cur.Revisions) < 2 {
+		// This was the last step, we're done! Clear the params out.
+		cur.StepParams = RolloutParams{}
+		return
+	}
+
+	step := params.Steps[i]
+	if step.Pause {
+		params.AwaitingApproval = true
+		params.StepDuration = 0
+		params.NextStepTime = 0
 		return
 	}
+	params.StepDuration = step.Duration
+	params.NextStepTime = nowTS + step.Duration
+}
+
+// stepTarget returns the share of the route traffic the latest revision
+// receives during the i-th step of the schedule.
+func (cur *ConfigurationRollout) stepTarget(i int) int {
+	t := int(math.Round(float64(cur.Percent*cur.StepParams.Steps[i].Percent) / 100))
+	if t < 1 {
+		return 1
+	}
+	return t
+}
 
+// moveTraffic moves size percent of the traffic of the configuration from
+// the newest of the older revisions to the latest revision.
+func moveTraffic(goal *ConfigurationRollout, size int) {
 	revLen := len(goal.Revisions)
-	remaining := goal.StepParams.StepSize
+	remaining := size
 	writePos := revLen - 1
 	// readPos is guaranteed to be >= 0, due to the check above.
 	readPos := revLen - 2
@@ -342,7 +773,7 @@ func stepRevisions(goal *ConfigurationRollout, nowTS int64) {
 	// Copy the last one to the write pos
 	goal.Revisions[writePos] = goal.Revisions[revLen-1]
 
-	goal.Revisions[writePos].Percent += goal.StepParams.StepSize
+	goal.Revisions[writePos].Percent += size
 	// This can happen if step is now larger than total allocation, see the
 	// note above.
 	// E.g. with example above R2 = 20, and ro we have to cap it at 15.
@@ -351,23 +782,19 @@ func stepRevisions(goal *ConfigurationRollout, nowTS int64) {
 	}
 	// And cull the tail portion of it.
 	goal.Revisions = goal.Revisions[:writePos+1]
-	// Also set the next time.
-	if len(goal.Revisions) > 1 {
-		goal.StepParams.NextStepTime = nowTS + goal.StepParams.StepDuration
-	} else {
-		// This is the last step, we're done! Clear the params out.
-		goal.StepParams = RolloutParams{}
-	}
 }
 
 // stepConfig takes previous and goal configuration shapes and returns a new
 // config rollout, after computing the percetage allocations.
 func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.SugaredLogger) *ConfigurationRollout {
+	prev.convert(goal.Weighted)
 	pc := len(prev.Revisions)
 	ret := &ConfigurationRollout{
 		ConfigurationName: goal.ConfigurationName,
 		Tag:               goal.Tag,
+		Path:              goal.Path,
 		Percent:           goal.Percent,
+		Weighted:          goal.Weighted,
 		Revisions:         goal.Revisions,
 
 		// If there is a new revision, then timing information should be reset.
@@ -377,6 +804,16 @@ func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.Sugar
 	if len(prev.Revisions) > 0 {
 		adjustPercentage(goal.Percent, prev, logger)
 	}
+	// If the desired revision was rolled back, keep serving the revision
+	// it was rolled back to, until a newer revision becomes desired.
+	if prev.RolledBack != nil && len(prev.Revisions) > 0 &&
+		goal.Revisions[0].RevisionName == prev.RolledBack.RevisionName {
+		logger.Debugf("Revision %s of config %s was rolled back, not rolling it out",
+			prev.RolledBack.RevisionName, goal.ConfigurationName)
+		ret.Revisions = prev.Revisions
+		ret.RolledBack = prev.RolledBack
+		return ret
+	}
 	// goal will always have just one revision in the list – the current desired revision.
 	// If it matches the last revision of the previous rollout state (or there were no revisions)
 	// then no new rollout has begun for this configuration.
@@ -394,14 +831,18 @@ func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.Sugar
 			// Copy various rollout stats from the previous when no new revision
 			// has been created.
 			ret.StepParams = prev.StepParams
+			ret.PreviousRevisions = prev.PreviousRevisions
 			// We might end up here before `ObserveReady` is called.
 			// In that case don't step individual revisions just yet.
-			if ret.StepParams.StepSize > 0 {
+			if ret.StepParams.scheduled() {
 				// adjustPercentage above would've already accounted if target for the
 				// whole Configuration changed up or down. So here we should just redistribute
 				// the existing values.
 				stepRevisions(ret, nowTS)
 			}
+			if len(ret.Revisions) < 2 {
+				ret.PreviousRevisions = nil
+			}
 		}
 		return ret
 	}
@@ -412,6 +853,7 @@ func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.Sugar
 	logger.Debugf("Starting a new revision rollout for configuration %s and revision %s at %d",
 		goal.ConfigurationName, goal.Revisions[0].RevisionName, nowTS)
 	ret.StepParams.StartTime = nowTS
+	ret.PreviousRevisions = append([]RevisionRollout(nil), prev.Revisions...)
 
 	// Go backwards and find first revision with traffic assignment > 0.
 	// Reduce it by one, so we can give that 1% to the new revision.
@@ -484,14 +926,15 @@ func (cur *ConfigurationRollout) computeProperties(nowTS, minStepSec, durationSe
 	cur.StepParams.NextStepTime = int64(nowTS + stepDuration)
 }
 
-// sortRollout sorts the rollout based on tag so it's consistent
+// sortRollout sorts the rollout based on path and tag so it's consistent
 // from run to run, since input to the process is map iterator.
 func sortRollout(r *Rollout) {
 	sort.Slice(r.Configurations, func(i, j int) bool {
-		// Sort by tag and within tag sort by config name.
-		if r.Configurations[i].Tag == r.Configurations[j].Tag {
+		// Sort by path and tag and within them sort by config name.
+		ki, kj := r.Configurations[i].key(), r.Configurations[j].key()
+		if ki == kj {
 			return r.Configurations[i].ConfigurationName < r.Configurations[j].ConfigurationName
 		}
-		return r.Configurations[i].Tag < r.Configurations[j].Tag
+		return ki.less(kj)
 	})
 }
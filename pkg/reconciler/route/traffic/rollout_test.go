@@ -28,8 +28,10 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "knative.dev/pkg/logging/testing"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
 func TestStep(t *testing.T) {
@@ -312,6 +314,13 @@ func TestStep(t *testing.T) {
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "keith",
 				Percent:           52,
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "sticky-fingers",
+					Percent:      36,
+				}, {
+					RevisionName: "beggars-banquet",
+					Percent:      16,
+				}},
 				StepParams: RolloutParams{
 					StartTime: 2020,
 				},
@@ -489,6 +498,10 @@ func TestStep(t *testing.T) {
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "mick",
 				Percent:           100,
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "goat-head-soup",
+					Percent:      100,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -530,6 +543,13 @@ func TestStep(t *testing.T) {
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "mick",
 				Percent:           33,
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "goat-head-soup",
+					Percent:      2,
+				}, {
+					RevisionName: "aftermath",
+					Percent:      31,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -573,6 +593,13 @@ func TestStep(t *testing.T) {
 		want: &Rollout{
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "mick",
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "goat-head-soup",
+					Percent:      11,
+				}, {
+					RevisionName: "aftermath",
+					Percent:      64,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -615,6 +642,10 @@ func TestStep(t *testing.T) {
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "brian",
 				Percent:           70,
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      70,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -659,6 +690,13 @@ func TestStep(t *testing.T) {
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "mick",
 				Percent:           100,
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "goat-head-soup",
+					Percent:      95,
+				}, {
+					RevisionName: "beggars-banquet",
+					Percent:      5,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -705,6 +743,13 @@ func TestStep(t *testing.T) {
 		want: &Rollout{
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "mick",
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "goat-head-soup",
+					Percent:      99,
+				}, {
+					RevisionName: "bridges-to-babylon",
+					Percent:      1,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -877,6 +922,10 @@ func TestStep(t *testing.T) {
 			Configurations: []*ConfigurationRollout{{
 				ConfigurationName: "keith",
 				Percent:           99,
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "can't-get-no-satisfaction",
+					Percent:      99,
+				}},
 				StepParams: RolloutParams{
 					StartTime: now,
 				},
@@ -988,6 +1037,258 @@ func TestStep(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name: "rolled back revision stays rolled back",
+		cur: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "goats-head-soup",
+					Percent:      100,
+				}},
+			}},
+		},
+		prev: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+				RolledBack: &RolledBackRevision{
+					RevisionName: "goats-head-soup",
+					Reason:       "too slow",
+				},
+			}},
+		},
+		want: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+				RolledBack: &RolledBackRevision{
+					RevisionName: "goats-head-soup",
+					Reason:       "too slow",
+				},
+			}},
+		},
+	}, {
+		name: "newer revision after a rollback",
+		cur: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "black-and-blue",
+					Percent:      100,
+				}},
+			}},
+		},
+		prev: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+				RolledBack: &RolledBackRevision{
+					RevisionName: "goats-head-soup",
+					Reason:       "too slow",
+				},
+			}},
+		},
+		want: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      99,
+				}, {
+					RevisionName: "black-and-blue",
+					Percent:      1,
+				}},
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+				StepParams: RolloutParams{
+					StartTime: now,
+				},
+			}},
+		},
+	}, {
+		name: "pinned back to the previous revision after a rollback",
+		cur: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+			}},
+		},
+		prev: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+				RolledBack: &RolledBackRevision{
+					RevisionName: "goats-head-soup",
+					Reason:       "too slow",
+				},
+			}},
+		},
+		want: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      100,
+				}},
+			}},
+		},
+	}, {
+		name: "weighted, new revision",
+		cur: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           9990,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "goats-head-soup",
+					Percent:      9990,
+				}},
+			}},
+		},
+		prev: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           9990,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      9990,
+				}},
+			}},
+		},
+		// The rollout starts at a basis point.
+		want: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           9990,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      9989,
+				}, {
+					RevisionName: "goats-head-soup",
+					Percent:      1,
+				}},
+				PreviousRevisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      9990,
+				}},
+				StepParams: RolloutParams{
+					StartTime: now,
+				},
+			}},
+		},
+	}, {
+		name: "percents to weights, rollout in progress",
+		cur: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           9950,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "goats-head-soup",
+					Percent:      9950,
+				}},
+			}},
+		},
+		prev: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      60,
+				}, {
+					RevisionName: "goats-head-soup",
+					Percent:      40,
+				}},
+			}},
+		},
+		want: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           9950,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      5950,
+				}, {
+					RevisionName: "goats-head-soup",
+					Percent:      4000,
+				}},
+			}},
+		},
+	}, {
+		name: "weights to percents, rollout in progress",
+		cur: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "goats-head-soup",
+					Percent:      100,
+				}},
+			}},
+		},
+		prev: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           10000,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "sticky-fingers",
+					Percent:      50,
+				}, {
+					RevisionName: "exile-on-main-st",
+					Percent:      9900,
+				}, {
+					RevisionName: "goats-head-soup",
+					Percent:      50,
+				}},
+			}},
+		},
+		// The revisions under a percent are drained, the latest revision
+		// gets the remainder.
+		want: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "exile-on-main-st",
+					Percent:      99,
+				}, {
+					RevisionName: "goats-head-soup",
+					Percent:      1,
+				}},
+			}},
+		},
 	}}
 
 	for _, tc := range tests {
@@ -1122,7 +1423,7 @@ func TestObserveReady(t *testing.T) {
 
 	// This works in place.
 	ctx := TestContextWithLogger(t)
-	ro.ObserveReady(ctx, now, duration)
+	ro.ObserveReady(ctx, now, duration, nil)
 
 	if !cmp.Equal(ro, want) {
 		t.Errorf("ObserveReady generated mismatched config: diff(-want,+got):\n%s",
@@ -1131,6 +1432,391 @@ func TestObserveReady(t *testing.T) {
 
 }
 
+func TestObserveReadySchedule(t *testing.T) {
+	const now = 200620092020
+	steps := []RolloutStep{{Percent: 10, Duration: 300}, {Percent: 50, Pause: true}}
+	ro := Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "started",
+			Percent:           80,
+			Revisions: []RevisionRollout{{
+				RevisionName: "brown-sugar",
+				Percent:      79,
+			}, {
+				RevisionName: "wild-horses",
+				Percent:      1,
+			}},
+			StepParams: RolloutParams{
+				StartTime: 1971,
+			},
+		}, {
+			ConfigurationName: "not-started",
+			Percent:           20,
+			Revisions: []RevisionRollout{{
+				RevisionName: "angie",
+				Percent:      20,
+			}},
+		}},
+	}
+	want := Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "started",
+			Percent:           80,
+			Revisions: []RevisionRollout{{
+				RevisionName: "brown-sugar",
+				Percent:      72,
+			}, {
+				RevisionName: "wild-horses",
+				Percent:      8, // 10% of 80%.
+			}},
+			StepParams: RolloutParams{
+				StartTime:    1971,
+				StepDuration: 300,
+				NextStepTime: now + 300,
+				Steps:        steps,
+			},
+		}, {
+			ConfigurationName: "not-started",
+			Percent:           20,
+			Revisions: []RevisionRollout{{
+				RevisionName: "angie",
+				Percent:      20,
+			}},
+		}},
+	}
+
+	ro.ObserveReady(TestContextWithLogger(t), now, 120, steps)
+	if !cmp.Equal(ro, want) {
+		t.Errorf("ObserveReady generated mismatched config: diff(-want,+got):\n%s",
+			cmp.Diff(want, ro))
+	}
+}
+
+func TestApprove(t *testing.T) {
+	cr := &ConfigurationRollout{
+		ConfigurationName: "mick",
+		Percent:           100,
+		Revisions: []RevisionRollout{{
+			RevisionName: "sticky-fingers",
+			Percent:      75,
+		}, {
+			RevisionName: "goats-head-soup",
+			Percent:      25,
+		}},
+		StepParams: RolloutParams{
+			StartTime: 1971,
+			Steps: []RolloutStep{
+				{Percent: 10, Duration: 300},
+				{Percent: 25, Pause: true},
+				{Percent: 50, Duration: 600},
+			},
+			StepIndex:        1,
+			AwaitingApproval: true,
+		},
+	}
+	ro := &Rollout{Configurations: []*ConfigurationRollout{{ConfigurationName: "keith"}, cr}}
+	if got := ro.AwaitingApproval(); !cmp.Equal(got, []*ConfigurationRollout{cr}) {
+		t.Errorf("AwaitingApproval() = %v, want: %v", got, []*ConfigurationRollout{cr})
+	}
+	if got, want := cr.LatestRevision(), "goats-head-soup"; got != want {
+		t.Errorf("LatestRevision() = %s, want: %s", got, want)
+	}
+	if got, want := cr.PausedAt(), 25; got != want {
+		t.Errorf("PausedAt() = %d, want: %d", got, want)
+	}
+	if cr.StepDue(2020) {
+		t.Error("StepDue() = true while awaiting approval")
+	}
+
+	// Paused rollouts don't step until approved.
+	stepRevisions(cr, 2020)
+	if got := cr.Revisions[1].Percent; got != 25 {
+		t.Errorf("Latest revision percent = %d while paused, want: 25", got)
+	}
+
+	cr.Approve(2020)
+	if cr.PausedAt() != 0 || !cr.StepDue(2020) {
+		t.Errorf("Approved rollout is not due to step: %#v", cr.StepParams)
+	}
+	// A postponed step waits for the duration of the next step.
+	if got, want := cr.StepParams.StepDuration, int64(600); got != want {
+		t.Errorf("StepDuration after the approval = %d, want: %d", got, want)
+	}
+	stepRevisions(cr, 2020)
+	want := RolloutParams{
+		StartTime:    1971,
+		Steps:        cr.StepParams.Steps,
+		StepIndex:    2,
+		StepDuration: 600,
+		NextStepTime: 2620,
+	}
+	if !cmp.Equal(cr.StepParams, want) {
+		t.Error("StepParams after the approval (-want, +got):", cmp.Diff(want, cr.StepParams))
+	}
+	if got := cr.Revisions[1].Percent; got != 50 {
+		t.Errorf("Latest revision percent = %d after the approval, want: 50", got)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	cr := &ConfigurationRollout{
+		ConfigurationName: "mick",
+		Percent:           100,
+		Revisions: []RevisionRollout{{
+			RevisionName: "sticky-fingers",
+			Percent:      60,
+		}, {
+			RevisionName: "goats-head-soup",
+			Percent:      40,
+		}},
+		StepParams: RolloutParams{
+			StartTime:    1971,
+			NextStepTime: 2020,
+			StepDuration: 16,
+			StepSize:     20,
+		},
+	}
+	cr.Pause()
+	if !cr.StepParams.Paused || cr.StepParams.NextStepTime != 0 {
+		t.Errorf("Pause() = %#v, want paused without a next step", cr.StepParams)
+	}
+	ro := &Rollout{Configurations: []*ConfigurationRollout{{ConfigurationName: "keith"}, cr}}
+	if got := ro.Paused(); !cmp.Equal(got, []*ConfigurationRollout{cr}) {
+		t.Errorf("Paused() = %v, want: %v", got, []*ConfigurationRollout{cr})
+	}
+	if cr.StepDue(2020) {
+		t.Error("StepDue() = true while paused")
+	}
+
+	// Paused rollouts hold their traffic split.
+	stepRevisions(cr, 2020)
+	if got := cr.Revisions[1].Percent; got != 40 {
+		t.Errorf("Latest revision percent = %d while paused, want: 40", got)
+	}
+
+	cr.Resume(2030)
+	want := RolloutParams{
+		StartTime:    1971,
+		NextStepTime: 2046,
+		StepDuration: 16,
+		StepSize:     20,
+	}
+	if !cmp.Equal(cr.StepParams, want) {
+		t.Error("StepParams after Resume() (-want, +got):", cmp.Diff(want, cr.StepParams))
+	}
+	// Resuming a rollout that isn't paused is a noop.
+	cr.Resume(2040)
+	if !cmp.Equal(cr.StepParams, want) {
+		t.Error("StepParams after a second Resume() (-want, +got):", cmp.Diff(want, cr.StepParams))
+	}
+
+	// Rollouts without computed steps don't get a next step on resume.
+	cr.StepParams = RolloutParams{StartTime: 1971}
+	cr.Pause()
+	cr.Resume(2030)
+	if want := (RolloutParams{StartTime: 1971}); !cmp.Equal(cr.StepParams, want) {
+		t.Error("StepParams after Resume() (-want, +got):", cmp.Diff(want, cr.StepParams))
+	}
+
+	// Pausing a done rollout is a noop.
+	done := &ConfigurationRollout{Revisions: []RevisionRollout{{RevisionName: "angie", Percent: 100}}}
+	if done.Pause(); done.StepParams.Paused {
+		t.Error("Pause() paused a done rollout")
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	nst := time.Unix(1982, 0)
+	ro := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "done",
+			Percent:           100,
+			Revisions: []RevisionRollout{{
+				RevisionName: "angie",
+				Percent:      100,
+			}},
+		}, {
+			ConfigurationName: "progressing",
+			Percent:           100,
+			Revisions: []RevisionRollout{{
+				RevisionName: "sticky-fingers",
+				Percent:      60,
+			}, {
+				RevisionName: "goats-head-soup",
+				Percent:      40,
+			}},
+			StepParams: RolloutParams{
+				StartTime:    time.Unix(1971, 0).UnixNano(),
+				NextStepTime: nst.Add(300 * time.Millisecond).UnixNano(),
+				StepDuration: int64(10 * time.Second),
+				StepSize:     20,
+			},
+		}, {
+			ConfigurationName: "paused",
+			Tag:               "keith",
+			Percent:           50,
+			Revisions: []RevisionRollout{{
+				RevisionName: "brown-sugar",
+				Percent:      49,
+			}, {
+				RevisionName: "wild-horses",
+				Percent:      1,
+			}},
+			StepParams: RolloutParams{
+				Paused: true,
+			},
+		}, {
+			ConfigurationName: "awaiting",
+			Path:              "/sessions",
+			Percent:           50,
+			Revisions: []RevisionRollout{{
+				RevisionName: "beast-of-burden",
+				Percent:      25,
+			}, {
+				RevisionName: "miss-you",
+				Percent:      25,
+			}},
+			StepParams: RolloutParams{
+				AwaitingApproval: true,
+			},
+		}, {
+			ConfigurationName: "weighted",
+			Tag:               "ronnie",
+			Percent:           10000,
+			Weighted:          true,
+			Revisions: []RevisionRollout{{
+				RevisionName: "some-girls",
+				Percent:      9995,
+			}, {
+				RevisionName: "tattoo-you",
+				Percent:      5,
+			}},
+		}},
+	}
+	want := []v1.RolloutStatus{{
+		ConfigurationName: "progressing",
+		State:             v1.RolloutStateProgressing,
+		Revisions: []v1.RolloutRevisionStatus{{
+			RevisionName: "sticky-fingers",
+			Percent:      60,
+		}, {
+			RevisionName: "goats-head-soup",
+			Percent:      40,
+		}},
+		StartTime: &metav1.Time{Time: time.Unix(1971, 0)},
+		// Truncated to the second.
+		NextStepTime: &metav1.Time{Time: nst},
+		// 3 steps of 20% to go, 10s apart.
+		EstimatedCompletionTime: &metav1.Time{Time: nst.Add(20 * time.Second)},
+	}, {
+		ConfigurationName: "paused",
+		Tag:               "keith",
+		State:             v1.RolloutStatePaused,
+		Revisions: []v1.RolloutRevisionStatus{{
+			RevisionName: "brown-sugar",
+			Percent:      49,
+		}, {
+			RevisionName: "wild-horses",
+			Percent:      1,
+		}},
+	}, {
+		ConfigurationName: "awaiting",
+		Path:              "/sessions",
+		State:             v1.RolloutStateAwaitingApproval,
+		Revisions: []v1.RolloutRevisionStatus{{
+			RevisionName: "beast-of-burden",
+			Percent:      25,
+		}, {
+			RevisionName: "miss-you",
+			Percent:      25,
+		}},
+	}, {
+		ConfigurationName: "weighted",
+		Tag:               "ronnie",
+		State:             v1.RolloutStateProgressing,
+		Revisions: []v1.RolloutRevisionStatus{{
+			RevisionName: "some-girls",
+			Percent:      99,
+			Weight:       9995,
+		}, {
+			RevisionName: "tattoo-you",
+			Percent:      0,
+			Weight:       5,
+		}},
+	}}
+	if got := ro.Status(); !cmp.Equal(got, want) {
+		t.Error("Status() (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func TestEstimateCompletion(t *testing.T) {
+	const nst = int64(1982 * time.Second)
+	revs := []RevisionRollout{{
+		RevisionName: "sticky-fingers",
+		Percent:      70,
+	}, {
+		RevisionName: "goats-head-soup",
+		Percent:      30,
+	}}
+	tests := []struct {
+		name   string
+		params RolloutParams
+		want   int64
+	}{{
+		name: "not scheduled yet",
+		params: RolloutParams{
+			StartTime: 1971,
+		},
+	}, {
+		name: "uniform steps",
+		params: RolloutParams{
+			NextStepTime: nst,
+			StepDuration: 10,
+			StepSize:     25,
+		},
+		// 70% left in 3 steps.
+		want: nst + 20,
+	}, {
+		name: "last uniform step",
+		params: RolloutParams{
+			NextStepTime: nst,
+			StepDuration: 10,
+			StepSize:     80,
+		},
+		want: nst,
+	}, {
+		name: "schedule",
+		params: RolloutParams{
+			NextStepTime: nst,
+			StepDuration: 10,
+			Steps:        []RolloutStep{{Percent: 30, Duration: 10}, {Percent: 50, Duration: 20}, {Percent: 90, Duration: 40}},
+		},
+		want: nst + 60,
+	}, {
+		name: "schedule with a pause ahead",
+		params: RolloutParams{
+			NextStepTime: nst,
+			StepDuration: 10,
+			Steps:        []RolloutStep{{Percent: 30, Duration: 10}, {Percent: 50, Pause: true}},
+		},
+	}, {
+		name: "paused",
+		params: RolloutParams{
+			StepDuration: 10,
+			StepSize:     25,
+			Paused:       true,
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := &ConfigurationRollout{Percent: 100, Revisions: revs, StepParams: tc.params}
+			if got := cr.estimateCompletion(); got != tc.want {
+				t.Errorf("estimateCompletion() = %d, want: %d", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestAdjustPercentage(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1274,6 +1960,19 @@ func TestValidateFailures(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name: "weighted config > 100%",
+		r: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "keith",
+				Percent:           10001,
+				Weighted:          true,
+				Revisions: []RevisionRollout{{
+					RevisionName: "black-on-blue",
+					Percent:      10001,
+				}},
+			}},
+		},
 	}, {
 		name: "step larger than total",
 		r: &Rollout{
@@ -1351,6 +2050,37 @@ func TestValidateFailures(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name: "step index out of the schedule",
+		r: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "keith",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "black-on-blue",
+					Percent:      100,
+				}},
+				StepParams: RolloutParams{
+					Steps:     []RolloutStep{{Percent: 10, Duration: 1}},
+					StepIndex: 1,
+				},
+			}},
+		},
+	}, {
+		name: "schedule not increasing",
+		r: &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "keith",
+				Percent:           100,
+				Revisions: []RevisionRollout{{
+					RevisionName: "black-on-blue",
+					Percent:      100,
+				}},
+				StepParams: RolloutParams{
+					Steps: []RolloutStep{{Percent: 10, Duration: 1}, {Percent: 10, Duration: 1}},
+				},
+			}},
+		},
 	}}
 
 	for _, tc := range tests {
@@ -1412,6 +2142,123 @@ func TestConfigDone(t *testing.T) {
 	}
 }
 
+func TestRollback(t *testing.T) {
+	cr := &ConfigurationRollout{
+		ConfigurationName: "mick",
+		Percent:           90,
+		Revisions: []RevisionRollout{{
+			RevisionName: "sticky-fingers",
+			Percent:      10,
+		}, {
+			RevisionName: "exile-on-main-st",
+			Percent:      50,
+		}, {
+			RevisionName: "goats-head-soup",
+			Percent:      30,
+		}},
+		StepParams: RolloutParams{
+			StartTime:    2004,
+			NextStepTime: 2020,
+			StepDuration: 16,
+			StepSize:     20,
+		},
+	}
+	if !cr.StepDue(2020) {
+		t.Error("StepDue(2020) = false, want: true")
+	}
+	if cr.StepDue(2019) {
+		t.Error("StepDue(2019) = true, want: false")
+	}
+
+	cr.Rollback("too slow")
+	want := &ConfigurationRollout{
+		ConfigurationName: "mick",
+		Percent:           90,
+		Revisions: []RevisionRollout{{
+			RevisionName: "exile-on-main-st",
+			Percent:      90,
+		}},
+		RolledBack: &RolledBackRevision{
+			RevisionName: "goats-head-soup",
+			Reason:       "too slow",
+		},
+	}
+	if !cmp.Equal(cr, want) {
+		t.Error("Rollback() (-want, +got):", cmp.Diff(want, cr))
+	}
+	if cr.StepDue(2020) {
+		t.Error("StepDue() = true after the rollback")
+	}
+
+	ro := &Rollout{Configurations: []*ConfigurationRollout{{ConfigurationName: "keith"}, cr}}
+	if got := ro.RolledBack(); !cmp.Equal(got, []*ConfigurationRollout{cr}) {
+		t.Errorf("RolledBack() = %v, want: %v", got, []*ConfigurationRollout{cr})
+	}
+
+	// A second rollback is a noop.
+	cr.Rollback("again")
+	if !cmp.Equal(cr, want) {
+		t.Error("Rollback() of a done rollout (-want, +got):", cmp.Diff(want, cr))
+	}
+}
+
+func TestRollbackRestoresPreviousSplit(t *testing.T) {
+	cr := &ConfigurationRollout{
+		ConfigurationName: "mick",
+		Percent:           80,
+		Revisions: []RevisionRollout{{
+			RevisionName: "sticky-fingers",
+			Percent:      50,
+		}, {
+			RevisionName: "goats-head-soup",
+			Percent:      30,
+		}},
+		// The traffic of the configuration was lowered during the rollout.
+		PreviousRevisions: []RevisionRollout{{
+			RevisionName: "sticky-fingers",
+			Percent:      40,
+		}, {
+			RevisionName: "exile-on-main-st",
+			Percent:      50,
+		}},
+		StepParams: RolloutParams{
+			StartTime:    2004,
+			NextStepTime: 2020,
+			StepDuration: 16,
+			StepSize:     20,
+		},
+	}
+
+	cr.Rollback("too slow")
+	want := &ConfigurationRollout{
+		ConfigurationName: "mick",
+		Percent:           80,
+		Revisions: []RevisionRollout{{
+			RevisionName: "sticky-fingers",
+			Percent:      30,
+		}, {
+			RevisionName: "exile-on-main-st",
+			Percent:      50,
+		}},
+		RolledBack: &RolledBackRevision{
+			RevisionName: "goats-head-soup",
+			Reason:       "too slow",
+		},
+	}
+	if !cmp.Equal(cr, want) {
+		t.Error("Rollback() (-want, +got):", cmp.Diff(want, cr))
+	}
+
+	// The restored split is held, rather than rolled out.
+	ro := &Rollout{Configurations: []*ConfigurationRollout{cr}}
+	if !ro.Done() {
+		t.Error("Done() = false after the rollback")
+	}
+	if got := ro.Status(); len(got) != 0 {
+		t.Errorf("Status() = %v, want: none", got)
+	}
+}
+
 func TestJSONRoundtrip(t *testing.T) {
 	orig := &Rollout{
 		Configurations: []*ConfigurationRollout{{
@@ -1637,6 +2484,75 @@ func TestStepRevisions(t *testing.T) {
 				Percent: 15,
 			}},
 		},
+	}, {
+		name: "schedule: next step",
+		now:  1984,
+		cfg: &ConfigurationRollout{
+			Percent: 60,
+			StepParams: RolloutParams{
+				NextStepTime: 1984,
+				StepDuration: 300,
+				Steps: []RolloutStep{
+					{Percent: 10, Duration: 300},
+					{Percent: 25, Pause: true},
+					{Percent: 50, Duration: 600},
+				},
+			},
+			Revisions: []RevisionRollout{{
+				Percent: 50,
+			}, {
+				Percent: 4,
+			}, {
+				Percent: 6,
+			}},
+		},
+		want: &ConfigurationRollout{
+			Percent: 60,
+			StepParams: RolloutParams{
+				StepIndex:        1,
+				AwaitingApproval: true,
+				Steps: []RolloutStep{
+					{Percent: 10, Duration: 300},
+					{Percent: 25, Pause: true},
+					{Percent: 50, Duration: 600},
+				},
+			},
+			Revisions: []RevisionRollout{{
+				Percent: 45,
+			}, {
+				Percent: 15, // 25% of 60%.
+			}},
+		},
+	}, {
+		name: "schedule: after the last step",
+		now:  1984,
+		cfg: &ConfigurationRollout{
+			Percent: 60,
+			StepParams: RolloutParams{
+				NextStepTime: 1984,
+				StepDuration: 600,
+				StepIndex:    2,
+				Steps: []RolloutStep{
+					{Percent: 10, Duration: 300},
+					{Percent: 25, Pause: true},
+					{Percent: 50, Duration: 600},
+				},
+			},
+			Revisions: []RevisionRollout{{
+				Percent: 30,
+			}, {
+				Percent: 30,
+			}},
+		},
+		want: &ConfigurationRollout{
+			Percent:    60,
+			StepParams: RolloutParams{
+				// we reset rollout params, since we're done now.
+			},
+			Revisions: []RevisionRollout{{
+				Percent: 60,
+			}},
+		},
 	}}
 
 	for _, tc := range tests {
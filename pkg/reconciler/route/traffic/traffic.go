@@ -19,9 +19,11 @@ package traffic
 import (
 	"context"
 	"errors"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	net "knative.dev/networking/pkg/apis/networking"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
@@ -45,6 +47,32 @@ type RevisionTarget struct {
 	Protocol net.ProtocolType
 }
 
+// Share returns the share of the traffic of the target: its weight in basis
+// points in the Routes split by weight, its percent otherwise.
+func (rt *RevisionTarget) Share() int64 {
+	if rt.Weight != nil {
+		return *rt.Weight
+	}
+	return *rt.Percent
+}
+
+// setShare sets the share of the traffic of the target, in its unit.
+func (rt *RevisionTarget) setShare(share int64) {
+	if rt.Weight != nil {
+		rt.Weight = ptr.Int64(share)
+	} else {
+		rt.Percent = ptr.Int64(share)
+	}
+}
+
+// total returns the share of all the traffic, in the unit of the target.
+func (rt *RevisionTarget) total() int64 {
+	if rt.Weight != nil {
+		return v1.MaxTrafficWeight
+	}
+	return 100
+}
+
 // RevisionTargets is a collection of revision targets.
 type RevisionTargets []RevisionTarget
 
@@ -57,9 +85,18 @@ type Config struct {
 	// realize a route's setting.
 	Targets map[string]RevisionTargets
 
+	// Group of traffic splits of the targets with a path, keyed by their
+	// path.
+	PathTargets map[string]RevisionTargets
+
 	// Visibility of the traffic targets.
 	Visibility map[string]netv1alpha1.IngressVisibility
 
+	// Weighted is set when the Route splits its traffic by weight: the
+	// shares of all the targets are weights in basis points rather than
+	// percents.
+	Weighted bool
+
 	// A list traffic targets, flattened to the Revision level.  This
 	// is used to populate the Route.Status.TrafficTarget field.
 	revisionTargets RevisionTargets
@@ -83,6 +120,15 @@ func BuildTrafficConfiguration(configLister listers.ConfigurationLister, revList
 	return newBuilder(configLister, revLister, r).build()
 }
 
+// rolloutsFor returns the rollouts of the traffic target: those of its
+// path if it has one, of its tag otherwise.
+func rolloutsFor(ro *Rollout, tt *v1.TrafficTarget) []*ConfigurationRollout {
+	if tt.Path != "" {
+		return ro.RolloutsByPath(tt.Path)
+	}
+	return ro.RolloutsByTag(tt.Tag)
+}
+
 func rolloutConfig(cfgName string, ros []*ConfigurationRollout) *ConfigurationRollout {
 	for _, ro := range ros {
 		if ro.ConfigurationName == cfgName {
@@ -135,10 +181,16 @@ func (cfg *Config) targetToStatus(ctx context.Context, r *v1.Route, tt *Revision
 		result := v1.TrafficTarget{
 			Tag:            tt.Tag,
 			RevisionName:   rr.RevisionName,
-			Percent:        ptr.Int64(int64(rr.Percent)),
 			LatestRevision: tt.LatestRevision,
+			MirrorPercent:  tt.MirrorPercent,
+			Path:           tt.Path,
 		}
 
+		if cfg.Weighted {
+			result.Weight = ptr.Int64(int64(rr.Percent))
+		} else {
+			result.Percent = ptr.Int64(int64(rr.Percent))
+		}
 		if tt.Tag != "" {
 			result.URL = url
 		}
@@ -159,7 +211,7 @@ func (cfg *Config) GetRevisionTrafficTargets(ctx context.Context, r *v1.Route, r
 		)
 
 		if tt.LatestRevision != nil && *tt.LatestRevision {
-			cfgs := ro.RolloutsByTag(tt.Tag)
+			cfgs := rolloutsFor(ro, &tt.TrafficTarget)
 			roCfg = rolloutConfig(tt.ConfigurationName, cfgs)
 		}
 
@@ -168,7 +220,7 @@ func (cfg *Config) GetRevisionTrafficTargets(ctx context.Context, r *v1.Route, r
 		if roCfg == nil {
 			revs = []RevisionRollout{{
 				RevisionName: tt.RevisionName,
-				Percent:      int(*tt.Percent),
+				Percent:      int(tt.Share()),
 			}}
 		} else {
 			revs = roCfg.Revisions
@@ -189,6 +241,9 @@ type configBuilder struct {
 	// targets is a grouping of traffic targets serving the same origin.
 	targets map[string]RevisionTargets
 
+	// pathTargets is a grouping of traffic targets serving the same path.
+	pathTargets map[string]RevisionTargets
+
 	// revisionTargets is the original list of targets, at the Revision level.
 	revisionTargets RevisionTargets
 
@@ -203,6 +258,9 @@ type configBuilder struct {
 
 	// TargetError are deferred until we got a complete list of all referred targets.
 	deferredTargetErr TargetError
+
+	// weighted is set when the shares of the targets are weights.
+	weighted bool
 }
 
 func newBuilder(
@@ -217,26 +275,41 @@ func newBuilder(
 
 		configurations: make(map[string]*v1.Configuration),
 		revisions:      make(map[string]*v1.Revision, 1),
+		weighted:       isWeighted(r.Spec.Traffic),
 	}
 }
 
+// isWeighted returns whether any of the traffic targets has a weight.
+func isWeighted(traffic []v1.TrafficTarget) bool {
+	for i := range traffic {
+		if traffic[i].Weight != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // BuildRollout builds the current rollout state.
 // It is expected to be invoked after applySpecTraffic.
-// Returned Rollout will be sorted by tag and within tag by configuration
-// (only default tag can have more than configuration object attached).
+// Returned Rollout will be sorted by path and tag and within them by
+// configuration (only default tag can have more than configuration object attached).
 func (cfg *Config) BuildRollout() *Rollout {
 	rollout := &Rollout{}
 
 	for tag, targets := range cfg.Targets {
-		buildRolloutForTag(rollout, tag, targets)
+		buildRolloutForTag(rollout, tag, "", targets)
+	}
+	for path, targets := range cfg.PathTargets {
+		buildRolloutForTag(rollout, "", path, targets)
 	}
 	sortRollout(rollout)
 	return rollout
 }
 
-// buildRolloutForTag builds the current rollout state.
+// buildRolloutForTag builds the current rollout state of the targets with
+// the tag, or with the path if it is set.
 // It is expected to be invoked after applySpecTraffic.
-func buildRolloutForTag(r *Rollout, tag string, rts RevisionTargets) {
+func buildRolloutForTag(r *Rollout, tag, path string, rts RevisionTargets) {
 	// Only main target will have more than 1 element here.
 	for _, rt := range rts {
 		// Skip if it's revision target.
@@ -246,19 +319,22 @@ func buildRolloutForTag(r *Rollout, tag string, rts RevisionTargets) {
 
 		// Ignore the rollouts with 0 percent target traffic.
 		// This can happen only for the default tag.
-		if *rt.Percent == 0 {
+		share := int(rt.Share())
+		if share == 0 {
 			continue
 		}
 		// The targets with the same revision are already joined together.
 		r.Configurations = append(r.Configurations, &ConfigurationRollout{
 			ConfigurationName: rt.ConfigurationName,
 			Tag:               tag,
-			Percent:           int(*rt.Percent),
+			Path:              path,
+			Percent:           share,
+			Weighted:          rt.Weight != nil,
 			Revisions: []RevisionRollout{{
 				RevisionName: rt.RevisionName,
 				// Note: this will match config value in steady state, but
 				// during rollout it will be overridden by the rollout logic.
-				Percent: int(*rt.Percent),
+				Percent: share,
 			}},
 		})
 	}
@@ -397,9 +473,9 @@ func (cb *configBuilder) addRevisionTarget(tt *v1.TrafficTarget) error {
 // This expects single digit lists, so just does an O(N) search.
 func mergeIfNecessary(rts RevisionTargets, rt RevisionTarget) RevisionTargets {
 	for i := range rts {
-		if rts[i].Tag == rt.Tag && rts[i].RevisionName == rt.RevisionName &&
+		if rts[i].Tag == rt.Tag && rts[i].Path == rt.Path && rts[i].RevisionName == rt.RevisionName &&
 			*rt.LatestRevision == *rts[i].LatestRevision {
-			rts[i].Percent = ptr.Int64(*rts[i].Percent + *rt.Percent)
+			rts[i].setShare(rts[i].Share() + rt.Share())
 			return rts
 		}
 	}
@@ -408,7 +484,23 @@ func mergeIfNecessary(rts RevisionTargets, rt RevisionTarget) RevisionTargets {
 
 func (cb *configBuilder) addFlattenedTarget(target RevisionTarget) {
 	name := target.TrafficTarget.Tag
+	target.Headers = mergeHeaderMutations(cb.route.Spec.Headers, target.Headers)
+	if cb.weighted {
+		// The percents of the targets are converted to weights.
+		if target.Weight == nil {
+			target.Weight = ptr.Int64(*target.Percent * (v1.MaxTrafficWeight / 100))
+		}
+		target.Percent = nil
+	}
 	cb.revisionTargets = mergeIfNecessary(cb.revisionTargets, target)
+	if path := target.TrafficTarget.Path; path != "" {
+		// The targets with a path only serve the requests for their path.
+		if cb.pathTargets == nil {
+			cb.pathTargets = make(map[string]RevisionTargets, 1)
+		}
+		cb.pathTargets[path] = append(cb.pathTargets[path], target)
+		return
+	}
 	cb.targets[DefaultTarget] = append(cb.targets[DefaultTarget], target)
 	if name != "" {
 		// This should always have just a single entry at most.
@@ -422,15 +514,85 @@ func (cb *configBuilder) build() (*Config, error) {
 	}
 	if cb.deferredTargetErr != nil {
 		cb.targets = nil
+		cb.pathTargets = nil
 		cb.revisionTargets = nil
 	}
-	return &Config{
+	cfg := &Config{
 		Targets:         consolidateAll(cb.targets),
 		revisionTargets: cb.revisionTargets,
 		Configurations:  cb.configurations,
 		Revisions:       cb.revisions,
 		MissingTargets:  cb.missingTargets,
-	}, cb.deferredTargetErr
+		Weighted:        cb.weighted,
+	}
+	if len(cb.pathTargets) > 0 {
+		cfg.PathTargets = consolidateAll(cb.pathTargets)
+	}
+	return cfg, cb.deferredTargetErr
+}
+
+// mergeHeaderMutations returns the header mutations of a traffic target:
+// those of the Route, overridden by those of the target for the headers
+// that it mutates.
+func mergeHeaderMutations(route, target *v1.HeaderMutations) *v1.HeaderMutations {
+	if route == nil {
+		return target
+	}
+	if target == nil {
+		return route.DeepCopy()
+	}
+	return &v1.HeaderMutations{
+		Request:  mergeHeaderOperations(route.Request, target.Request),
+		Response: mergeHeaderOperations(route.Response, target.Response),
+	}
+}
+
+func mergeHeaderOperations(route, target *v1.HeaderOperations) *v1.HeaderOperations {
+	if route == nil {
+		return target.DeepCopy()
+	}
+	if target == nil {
+		return route.DeepCopy()
+	}
+	merged := target.DeepCopy()
+	overridden := headerNames(target)
+	for name, value := range route.Set {
+		if !overridden.Has(strings.ToLower(name)) {
+			if merged.Set == nil {
+				merged.Set = make(map[string]string, len(route.Set))
+			}
+			merged.Set[name] = value
+		}
+	}
+	for name, value := range route.Add {
+		if !overridden.Has(strings.ToLower(name)) {
+			if merged.Add == nil {
+				merged.Add = make(map[string]string, len(route.Add))
+			}
+			merged.Add[name] = value
+		}
+	}
+	for _, name := range route.Remove {
+		if !overridden.Has(strings.ToLower(name)) {
+			merged.Remove = append(merged.Remove, name)
+		}
+	}
+	return merged
+}
+
+// headerNames returns the lowercase names of the headers of the operations.
+func headerNames(ops *v1.HeaderOperations) sets.String {
+	names := make(sets.String, len(ops.Set)+len(ops.Add)+len(ops.Remove))
+	for name := range ops.Set {
+		names.Insert(strings.ToLower(name))
+	}
+	for name := range ops.Add {
+		names.Insert(strings.ToLower(name))
+	}
+	for _, name := range ops.Remove {
+		names.Insert(strings.ToLower(name))
+	}
+	return names
 }
 
 func consolidateAll(targets map[string]RevisionTargets) map[string]RevisionTargets {
@@ -452,8 +614,7 @@ func consolidate(targets RevisionTargets) RevisionTargets {
 			names = append(names, name)
 			continue
 		}
-		cur.TrafficTarget.Percent = ptr.Int64(
-			*cur.TrafficTarget.Percent + *tt.TrafficTarget.Percent)
+		cur.setShare(cur.Share() + tt.Share())
 		byName[name] = cur
 	}
 	consolidated := make([]RevisionTarget, len(names))
@@ -461,7 +622,7 @@ func consolidate(targets RevisionTargets) RevisionTargets {
 		consolidated[i] = byName[name]
 	}
 	if len(consolidated) == 1 {
-		consolidated[0].TrafficTarget.Percent = ptr.Int64(100)
+		consolidated[0].setShare(consolidated[0].total())
 	}
 	return consolidated
 }
@@ -1325,6 +1325,270 @@ func TestBuildTrafficConfigurationMissingRevision(t *testing.T) {
 	}
 }
 
+func TestBuildTrafficConfigurationPaths(t *testing.T) {
+	route := testRouteWithTrafficTargets(WithSpecTraffic(v1.TrafficTarget{
+		ConfigurationName: goodConfig.Name,
+		Percent:           ptr.Int64(100),
+	}, v1.TrafficTarget{
+		ConfigurationName: niceConfig.Name,
+		Path:              "/api",
+		Percent:           ptr.Int64(100),
+	}))
+	tc, err := BuildTrafficConfiguration(configLister, revLister, route)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	wantTargets := map[string]RevisionTargets{
+		DefaultTarget: {{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: goodConfig.Name,
+				RevisionName:      goodNewRev.Name,
+				Percent:           ptr.Int64(100),
+				LatestRevision:    ptr.Bool(true),
+			},
+			Protocol: net.ProtocolH2C,
+		}},
+	}
+	if !cmp.Equal(wantTargets, tc.Targets, cmpOpts...) {
+		t.Error("Unexpected targets diff (-want +got):", cmp.Diff(wantTargets, tc.Targets, cmpOpts...))
+	}
+	wantPathTargets := map[string]RevisionTargets{
+		"/api": {{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: niceConfig.Name,
+				RevisionName:      niceNewRev.Name,
+				Path:              "/api",
+				Percent:           ptr.Int64(100),
+				LatestRevision:    ptr.Bool(true),
+			},
+			Protocol: net.ProtocolH2C,
+		}},
+	}
+	if !cmp.Equal(wantPathTargets, tc.PathTargets, cmpOpts...) {
+		t.Error("Unexpected path targets diff (-want +got):", cmp.Diff(wantPathTargets, tc.PathTargets, cmpOpts...))
+	}
+
+	// The rollouts of the path groups are looked up by their path.
+	if got := tc.BuildRollout().RolloutsByPath("/api"); len(got) != 1 || got[0].ConfigurationName != niceConfig.Name {
+		t.Errorf("RolloutsByPath(/api) = %v, want the rollout of %s", got, niceConfig.Name)
+	}
+	if got := tc.BuildRollout().RolloutsByTag(""); len(got) != 1 || got[0].ConfigurationName != goodConfig.Name {
+		t.Errorf(`RolloutsByTag("") = %v, want the rollout of %s`, got, goodConfig.Name)
+	}
+
+	ro := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: niceConfig.Name,
+			Path:              "/api",
+			Percent:           100,
+			Revisions: []RevisionRollout{{
+				RevisionName: niceOldRev.Name,
+				Percent:      70,
+			}, {
+				RevisionName: niceNewRev.Name,
+				Percent:      30,
+			}},
+		}},
+	}
+	want := []v1.TrafficTarget{{
+		RevisionName:   goodNewRev.Name,
+		Percent:        ptr.Int64(100),
+		LatestRevision: ptr.Bool(true),
+	}, {
+		RevisionName:   niceOldRev.Name,
+		Path:           "/api",
+		Percent:        ptr.Int64(70),
+		LatestRevision: ptr.Bool(true),
+	}, {
+		RevisionName:   niceNewRev.Name,
+		Path:           "/api",
+		Percent:        ptr.Int64(30),
+		LatestRevision: ptr.Bool(true),
+	}}
+	got, err := tc.GetRevisionTrafficTargets(getContext(), route, ro)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("Unexpected traffic diff (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestBuildTrafficConfigurationHeaders(t *testing.T) {
+	route := testRouteWithTrafficTargets(WithSpecTraffic(v1.TrafficTarget{
+		ConfigurationName: goodConfig.Name,
+		Percent:           ptr.Int64(90),
+	}, v1.TrafficTarget{
+		ConfigurationName: niceConfig.Name,
+		Percent:           ptr.Int64(10),
+		Headers: &v1.HeaderMutations{
+			Request: &v1.HeaderOperations{
+				Set:    map[string]string{"canary": "true"},
+				Remove: []string{"Debug"},
+			},
+		},
+	}))
+	route.Spec.Headers = &v1.HeaderMutations{
+		Request: &v1.HeaderOperations{
+			Set: map[string]string{"Canary": "false", "Debug": "true"},
+			Add: map[string]string{"Via": "route"},
+		},
+		Response: &v1.HeaderOperations{
+			Remove: []string{"Server"},
+		},
+	}
+	tc, err := BuildTrafficConfiguration(configLister, revLister, route)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	// The targets mutate the headers as the Route does, except for the
+	// headers they mutate themselves.
+	want := RevisionTargets{{
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: goodConfig.Name,
+			RevisionName:      goodNewRev.Name,
+			Percent:           ptr.Int64(90),
+			LatestRevision:    ptr.Bool(true),
+			Headers:           route.Spec.Headers,
+		},
+		Protocol: net.ProtocolH2C,
+	}, {
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: niceConfig.Name,
+			RevisionName:      niceNewRev.Name,
+			Percent:           ptr.Int64(10),
+			LatestRevision:    ptr.Bool(true),
+			Headers: &v1.HeaderMutations{
+				Request: &v1.HeaderOperations{
+					Set:    map[string]string{"canary": "true"},
+					Add:    map[string]string{"Via": "route"},
+					Remove: []string{"Debug"},
+				},
+				Response: &v1.HeaderOperations{
+					Remove: []string{"Server"},
+				},
+			},
+		},
+		Protocol: net.ProtocolH2C,
+	}}
+	if got := tc.Targets[DefaultTarget]; !cmp.Equal(want, got, cmpOpts...) {
+		t.Error("Unexpected targets diff (-want +got):", cmp.Diff(want, got, cmpOpts...))
+	}
+
+	// The header mutations are not reflected in status.
+	targets, err := tc.GetRevisionTrafficTargets(getContext(), route, tc.BuildRollout())
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	for _, tt := range targets {
+		if tt.Headers != nil {
+			t.Errorf("Status target %s has header mutations %v", tt.RevisionName, tt.Headers)
+		}
+	}
+}
+
+func TestBuildTrafficConfigurationWeights(t *testing.T) {
+	route := testRouteWithTrafficTargets(WithSpecTraffic(v1.TrafficTarget{
+		ConfigurationName: goodConfig.Name,
+		Percent:           ptr.Int64(99),
+	}, v1.TrafficTarget{
+		RevisionName: goodOldRev.Name,
+		Weight:       ptr.Int64(75),
+	}, v1.TrafficTarget{
+		ConfigurationName: niceConfig.Name,
+		Weight:            ptr.Int64(25),
+	}))
+	tc, err := BuildTrafficConfiguration(configLister, revLister, route)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if !tc.Weighted {
+		t.Error("Weighted = false, want true")
+	}
+
+	// The percents are converted to weights.
+	want := map[string]RevisionTargets{
+		DefaultTarget: {{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: goodConfig.Name,
+				RevisionName:      goodNewRev.Name,
+				Weight:            ptr.Int64(9900),
+				LatestRevision:    ptr.Bool(true),
+			},
+			Protocol: net.ProtocolH2C,
+		}, {
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: goodConfig.Name,
+				RevisionName:      goodOldRev.Name,
+				Weight:            ptr.Int64(75),
+				LatestRevision:    ptr.Bool(false),
+			},
+			Protocol: net.ProtocolHTTP1,
+		}, {
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: niceConfig.Name,
+				RevisionName:      niceNewRev.Name,
+				Weight:            ptr.Int64(25),
+				LatestRevision:    ptr.Bool(true),
+			},
+			Protocol: net.ProtocolH2C,
+		}},
+	}
+	if got := tc.Targets; !cmp.Equal(want, got, cmpOpts...) {
+		t.Error("Unexpected targets diff (-want +got):", cmp.Diff(want, got, cmpOpts...))
+	}
+
+	// The rollouts are in basis points too.
+	ro := tc.BuildRollout()
+	for _, c := range ro.Configurations {
+		if !c.Weighted {
+			t.Errorf("Rollout of %s is not weighted", c.ConfigurationName)
+		}
+	}
+
+	// The status reports the weights in place of the percents.
+	targets, err := tc.GetRevisionTrafficTargets(getContext(), route, ro)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	wantWeights := map[string]int64{
+		goodNewRev.Name: 9900,
+		goodOldRev.Name: 75,
+		niceNewRev.Name: 25,
+	}
+	for _, tt := range targets {
+		if tt.Percent != nil || tt.Weight == nil || *tt.Weight != wantWeights[tt.RevisionName] {
+			t.Errorf("Status target %s has percent %v and weight %v, want weight %d",
+				tt.RevisionName, tt.Percent, tt.Weight, wantWeights[tt.RevisionName])
+		}
+	}
+}
+
+func TestBuildTrafficConfigurationMatch(t *testing.T) {
+	r := testRouteWithTrafficTargets(WithSpecTraffic(v1.TrafficTarget{
+		RevisionName: goodNewRev.Name,
+		Percent:      ptr.Int64(100),
+	}, v1.TrafficTarget{
+		Tag:          "canary",
+		RevisionName: goodOldRev.Name,
+		Percent:      ptr.Int64(0),
+	}))
+	WithSpecMatch(v1.TrafficMatch{
+		Tag:     "canary",
+		Headers: []v1.TrafficHeaderMatch{{Name: "X-User", Exact: "alice"}},
+	})(r)
+
+	tc, err := BuildTrafficConfiguration(configLister, revLister, r)
+	if err != nil {
+		t.Fatal("BuildTrafficConfiguration() =", err)
+	}
+	if got := tc.Targets["canary"]; len(got) != 1 || got[0].RevisionName != goodOldRev.Name {
+		t.Errorf("Targets[canary] = %v, want %s", got, goodOldRev.Name)
+	}
+}
+
 var errAPI = errors.New("failed to connect API")
 
 type revFakeErrorLister struct {
@@ -123,6 +123,11 @@ func (l *Listers) GetDomainMappingLister() servingv1alpha1listers.DomainMappingL
 	return servingv1alpha1listers.NewDomainMappingLister(l.IndexerFor(&v1alpha1.DomainMapping{}))
 }
 
+// GetDomainMappingGrantLister returns a lister for DomainMappingGrant objects.
+func (l *Listers) GetDomainMappingGrantLister() servingv1alpha1listers.DomainMappingGrantLister {
+	return servingv1alpha1listers.NewDomainMappingGrantLister(l.IndexerFor(&v1alpha1.DomainMappingGrant{}))
+}
+
 // GetServerlessServiceLister returns a lister for the ServerlessService objects.
 func (l *Listers) GetServerlessServiceLister() networkinglisters.ServerlessServiceLister {
 	return networkinglisters.NewServerlessServiceLister(l.IndexerFor(&networking.ServerlessService{}))
@@ -202,3 +207,8 @@ func (l *Listers) GetPodsLister() corev1listers.PodLister {
 func (l *Listers) GetNamespaceLister() corev1listers.NamespaceLister {
 	return corev1listers.NewNamespaceLister(l.IndexerFor(&corev1.Namespace{}))
 }
+
+// GetResourceQuotaLister gets lister for ResourceQuota resource.
+func (l *Listers) GetResourceQuotaLister() corev1listers.ResourceQuotaLister {
+	return corev1listers.NewResourceQuotaLister(l.IndexerFor(&corev1.ResourceQuota{}))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PodsWithinQuotas returns the number of pods of the podSpec which fit in the
+// headroom of the quotas, i.e. their hard limits less their usage, along with
+// the name of the quota admitting the fewest of them. It returns -1 when the
+// quotas do not limit the pods. The quotas with scopes are ignored, since
+// whether they apply depends on more than the podSpec.
+func PodsWithinQuotas(quotas []*corev1.ResourceQuota, podSpec *corev1.PodSpec) (int64, string) {
+	usage := podUsage(podSpec)
+
+	pods, name := int64(-1), ""
+	for _, quota := range quotas {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for resourceName, hard := range quota.Status.Hard {
+			perPod, ok := usage[resourceName]
+			if !ok || perPod.IsZero() {
+				continue
+			}
+			headroom := hard.DeepCopy()
+			headroom.Sub(quota.Status.Used[resourceName])
+
+			n := headroom.MilliValue() / perPod.MilliValue()
+			if n < 0 {
+				n = 0
+			}
+			if pods == -1 || n < pods {
+				pods, name = n, quota.Name
+			}
+		}
+	}
+	return pods, name
+}
+
+// podUsage returns the quota usage of a pod of the podSpec, keyed by the
+// names of the resources quotas limit.
+func podUsage(podSpec *corev1.PodSpec) corev1.ResourceList {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for i := range podSpec.Containers {
+		addResources(requests, podSpec.Containers[i].Resources.Requests)
+		addResources(limits, podSpec.Containers[i].Resources.Limits)
+	}
+	// The init containers run one at a time, before the containers.
+	for i := range podSpec.InitContainers {
+		maxResources(requests, podSpec.InitContainers[i].Resources.Requests)
+		maxResources(limits, podSpec.InitContainers[i].Resources.Limits)
+	}
+
+	one := *resource.NewQuantity(1, resource.DecimalSI)
+	usage := corev1.ResourceList{
+		corev1.ResourcePods:               one,
+		corev1.ResourceName("count/pods"): one,
+	}
+	for name, q := range requests {
+		usage[name] = q
+		usage[corev1.DefaultResourceRequestsPrefix+name] = q
+	}
+	for name, q := range limits {
+		usage["limits."+name] = q
+	}
+	return usage
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, q := range resources {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+}
+
+func maxResources(total, resources corev1.ResourceList) {
+	for name, q := range resources {
+		if current, ok := total[name]; !ok || q.Cmp(current) > 0 {
+			total[name] = q.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodsWithinQuotas(t *testing.T) {
+	quota := func(name string, hard, used corev1.ResourceList) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: hard,
+				Used: used,
+			},
+		}
+	}
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+			},
+		}, {
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("25m"),
+				},
+			},
+		}},
+		InitContainers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		quotas    []*corev1.ResourceQuota
+		wantPods  int64
+		wantQuota string
+	}{{
+		name:     "no quotas",
+		wantPods: -1,
+	}, {
+		name: "unrelated resources",
+		quotas: []*corev1.ResourceQuota{quota("storage", corev1.ResourceList{
+			corev1.ResourceRequestsStorage: resource.MustParse("10Gi"),
+		}, nil)},
+		wantPods: -1,
+	}, {
+		name: "cpu requests",
+		quotas: []*corev1.ResourceQuota{quota("compute", corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("2"),
+		}, corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("500m"),
+		})},
+		// 1500m of headroom for 275m per pod.
+		wantPods:  5,
+		wantQuota: "compute",
+	}, {
+		name: "memory of the init containers",
+		quotas: []*corev1.ResourceQuota{quota("compute", corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("3Gi"),
+		}, nil)},
+		wantPods:  3,
+		wantQuota: "compute",
+	}, {
+		name: "fewest pods of several quotas",
+		quotas: []*corev1.ResourceQuota{quota("pods", corev1.ResourceList{
+			corev1.ResourcePods: resource.MustParse("10"),
+		}, corev1.ResourceList{
+			corev1.ResourcePods: resource.MustParse("8"),
+		}), quota("limits", corev1.ResourceList{
+			corev1.ResourceLimitsCPU: resource.MustParse("4"),
+		}, nil)},
+		wantPods:  2,
+		wantQuota: "pods",
+	}, {
+		name: "exceeded quota",
+		quotas: []*corev1.ResourceQuota{quota("pods", corev1.ResourceList{
+			corev1.ResourcePods: resource.MustParse("10"),
+		}, corev1.ResourceList{
+			corev1.ResourcePods: resource.MustParse("12"),
+		})},
+		wantPods:  0,
+		wantQuota: "pods",
+	}, {
+		name: "scoped quota",
+		quotas: []*corev1.ResourceQuota{{
+			ObjectMeta: metav1.ObjectMeta{Name: "best-effort"},
+			Spec: corev1.ResourceQuotaSpec{
+				Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort},
+			},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{
+					corev1.ResourcePods: resource.MustParse("1"),
+				},
+			},
+		}},
+		wantPods: -1,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pods, quota := PodsWithinQuotas(test.quotas, podSpec)
+			if pods != test.wantPods || quota != test.wantQuota {
+				t.Errorf("PodsWithinQuotas() = %d, %q, want: %d, %q", pods, quota, test.wantPods, test.wantQuota)
+			}
+		})
+	}
+}
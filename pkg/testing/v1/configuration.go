@@ -78,6 +78,13 @@ func WithLatestReady(name string) ConfigOption {
 	}
 }
 
+// WithGCCandidates sets the .status.gcCandidates to the names.
+func WithGCCandidates(names ...string) ConfigOption {
+	return func(cfg *v1.Configuration) {
+		cfg.Status.GCCandidates = names
+	}
+}
+
 // MarkRevisionCreationFailed calls .Status.MarkRevisionCreationFailed.
 func MarkRevisionCreationFailed(msg string) ConfigOption {
 	return func(cfg *v1.Configuration) {
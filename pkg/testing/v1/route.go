@@ -42,6 +42,13 @@ func WithSpecTraffic(traffic ...v1.TrafficTarget) RouteOption {
 	}
 }
 
+// WithSpecMatch sets the Route's match rules.
+func WithSpecMatch(match ...v1.TrafficMatch) RouteOption {
+	return func(r *v1.Route) {
+		r.Spec.Match = match
+	}
+}
+
 // WithRouteUID sets the Route's UID
 func WithRouteUID(uid types.UID) RouteOption {
 	return func(r *v1.Route) {
@@ -104,6 +111,13 @@ func WithStatusTraffic(traffic ...v1.TrafficTarget) RouteOption {
 	}
 }
 
+// WithStatusRollouts sets the rollouts in progress in the route status.
+func WithStatusRollouts(rollouts ...v1.RolloutStatus) RouteOption {
+	return func(r *v1.Route) {
+		r.Status.Rollouts = rollouts
+	}
+}
+
 // WithRouteOwnersRemoved clears the owner references of this Route.
 func WithRouteOwnersRemoved(r *v1.Route) {
 	r.OwnerReferences = nil
@@ -245,6 +259,26 @@ func MarkInRollout(r *v1.Route) {
 	r.Status.MarkIngressRolloutInProgress()
 }
 
+// MarkRolloutPaused marks the route to have its rollouts paused.
+func MarkRolloutPaused(r *v1.Route) {
+	r.Status.MarkIngressRolloutPaused()
+}
+
+// MarkAwaitingApproval marks the route to have the rollout of the revision
+// paused at percent until it is approved.
+func MarkAwaitingApproval(revision string, percent int) RouteOption {
+	return func(r *v1.Route) {
+		r.Status.MarkIngressRolloutAwaitingApproval(revision, percent)
+	}
+}
+
+// MarkRolledBack marks the route to have rolled back the rollout of the revision.
+func MarkRolledBack(revision, reason string) RouteOption {
+	return func(r *v1.Route) {
+		r.Status.MarkRolloutRolledBack(revision, reason)
+	}
+}
+
 // MarkIngressNotConfigured calls the method of the same name on .Status
 func MarkIngressNotConfigured(r *v1.Route) {
 	r.Status.MarkIngressNotConfigured()
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/webhook"
+	"knative.dev/serving/pkg/apis/serving"
+	servingwebhook "knative.dev/serving/pkg/webhook"
+)
+
+// admissionController evaluates the policies against the objects admitted
+// by its resource validation admission controller, e.g. of
+// validation.NewAdmissionController.
+type admissionController struct {
+	servingwebhook.ResourceReconciler
+
+	withContext func(context.Context) context.Context
+}
+
+// WithPolicies extends the resource validation admission controller impl to
+// evaluate the policies of the contexts returned by withContext.
+func WithPolicies(impl *controller.Impl, withContext func(context.Context) context.Context) *controller.Impl {
+	impl.Reconciler = &admissionController{
+		ResourceReconciler: impl.Reconciler.(servingwebhook.ResourceReconciler),
+		withContext:        withContext,
+	}
+	return impl
+}
+
+// Admit implements AdmissionController
+func (ac *admissionController) Admit(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := ac.ResourceReconciler.Admit(ctx, request)
+	if !response.Allowed || request.Kind.Group != serving.GroupName || request.SubResource != "" {
+		return response
+	}
+	switch request.Operation {
+	case admissionv1.Create, admissionv1.Update:
+	default:
+		return response
+	}
+
+	ctx = ac.withContext(ctx)
+	logger := logging.FromContext(ctx)
+
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(request.Object.Raw, obj); err != nil {
+		return webhook.MakeErrorStatus("cannot decode incoming new object: %v", err)
+	}
+
+	var errs *apis.FieldError
+	for _, p := range FromContext(ctx).Policies {
+		if !p.appliesTo(request.Kind.Kind, request.Namespace) {
+			continue
+		}
+		err := p.check(obj.Object)
+		if err == nil {
+			continue
+		}
+		switch p.Mode {
+		case Enforce:
+			errs = errs.Also(err)
+		case Warn:
+			response.Warnings = append(response.Warnings, err.Error())
+		case Audit:
+			logger.Infow("Admitted an object violating an audited policy", zap.String("policy", p.Name), zap.Error(err))
+			if response.AuditAnnotations == nil {
+				response.AuditAnnotations = make(map[string]string, 1)
+			}
+			response.AuditAnnotations[p.Name] = err.Error()
+		}
+	}
+	if errs != nil {
+		// Keep the warnings of the other policies and of the inner webhook.
+		denied := webhook.MakeErrorStatus("validation failed: %v", errs)
+		denied.Warnings = response.Warnings
+		return denied
+	}
+	return response
+}
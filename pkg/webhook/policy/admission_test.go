@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/webhook"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const (
+	maxScalePolicy = `
+kinds: ["Service"]
+namespaces: ["team-a"]
+expression: "int(object.spec.template.metadata.annotations['autoscaling.knative.dev/max-scale']) <= 50"
+field: spec.template.metadata.annotations
+message: max-scale must be at most 50`
+	timeoutPolicy = `
+expression: "!has(object.spec.template.spec.timeoutSeconds) || object.spec.template.spec.timeoutSeconds < 300"
+field: spec.template.spec.timeoutSeconds
+message: timeoutSeconds must be under 300`
+)
+
+var (
+	// hundred is a CEL list of a hundred numbers.
+	hundred = "[" + strings.TrimSuffix(strings.Repeat("1,", 100), ",") + "]"
+	// loopPolicy evaluates a million iterations.
+	loopPolicy = `
+expression: "` + hundred + `.all(a, ` + hundred + `.all(b, ` + hundred + `.all(c, a + b + c >= 0)))"
+field: spec`
+)
+
+func TestAdmit(t *testing.T) {
+	service := func(maxScale string, timeout int64) runtime.Object {
+		return &v1.Service{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       "Service",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "hello",
+				Namespace: "team-a",
+			},
+			Spec: v1.ServiceSpec{
+				ConfigurationSpec: v1.ConfigurationSpec{
+					Template: v1.RevisionTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"autoscaling.knative.dev/max-scale": maxScale,
+							},
+						},
+						Spec: v1.RevisionSpec{
+							TimeoutSeconds: ptr.Int64(timeout),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		policies      map[string]string
+		invalid       bool
+		request       *admissionv1.AdmissionRequest
+		wantAllowed   bool
+		wantMessage   string
+		wantWarnings  []string
+		wantAuditAnns map[string]string
+	}{{
+		name:        "no policies",
+		request:     request(t, "team-a", service("100", 600)),
+		wantAllowed: true,
+	}, {
+		name:        "satisfied policies",
+		policies:    map[string]string{"max-scale": maxScalePolicy, "timeout": timeoutPolicy},
+		request:     request(t, "team-a", service("10", 60)),
+		wantAllowed: true,
+	}, {
+		name:        "invalid object",
+		policies:    map[string]string{"max-scale": maxScalePolicy},
+		invalid:     true,
+		request:     request(t, "team-a", service("100", 60)),
+		wantMessage: "invalid",
+	}, {
+		name:     "enforced policies",
+		policies: map[string]string{"max-scale": maxScalePolicy, "timeout": timeoutPolicy},
+		request:  request(t, "team-a", service("100", 600)),
+		wantMessage: "validation failed: " +
+			`policy "max-scale": max-scale must be at most 50: spec.template.metadata.annotations` + "\n" +
+			`policy "timeout": timeoutSeconds must be under 300: spec.template.spec.timeoutSeconds`,
+	}, {
+		name:     "enforced and warned policies",
+		policies: map[string]string{"max-scale": maxScalePolicy, "timeout": timeoutPolicy + "\nmode: warn"},
+		request:  request(t, "team-a", service("100", 600)),
+		wantMessage: "validation failed: " +
+			`policy "max-scale": max-scale must be at most 50: spec.template.metadata.annotations`,
+		wantWarnings: []string{
+			`policy "timeout": timeoutSeconds must be under 300: spec.template.spec.timeoutSeconds`,
+		},
+	}, {
+		name:     "evaluation over the cost limit",
+		policies: map[string]string{"loop": loopPolicy},
+		request:  request(t, "team-a", service("10", 60)),
+		wantMessage: "validation failed: " +
+			`policy "loop": failed to evaluate: evaluation exceeded the cost limit of 100000 steps: spec`,
+	}, {
+		name:        "policy of another namespace",
+		policies:    map[string]string{"max-scale": maxScalePolicy},
+		request:     request(t, "team-b", service("100", 60)),
+		wantAllowed: true,
+	}, {
+		name:     "policy of another kind",
+		policies: map[string]string{"max-scale": maxScalePolicy},
+		request: request(t, "team-a", &autoscalingv1alpha1.PodAutoscaler{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: autoscalingv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "PodAutoscaler",
+			},
+		}),
+		wantAllowed: true,
+	}, {
+		name:     "evaluation failure",
+		policies: map[string]string{"max-scale": maxScalePolicy},
+		request:  request(t, "team-a", service("many", 60)),
+		wantMessage: "validation failed: " +
+			`policy "max-scale": failed to evaluate: type conversion error from 'string' to 'int': spec.template.metadata.annotations`,
+	}, {
+		name:        "warned policy",
+		policies:    map[string]string{"timeout": timeoutPolicy + "\nmode: warn"},
+		request:     request(t, "team-a", service("10", 600)),
+		wantAllowed: true,
+		wantWarnings: []string{
+			`policy "timeout": timeoutSeconds must be under 300: spec.template.spec.timeoutSeconds`,
+		},
+	}, {
+		name:        "audited policy",
+		policies:    map[string]string{"timeout": timeoutPolicy + "\nmode: audit"},
+		request:     request(t, "team-a", service("10", 600)),
+		wantAllowed: true,
+		wantAuditAnns: map[string]string{
+			"timeout": `policy "timeout": timeoutSeconds must be under 300: spec.template.spec.timeoutSeconds`,
+		},
+	}, {
+		name:     "status update",
+		policies: map[string]string{"timeout": timeoutPolicy},
+		request: func() *admissionv1.AdmissionRequest {
+			r := request(t, "team-a", service("10", 600))
+			r.SubResource = "status"
+			return r
+		}(),
+		wantAllowed: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewConfigFromConfigMap(&corev1.ConfigMap{Data: tt.policies})
+			if err != nil {
+				t.Fatal("NewConfigFromConfigMap() =", err)
+			}
+			impl := WithPolicies(&controller.Impl{
+				Reconciler: &fakeValidation{invalid: tt.invalid},
+			}, func(ctx context.Context) context.Context {
+				return ToContext(ctx, cfg)
+			})
+
+			got := impl.Reconciler.(webhook.AdmissionController).Admit(logtesting.TestContextWithLogger(t), tt.request)
+			if got.Allowed != tt.wantAllowed {
+				t.Errorf("Allowed = %v, want: %v", got.Allowed, tt.wantAllowed)
+			}
+			if !tt.wantAllowed && got.Result.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want: %q", got.Result.Message, tt.wantMessage)
+			}
+			if !cmp.Equal(got.Warnings, tt.wantWarnings) {
+				t.Error("Warnings (-want, +got):", cmp.Diff(tt.wantWarnings, got.Warnings))
+			}
+			if !cmp.Equal(got.AuditAnnotations, tt.wantAuditAnns) {
+				t.Error("AuditAnnotations (-want, +got):", cmp.Diff(tt.wantAuditAnns, got.AuditAnnotations))
+			}
+		})
+	}
+}
+
+func request(t *testing.T, namespace string, obj runtime.Object) *admissionv1.AdmissionRequest {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal("json.Marshal() =", err)
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Kind: metav1.GroupVersionKind{
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind,
+		},
+		Namespace: namespace,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+// fakeValidation admits the objects unless invalid.
+type fakeValidation struct {
+	webhook.StatelessAdmissionImpl
+	pkgreconciler.LeaderAwareFuncs
+
+	invalid bool
+}
+
+func (*fakeValidation) Reconcile(context.Context, string) error {
+	return nil
+}
+
+func (*fakeValidation) Path() string {
+	return "/resource-validation"
+}
+
+func (f *fakeValidation) Admit(context.Context, *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if f.invalid {
+		return webhook.MakeErrorStatus("invalid")
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
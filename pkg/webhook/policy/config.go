@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/configmap"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigName is the name of the config map of the admission policies.
+	ConfigName = "config-policies"
+
+	// objectVar is the name of the variable holding the admitted object in
+	// the expressions of the policies.
+	objectVar = "object"
+)
+
+// Mode is how a policy treats the objects violating it.
+type Mode string
+
+const (
+	// Enforce rejects the objects violating the policy.
+	Enforce Mode = "enforce"
+	// Warn admits the objects violating the policy, with a warning to the
+	// client.
+	Warn Mode = "warn"
+	// Audit admits the objects violating the policy, with an annotation of
+	// the audit event of the request.
+	Audit Mode = "audit"
+)
+
+// kinds are the kinds of objects the policies may apply to.
+var kinds = sets.NewString("Service", "Configuration", "Route")
+
+// Policy is a CEL expression which the objects it applies to must satisfy.
+type Policy struct {
+	// Name is the key of the policy in the config map.
+	Name string
+	// Kinds are the kinds of objects the policy applies to.
+	Kinds sets.String
+	// Namespaces are the namespaces the policy applies to, all when empty.
+	Namespaces sets.String
+	// Expression evaluates to whether the object bound to `object`
+	// satisfies the policy.
+	Expression string
+	// Field is the path of the field reported with the violations.
+	Field string
+	// Message describes the violations.
+	Message string
+	// Mode is how the policy treats the objects violating it.
+	Mode Mode
+
+	program cel.Program
+}
+
+// policySpec is the YAML form of a Policy in the config map.
+type policySpec struct {
+	Kinds      []string `json:"kinds,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+	Expression string   `json:"expression"`
+	Field      string   `json:"field,omitempty"`
+	Message    string   `json:"message,omitempty"`
+	Mode       Mode     `json:"mode,omitempty"`
+}
+
+// Config is the set of admission policies.
+type Config struct {
+	// Policies are sorted by name.
+	Policies []*Policy
+}
+
+// NewConfigFromConfigMap creates a Config from the supplied ConfigMap, where
+// each key holds a policy.
+func NewConfigFromConfigMap(cm *corev1.ConfigMap) (*Config, error) {
+	env, err := cel.NewEnv(cel.Declarations(
+		decls.NewVar(objectVar, decls.NewMapType(decls.String, decls.Dyn))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CEL environment: %w", err)
+	}
+
+	names := make([]string, 0, len(cm.Data))
+	for name := range cm.Data {
+		if name != configmap.ExampleKey {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	c := &Config{Policies: make([]*Policy, 0, len(names))}
+	for _, name := range names {
+		p, err := newPolicy(env, name, cm.Data[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse policy %q: %w", name, err)
+		}
+		c.Policies = append(c.Policies, p)
+	}
+	return c, nil
+}
+
+func newPolicy(env *cel.Env, name, data string) (*Policy, error) {
+	var spec policySpec
+	if err := yaml.UnmarshalStrict([]byte(data), &spec); err != nil {
+		return nil, err
+	}
+
+	p := &Policy{
+		Name:       name,
+		Kinds:      sets.NewString(spec.Kinds...),
+		Namespaces: sets.NewString(spec.Namespaces...),
+		Expression: spec.Expression,
+		Field:      spec.Field,
+		Message:    spec.Message,
+		Mode:       spec.Mode,
+	}
+	if p.Kinds.Len() == 0 {
+		p.Kinds = kinds
+	} else if unknown := p.Kinds.Difference(kinds); unknown.Len() > 0 {
+		return nil, fmt.Errorf("kinds must be among %v, not %v", kinds.List(), unknown.List())
+	}
+	switch p.Mode {
+	case "":
+		p.Mode = Enforce
+	case Enforce, Warn, Audit:
+	default:
+		return nil, fmt.Errorf("mode must be one of %q, %q or %q, not %q", Enforce, Warn, Audit, p.Mode)
+	}
+	if p.Message == "" {
+		p.Message = "violated " + p.Expression
+	}
+
+	if p.Expression == "" {
+		return nil, fmt.Errorf("expression must not be empty")
+	}
+	ast, iss := env.Compile(p.Expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if !proto.Equal(ast.ResultType(), decls.Bool) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %v", ast.ResultType())
+	}
+	program, err := env.Program(ast, cel.CustomDecorator(decorateCost))
+	if err != nil {
+		return nil, err
+	}
+	p.program = program
+	return p, nil
+}
+
+// appliesTo returns whether the policy applies to the objects of the kind
+// in the namespace.
+func (p *Policy) appliesTo(kind, namespace string) bool {
+	return p.Kinds.Has(kind) && (p.Namespaces.Len() == 0 || p.Namespaces.Has(namespace))
+}
+
+// check returns the violation of the policy by the object, if any. The
+// objects for which the expression fails to evaluate, e.g. because it reads
+// a missing field or exceeds the cost limit, violate the policy.
+func (p *Policy) check(obj map[string]interface{}) *apis.FieldError {
+	act, err := newCostActivation(map[string]interface{}{objectVar: obj})
+	if err != nil {
+		return apis.ErrGeneric(fmt.Sprintf("policy %q: failed to evaluate: %v", p.Name, err), p.Field)
+	}
+	out, _, err := p.program.Eval(act)
+	// The logical operators may absorb the error of the step exceeding
+	// the limit.
+	if act.exceeded() {
+		err = errCostLimit
+	}
+	if err != nil {
+		return apis.ErrGeneric(fmt.Sprintf("policy %q: failed to evaluate: %v", p.Name, err), p.Field)
+	}
+	if ok, _ := out.Value().(bool); !ok {
+		return apis.ErrGeneric(fmt.Sprintf("policy %q: %s", p.Name, p.Message), p.Field)
+	}
+	return nil
+}
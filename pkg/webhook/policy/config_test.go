@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestOurConfig(t *testing.T) {
+	actual, example := ConfigMapsFromTestFile(t, ConfigName)
+
+	if got, err := NewConfigFromConfigMap(actual); err != nil {
+		t.Fatal("NewConfigFromConfigMap(actual) =", err)
+	} else if len(got.Policies) != 0 {
+		t.Errorf("NewConfigFromConfigMap(actual) = %v, want no policies", got.Policies)
+	}
+
+	got, err := NewConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatal("NewConfigFromConfigMap(example) =", err)
+	}
+	names := make([]string, 0, len(got.Policies))
+	for _, p := range got.Policies {
+		names = append(names, p.Name)
+	}
+	if want := []string{"max-scale-team-a", "short-timeouts", "trusted-registry"}; !cmp.Equal(names, want) {
+		t.Errorf("Policies = %v, want: %v", names, want)
+	}
+}
+
+func TestNewConfigFromConfigMap(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data map[string]string
+		want *Policy
+	}{{
+		name: "defaults",
+		data: map[string]string{
+			"short-timeouts": `expression: "object.spec.template.spec.timeoutSeconds < 300"`,
+		},
+		want: &Policy{
+			Name:       "short-timeouts",
+			Kinds:      sets.NewString("Service", "Configuration", "Route"),
+			Namespaces: sets.NewString(),
+			Expression: "object.spec.template.spec.timeoutSeconds < 300",
+			Message:    "violated object.spec.template.spec.timeoutSeconds < 300",
+			Mode:       Enforce,
+		},
+	}, {
+		name: "all fields",
+		data: map[string]string{
+			"short-timeouts": `
+kinds: ["Service"]
+namespaces: ["team-a", "team-b"]
+expression: "object.spec.template.spec.timeoutSeconds < 300"
+field: spec.template.spec.timeoutSeconds
+message: timeoutSeconds must be under 300
+mode: warn`,
+		},
+		want: &Policy{
+			Name:       "short-timeouts",
+			Kinds:      sets.NewString("Service"),
+			Namespaces: sets.NewString("team-a", "team-b"),
+			Expression: "object.spec.template.spec.timeoutSeconds < 300",
+			Field:      "spec.template.spec.timeoutSeconds",
+			Message:    "timeoutSeconds must be under 300",
+			Mode:       Warn,
+		},
+	}, {
+		name: "unknown field",
+		data: map[string]string{
+			"short-timeouts": `
+expression: "true"
+severity: high`,
+		},
+	}, {
+		name: "missing expression",
+		data: map[string]string{
+			"short-timeouts": `mode: audit`,
+		},
+	}, {
+		name: "unparsable expression",
+		data: map[string]string{
+			"short-timeouts": `expression: "object.spec.template.spec.timeoutSeconds <"`,
+		},
+	}, {
+		name: "expression not a bool",
+		data: map[string]string{
+			"short-timeouts": `expression: "size(object.spec.template.spec.containers)"`,
+		},
+	}, {
+		name: "unknown kind",
+		data: map[string]string{
+			"short-timeouts": `
+kinds: ["Revision"]
+expression: "true"`,
+		},
+	}, {
+		name: "unknown mode",
+		data: map[string]string{
+			"short-timeouts": `
+expression: "true"
+mode: deny`,
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfigFromConfigMap(&corev1.ConfigMap{Data: tt.data})
+			if (err != nil) != (tt.want == nil) {
+				t.Fatal("Unexpected error value:", err)
+			}
+			if tt.want == nil {
+				return
+			}
+
+			if len(got.Policies) != 1 {
+				t.Fatalf("Policies = %v, want one", got.Policies)
+			}
+			if !cmp.Equal(tt.want, got.Policies[0], cmpopts.IgnoreUnexported(Policy{})) {
+				t.Error("Policy (-want, +got):", cmp.Diff(tt.want, got.Policies[0], cmpopts.IgnoreUnexported(Policy{})))
+			}
+		})
+	}
+}
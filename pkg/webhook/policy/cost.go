@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
+)
+
+// costLimit bounds the steps evaluated by the expression of a policy for
+// an object, so that the expressions looping over large objects don't hold
+// up the admission of every object.
+const costLimit = 100000
+
+// errCostLimit is the error of the evaluations exceeding costLimit.
+var errCostLimit = fmt.Errorf("evaluation exceeded the cost limit of %d steps", costLimit)
+
+// costActivation is the activation of a single evaluation, which counts
+// its steps.
+type costActivation struct {
+	interpreter.Activation
+	steps int
+}
+
+// exceeded returns whether the evaluation exceeded costLimit.
+func (a *costActivation) exceeded() bool {
+	return a.steps > costLimit
+}
+
+// newCostActivation returns the activation binding vars for a single
+// evaluation.
+func newCostActivation(vars map[string]interface{}) (*costActivation, error) {
+	act, err := interpreter.NewActivation(vars)
+	if err != nil {
+		return nil, err
+	}
+	return &costActivation{Activation: act}, nil
+}
+
+// costOf returns the costActivation of the evaluation the activation is
+// nested in, e.g. by the variables of a comprehension.
+func costOf(act interpreter.Activation) *costActivation {
+	for ; act != nil; act = act.Parent() {
+		if ca, ok := act.(*costActivation); ok {
+			return ca
+		}
+	}
+	return nil
+}
+
+// costStep counts the evaluations of an expression step, and fails them
+// once the evaluation exceeded costLimit.
+type costStep struct {
+	interpreter.Interpretable
+}
+
+// Eval implements interpreter.Interpretable.
+func (s *costStep) Eval(act interpreter.Activation) ref.Val {
+	if ca := costOf(act); ca != nil {
+		ca.steps++
+		if ca.exceeded() {
+			return types.NewErr(errCostLimit.Error())
+		}
+	}
+	return s.Interpretable.Eval(act)
+}
+
+// decorateCost wraps the steps of the program in costStep. The attributes
+// and constants are left alone, since the planner inspects them. Every
+// iteration of a comprehension evaluates a call, so the loops are still
+// counted.
+func decorateCost(i interpreter.Interpretable) (interpreter.Interpretable, error) {
+	switch i.(type) {
+	case interpreter.InterpretableAttribute, interpreter.InterpretableConst:
+		return i, nil
+	default:
+		return &costStep{Interpretable: i}, nil
+	}
+}
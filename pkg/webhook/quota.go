@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/config"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/deployment"
+	revisionresources "knative.dev/serving/pkg/reconciler/revision/resources"
+	"knative.dev/serving/pkg/resources"
+)
+
+// quotasKey is used as the key for associating the ResourceQuota lister
+// with the context.
+type quotasKey struct{}
+
+// quotas holds the lister of the ResourceQuotas and the configuration of the
+// queue-proxy containers, which count against the quotas too.
+type quotas struct {
+	lister     corev1listers.ResourceQuotaLister
+	deployment *deployment.Config
+}
+
+// WithResourceQuotas attaches the lister of the ResourceQuotas, which the
+// max-scale of the revision templates is checked against, to the context.
+// The resources of the queue-proxy containers are derived from the
+// deploymentConfig, if any.
+func WithResourceQuotas(ctx context.Context, lister corev1listers.ResourceQuotaLister, deploymentConfig *deployment.Config) context.Context {
+	return context.WithValue(ctx, quotasKey{}, quotas{
+		lister:     lister,
+		deployment: deploymentConfig,
+	})
+}
+
+// warnQuotaHeadroom warns when the headroom of the ResourceQuotas of the
+// namespace admits fewer pods of the revision template than its max-scale,
+// in which case the scale fails silently beyond the headroom. The pods need
+// at least the resources of the containers of the template and of their
+// queue-proxy container.
+func warnQuotaHeadroom(ctx context.Context, uns *unstructured.Unstructured) {
+	q, ok := ctx.Value(quotasKey{}).(quotas)
+	if !ok {
+		return
+	}
+	logger := logging.FromContext(ctx)
+
+	val, found, err := unstructured.NestedFieldNoCopy(uns.UnstructuredContent(), "spec", "template")
+	if err != nil || !found || templateUnchanged(ctx, val) {
+		return
+	}
+	templ, err := decodeTemplate(val)
+	if err != nil {
+		return
+	}
+
+	key, v, ok := autoscaling.MaxScaleAnnotation.Get(templ.Annotations)
+	maxScale := int64(config.FromContextOrDefaults(ctx).Autoscaler.MaxScale)
+	if ok {
+		if maxScale, err = strconv.ParseInt(v, 10, 32); err != nil {
+			return
+		}
+	}
+	if maxScale <= 0 {
+		return
+	}
+
+	rqs, err := q.lister.ResourceQuotas(uns.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		logger.Warnw("Failed to list the ResourceQuotas", zap.Error(err))
+		return
+	}
+	podSpec := &templ.Spec.PodSpec
+	if q.deployment != nil {
+		rev := &v1.Revision{ObjectMeta: templ.ObjectMeta, Spec: templ.Spec}
+		podSpec = podSpec.DeepCopy()
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{
+			Name:      revisionresources.QueueContainerName,
+			Resources: revisionresources.BuildQueueResources(rev, q.deployment),
+		})
+	}
+	if pods, quota := resources.PodsWithinQuotas(rqs, podSpec); pods >= 0 && pods < maxScale {
+		serving.Warn(ctx, &apis.FieldError{
+			Message: fmt.Sprintf("max-scale=%d exceeds the %d pods the ResourceQuota %q has room for", maxScale, pods, quota),
+			Paths:   []string{"spec.template.metadata.annotations." + key},
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/deployment"
+)
+
+func TestWarnQuotaHeadroom(t *testing.T) {
+	service := func(maxScale string) *v1.Service {
+		s := &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "foo",
+			},
+			Spec: v1.ServiceSpec{
+				ConfigurationSpec: v1.ConfigurationSpec{
+					Template: v1.RevisionTemplateSpec{
+						Spec: v1.RevisionSpec{
+							PodSpec: corev1.PodSpec{
+								Containers: []corev1.Container{{
+									Image: "busybox",
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU: resource.MustParse("500m"),
+										},
+									},
+								}},
+							},
+						},
+					},
+				},
+			},
+		}
+		if maxScale != "" {
+			s.Spec.Template.Annotations = map[string]string{
+				autoscaling.MaxScaleAnnotationKey: maxScale,
+			}
+		}
+		return s
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(&corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "compute",
+			Namespace: "foo",
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("1"),
+			},
+		},
+	})
+	lister := corev1listers.NewResourceQuotaLister(indexer)
+	queueCPU := resource.MustParse("100m")
+
+	tests := []struct {
+		name       string
+		service    *v1.Service
+		old        *v1.Service
+		lister     corev1listers.ResourceQuotaLister
+		deployment *deployment.Config
+		want       string
+	}{{
+		name:    "no lister",
+		service: service("10"),
+	}, {
+		name:    "within the headroom",
+		service: service("6"),
+		lister:  lister,
+	}, {
+		name:    "unlimited max-scale",
+		service: service(""),
+		lister:  lister,
+	}, {
+		name:    "beyond the headroom",
+		service: service("10"),
+		lister:  lister,
+		want: `max-scale=10 exceeds the 6 pods the ResourceQuota "compute" has room for: ` +
+			"spec.template.metadata.annotations.autoscaling.knative.dev/max-scale",
+	}, {
+		name:       "beyond the headroom with the queue-proxy",
+		service:    service("6"),
+		lister:     lister,
+		deployment: &deployment.Config{QueueSidecarCPURequest: &queueCPU},
+		want: `max-scale=6 exceeds the 5 pods the ResourceQuota "compute" has room for: ` +
+			"spec.template.metadata.annotations.autoscaling.knative.dev/max-scale",
+	}, {
+		name:    "unchanged template",
+		service: service("10"),
+		old:     service("10"),
+		lister:  lister,
+	}, {
+		name:    "other namespace",
+		service: func() *v1.Service { s := service("10"); s.Namespace = "bar"; return s }(),
+		lister:  lister,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := serving.WithWarnings(logtesting.TestContextWithLogger(t))
+			if test.lister != nil {
+				ctx = WithResourceQuotas(ctx, test.lister, test.deployment)
+			}
+			if test.old != nil {
+				ctx = apis.WithinUpdate(ctx, test.old)
+			}
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(test.service)
+			if err != nil {
+				t.Fatal("ToUnstructured() =", err)
+			}
+
+			warnQuotaHeadroom(ctx, &unstructured.Unstructured{Object: content})
+			if got := serving.GetWarnings(ctx).Error(); got != test.want {
+				t.Errorf("Warnings = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateServiceWarnsQuotaHeadroom(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(&corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pods",
+			Namespace: "foo",
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("2"),
+			},
+		},
+	})
+	ctx := serving.WithWarnings(logtesting.TestContextWithLogger(t))
+	ctx = WithResourceQuotas(ctx, corev1listers.NewResourceQuotaLister(indexer), nil)
+
+	uns := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":      "valid",
+			"namespace": "foo",
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						autoscaling.MaxScaleAnnotationKey: "3",
+					},
+				},
+			},
+		},
+	}}
+	if err := ValidateService(ctx, uns); err != nil {
+		t.Fatal("ValidateService() =", err)
+	}
+	want := `max-scale=3 exceeds the 2 pods the ResourceQuota "pods" has room for: ` +
+		"spec.template.metadata.annotations.autoscaling.knative.dev/max-scale"
+	if got := serving.GetWarnings(ctx).Error(); got != want {
+		t.Errorf("Warnings = %q, want: %q", got, want)
+	}
+}
@@ -46,6 +46,7 @@ const (
 
 // ValidateService runs extra validation on Service resources
 func ValidateService(ctx context.Context, uns *unstructured.Unstructured) error {
+	warnQuotaHeadroom(ctx, uns)
 	return validateRevisionTemplate(ctx, uns)
 }
 
@@ -56,6 +57,7 @@ func ValidateConfiguration(ctx context.Context, uns *unstructured.Unstructured)
 		return nil
 	}
 
+	warnQuotaHeadroom(ctx, uns)
 	return validateRevisionTemplate(ctx, uns)
 }
 
@@ -63,7 +65,7 @@ func validateRevisionTemplate(ctx context.Context, uns *unstructured.Unstructure
 	content := uns.UnstructuredContent()
 
 	mode := DryRunMode(uns.GetAnnotations()[PodSpecDryRunAnnotation])
-	features := config.FromContextOrDefaults(ctx).Features
+	features := config.FromContextOrDefaults(config.WithinNamespace(ctx, uns.GetNamespace())).Features
 	switch features.PodSpecDryRun {
 	case config.Enabled:
 		if mode != DryRunStrict {
@@ -101,14 +103,23 @@ func validateRevisionTemplate(ctx context.Context, uns *unstructured.Unstructure
 		return nil // Don't need to validate empty templates
 	}
 
-	if apis.IsInUpdate(ctx) {
-		if uns, err := runtime.DefaultUnstructuredConverter.ToUnstructured(apis.GetBaseline(ctx)); err == nil {
-			if oldVal, found, _ := unstructured.NestedFieldNoCopy(uns, "spec", "template"); found &&
-				equality.Semantic.DeepEqual(val, oldVal) {
-				return nil // Don't validate no-change updates.
-			}
-		}
+	if templateUnchanged(ctx, val) {
+		return nil // Don't validate no-change updates.
 	}
 
 	return validatePodSpec(ctx, templ.Spec, namespace, mode)
 }
+
+// templateUnchanged returns whether the update keeps the spec.template val
+// of the baseline.
+func templateUnchanged(ctx context.Context, val interface{}) bool {
+	if !apis.IsInUpdate(ctx) {
+		return false
+	}
+	uns, err := runtime.DefaultUnstructuredConverter.ToUnstructured(apis.GetBaseline(ctx))
+	if err != nil {
+		return false
+	}
+	oldVal, found, _ := unstructured.NestedFieldNoCopy(uns, "spec", "template")
+	return found && equality.Semantic.DeepEqual(val, oldVal)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"knative.dev/pkg/controller"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/webhook"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+// ResourceReconciler is the reconciler of a resource admission controller,
+// e.g. of defaulting.NewAdmissionController or
+// validation.NewAdmissionController.
+type ResourceReconciler interface {
+	controller.Reconciler
+	pkgreconciler.LeaderAware
+	webhook.AdmissionController
+	webhook.StatelessAdmissionController
+}
+
+// warningsAdmissionController returns the warnings reported while its
+// ResourceReconciler admits the objects to the clients.
+type warningsAdmissionController struct {
+	ResourceReconciler
+}
+
+// WithWarnings extends the resource admission controller impl to return the
+// warnings reported by the defaulting and validation of the objects, see
+// serving.Warn, to the clients.
+func WithWarnings(impl *controller.Impl) *controller.Impl {
+	impl.Reconciler = &warningsAdmissionController{
+		ResourceReconciler: impl.Reconciler.(ResourceReconciler),
+	}
+	return impl
+}
+
+// Admit implements AdmissionController
+func (ac *warningsAdmissionController) Admit(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	// The subresources, e.g. the status, are updated by the controllers.
+	if request.SubResource != "" {
+		return ac.ResourceReconciler.Admit(ctx, request)
+	}
+
+	ctx = serving.WithWarnings(ctx)
+	response := ac.ResourceReconciler.Admit(ctx, request)
+	// The warnings carry no details, so each line of their message is a
+	// warning of its own.
+	if warnings := serving.GetWarnings(ctx); warnings != nil {
+		response.Warnings = append(response.Warnings, strings.Split(warnings.Error(), "\n")...)
+	}
+	return response
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/webhook"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+func TestWithWarnings(t *testing.T) {
+	tests := []struct {
+		name        string
+		warnings    *apis.FieldError
+		subResource string
+		want        []string
+	}{{
+		name: "no warnings",
+	}, {
+		name: "warnings",
+		warnings: apis.ErrGeneric("stale", "metadata.annotations").Also(
+			apis.ErrGeneric("risky", "spec.containerConcurrency")),
+		want: []string{
+			"from the policies",
+			"risky: spec.containerConcurrency",
+			"stale: metadata.annotations",
+		},
+	}, {
+		name:        "status update",
+		warnings:    apis.ErrGeneric("stale", "metadata.annotations"),
+		subResource: "status",
+		want:        []string{"from the policies"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			impl := WithWarnings(&controller.Impl{
+				Reconciler: &fakeAdmission{warnings: test.warnings},
+			})
+
+			got := impl.Reconciler.(webhook.AdmissionController).Admit(logtesting.TestContextWithLogger(t),
+				&admissionv1.AdmissionRequest{SubResource: test.subResource})
+			if !got.Allowed {
+				t.Error("Allowed = false, want: true")
+			}
+			if !cmp.Equal(got.Warnings, test.want) {
+				t.Error("Warnings (-want, +got):", cmp.Diff(test.want, got.Warnings))
+			}
+		})
+	}
+}
+
+// fakeAdmission admits the objects, reporting its warnings and those of the
+// policies, if any.
+type fakeAdmission struct {
+	webhook.StatelessAdmissionImpl
+	pkgreconciler.LeaderAwareFuncs
+
+	warnings *apis.FieldError
+}
+
+func (*fakeAdmission) Reconcile(context.Context, string) error {
+	return nil
+}
+
+func (*fakeAdmission) Path() string {
+	return "/resource-validation"
+}
+
+func (f *fakeAdmission) Admit(ctx context.Context, _ *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	serving.Warn(ctx, f.warnings)
+	response := &admissionv1.AdmissionResponse{Allowed: true}
+	if f.warnings != nil {
+		response.Warnings = []string{"from the policies"}
+	}
+	return response
+}